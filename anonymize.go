@@ -0,0 +1,139 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// AnonymizeOptions configure AnonymizeWithOptions.
+type AnonymizeOptions struct {
+	// Seed of the shuffle of vars and constraints. The same seed shuffles
+	// the same model the same way.
+	Seed int64 `json:"seed"`
+	// Scale multiplies each constraint and the objective by a random power
+	// of two, which hides coefficients while keeping the model equivalent:
+	// scaling by a power of two is exact in floating point and the optimal
+	// solutions do not change. The objective value is scaled by the same
+	// factor as the objective.
+	Scale bool `json:"scale"`
+}
+
+// Anonymize returns a copy of model which can be shared, e.g. with solver
+// vendors to report a bug, without leaking business data. It is
+// AnonymizeWithOptions with seed 0 and without scaling.
+func Anonymize(model Model) Model {
+	return AnonymizeWithOptions(model, AnonymizeOptions{})
+}
+
+// AnonymizeWithOptions returns a copy of model without names, groups and
+// attributes of vars and constraints, with vars and constraints shuffled
+// deterministically and optionally with scaled coefficients. Types, bounds
+// and fixed values of vars are preserved, so the returned model is
+// equivalent to model.
+//
+//	anonymized := mip.AnonymizeWithOptions(model, mip.AnonymizeOptions{Seed: 7, Scale: true})
+//	err := mip.WriteModelFile("bug-report.lp.gz", anonymized)
+func AnonymizeWithOptions(model Model, options AnonymizeOptions) Model {
+	r := rand.New(rand.NewSource(options.Seed))
+	scale := func() float64 {
+		if !options.Scale {
+			return 1
+		}
+		return math.Ldexp(1, r.Intn(5)-2)
+	}
+
+	vars := model.Vars()
+	anonymized := NewModel()
+	mapping := make(Vars, len(vars))
+	for _, i := range r.Perm(len(vars)) {
+		mapping[i] = newVarLike(anonymized, vars[i])
+	}
+
+	objective := anonymized.Objective()
+	if model.Objective().IsMaximize() {
+		objective.SetMaximize()
+	}
+	objectiveScale := scale()
+	for _, t := range sortedMappedTerms(model.Objective().Terms(), mapping) {
+		objective.NewTerm(objectiveScale*t.Coefficient, t.Var)
+	}
+	addMappedQuadraticTerms(objective, model.Objective().QuadraticTerms(), mapping, objectiveScale)
+
+	constraints := model.Constraints()
+	for _, i := range r.Perm(len(constraints)) {
+		c := constraints[i]
+		factor := scale()
+		anonymizedConstraint := anonymized.NewConstraint(c.Sense(), factor*c.RightHandSide())
+		for _, t := range sortedMappedTerms(c.Terms(), mapping) {
+			anonymizedConstraint.NewTerm(factor*t.Coefficient, t.Var)
+		}
+	}
+	return anonymized
+}
+
+// newVarLike adds a var to model with the type, bounds and fixed value of v.
+func newVarLike(model Model, v Var) Var {
+	var anonymized Var
+	switch v.Type() {
+	case Binary:
+		anonymized = model.NewBool()
+	case Integer:
+		anonymized = model.NewInt(intLowerBound(v.LowerBound()), intUpperBound(v.UpperBound()))
+	default:
+		anonymized = model.NewFloat(v.LowerBound(), v.UpperBound())
+	}
+	if value, ok := v.FixedValue(); ok {
+		anonymized.Fix(value)
+	}
+	return anonymized
+}
+
+// sortedMappedTerms returns terms with their vars replaced by mapping, indexed
+// by Var.Index, ordered by the index of the replacing vars.
+func sortedMappedTerms(terms Terms, mapping Vars) []TermSpec {
+	specs := make([]TermSpec, len(terms))
+	for i, t := range terms {
+		specs[i] = TermSpec{
+			Coefficient: t.Coefficient(),
+			Var:         mapping[t.Var().Index()],
+		}
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Var.Index() < specs[j].Var.Index()
+	})
+	return specs
+}
+
+// addMappedQuadraticTerms adds terms scaled by factor to objective, with their
+// vars replaced by mapping, indexed by Var.Index, ordered by the indices of
+// the replacing vars.
+func addMappedQuadraticTerms(
+	objective Objective,
+	terms QuadraticTerms,
+	mapping Vars,
+	factor float64,
+) {
+	type quadraticSpec struct {
+		coefficient float64
+		var1, var2  Var
+	}
+	specs := make([]quadraticSpec, len(terms))
+	for i, t := range terms {
+		v1, v2 := mapping[t.Var1().Index()], mapping[t.Var2().Index()]
+		if v1.Index() > v2.Index() {
+			v1, v2 = v2, v1
+		}
+		specs[i] = quadraticSpec{t.Coefficient(), v1, v2}
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		a, b := specs[i], specs[j]
+		return a.var1.Index() < b.var1.Index() ||
+			(a.var1.Index() == b.var1.Index() && a.var2.Index() < b.var2.Index())
+	})
+	for _, t := range specs {
+		objective.NewQuadraticTerm(factor*t.coefficient, t.var1, t.var2)
+	}
+}
@@ -0,0 +1,109 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func anonymizeModel() mip.Model {
+	model := mip.NewModel()
+	vars := mip.Vars{model.NewFloat(0, 10), model.NewInt(1, 5), model.NewBool()}
+	for i, v := range vars {
+		v.SetName([]string{"revenue", "trucks", "open_depot"}[i])
+	}
+	vars[2].Fix(1)
+	model.Objective().SetMaximize()
+	model.Objective().NewTerm(3, vars[0])
+	model.Objective().NewQuadraticTerm(1, vars[1], vars[0])
+	for i := 0; i < 4; i++ {
+		c := model.NewConstraint(mip.LessThanOrEqual, float64(10+i))
+		c.SetNamef("customer_%d", i)
+		for j, v := range vars {
+			c.NewTerm(float64(i+j+1), v)
+		}
+	}
+	return model
+}
+
+func lp(t *testing.T, model mip.Model) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := mip.WriteLP(&b, model); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestAnonymize(t *testing.T) {
+	model := anonymizeModel()
+	anonymized := mip.Anonymize(model)
+
+	text := lp(t, anonymized)
+	for _, name := range []string{"revenue", "trucks", "open_depot", "customer"} {
+		if strings.Contains(text, name) {
+			t.Errorf("anonymized model contains %q:\n%s", name, text)
+		}
+	}
+	if len(anonymized.Vars()) != 3 || len(anonymized.Constraints()) != 4 {
+		t.Fatalf("got %d vars and %d constraints, want 3 and 4",
+			len(anonymized.Vars()), len(anonymized.Constraints()))
+	}
+	if !anonymized.Objective().IsMaximize() || !anonymized.Objective().IsQuadratic() {
+		t.Errorf("anonymized objective is not a maximized quadratic objective")
+	}
+	fixed := 0
+	for _, v := range anonymized.Vars() {
+		if value, ok := v.FixedValue(); ok && value == 1 && v.IsBool() {
+			fixed++
+		}
+	}
+	if fixed != 1 {
+		t.Errorf("got %d fixed bool vars, want 1", fixed)
+	}
+
+	if lp(t, mip.Anonymize(model)) != text {
+		t.Errorf("anonymizing the same model twice differs")
+	}
+	if !strings.Contains(lp(t, model), "revenue") {
+		t.Errorf("anonymizing modified the model")
+	}
+}
+
+func TestAnonymizeScale(t *testing.T) {
+	model := anonymizeModel()
+	anonymized := mip.AnonymizeWithOptions(model, mip.AnonymizeOptions{Seed: 3, Scale: true})
+	for _, c := range anonymized.Constraints() {
+		for _, term := range c.Terms() {
+			ratio := c.RightHandSide() / term.Coefficient()
+			found := false
+			for _, original := range model.Constraints() {
+				for _, o := range original.Terms() {
+					found = found || ratio == original.RightHandSide()/o.Coefficient()
+				}
+			}
+			if !found {
+				t.Errorf("constraint %v is not a scaled original constraint", c)
+			}
+		}
+	}
+	coefficient := anonymized.Objective().Terms()[0].Coefficient() / 3
+	if _, exponent := math.Frexp(coefficient); coefficient != math.Ldexp(0.5, exponent) {
+		t.Errorf("objective is scaled by %v, not a power of two", coefficient)
+	}
+}
+
+func TestAnonymizeRetypedVar(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0.5, 4.5)
+	model.SetVarType(x, mip.Integer)
+	v := mip.Anonymize(model).Vars()[0]
+	if !v.IsInt() || v.LowerBound() != 1 || v.UpperBound() != 4 {
+		t.Errorf("got var %v in [%v, %v], want int in [1, 4]", v, v.LowerBound(), v.UpperBound())
+	}
+}
@@ -0,0 +1,131 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "sync"
+
+// arenaChunk is the number of vars, constraints or terms allocated at once
+// by an arena.
+const arenaChunk = 1024
+
+// NewModelWithArena creates a new model which allocates its vars,
+// constraints and constraint terms in chunks taken from pools shared by all
+// arena models, see Model.Free. Services which build and discard many
+// models reuse the chunks instead of leaving them to the garbage collector.
+// Copies and forks of the model do not use the arena, Fork copies the model
+// like Copy so it stays valid when the model is freed.
+//
+//	model := mip.NewModelWithArena(mip.ModelLimits{})
+//	defer model.Free()
+func NewModelWithArena(limits ModelLimits) Model {
+	m := NewModelWithLimits(limits).(*model)
+	m.arena = &arena{}
+	return m
+}
+
+func (m *model) Free() {
+	m.mustBeMutable()
+	if m.arena == nil {
+		return
+	}
+	a := m.arena
+	a.free()
+	*m = *NewModelWithLimits(m.limits).(*model)
+	m.arena = a
+}
+
+// arena allocates the vars, constraints and terms of a model. A nil arena
+// allocates them individually.
+type arena struct {
+	floats      arenaSlab[floatVariable]
+	ints        arenaSlab[intVariable]
+	bools       arenaSlab[boolVariable]
+	constraints arenaSlab[constraint]
+	terms       arenaSlab[term]
+}
+
+var (
+	floatChunks      = newChunkPool[floatVariable]()
+	intChunks        = newChunkPool[intVariable]()
+	boolChunks       = newChunkPool[boolVariable]()
+	constraintChunks = newChunkPool[constraint]()
+	termChunks       = newChunkPool[term]()
+)
+
+func (a *arena) float() *floatVariable {
+	if a == nil {
+		return &floatVariable{}
+	}
+	return a.floats.alloc(floatChunks)
+}
+
+func (a *arena) int() *intVariable {
+	if a == nil {
+		return &intVariable{}
+	}
+	return a.ints.alloc(intChunks)
+}
+
+func (a *arena) bool() *boolVariable {
+	if a == nil {
+		return &boolVariable{}
+	}
+	return a.bools.alloc(boolChunks)
+}
+
+func (a *arena) constraint() *constraint {
+	if a == nil {
+		return &constraint{}
+	}
+	return a.constraints.alloc(constraintChunks)
+}
+
+func (a *arena) term() *term {
+	if a == nil {
+		return &term{}
+	}
+	return a.terms.alloc(termChunks)
+}
+
+// free zeroes the chunks of the arena and returns them to their pools.
+func (a *arena) free() {
+	a.floats.free(floatChunks)
+	a.ints.free(intChunks)
+	a.bools.free(boolChunks)
+	a.constraints.free(constraintChunks)
+	a.terms.free(termChunks)
+}
+
+// arenaSlab hands out the elements of chunks taken from a pool.
+type arenaSlab[T any] struct {
+	chunks  []*[]T
+	current []T
+}
+
+func (s *arenaSlab[T]) alloc(pool *sync.Pool) *T {
+	if len(s.current) == cap(s.current) {
+		chunk := pool.Get().(*[]T)
+		s.chunks = append(s.chunks, chunk)
+		s.current = (*chunk)[:0]
+	}
+	s.current = s.current[:len(s.current)+1]
+	return &s.current[len(s.current)-1]
+}
+
+func (s *arenaSlab[T]) free(pool *sync.Pool) {
+	for _, chunk := range s.chunks {
+		clear(*chunk)
+		pool.Put(chunk)
+	}
+	s.chunks = nil
+	s.current = nil
+}
+
+func newChunkPool[T any]() *sync.Pool {
+	return &sync.Pool{
+		New: func() any {
+			chunk := make([]T, arenaChunk)
+			return &chunk
+		},
+	}
+}
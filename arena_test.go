@@ -0,0 +1,70 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// buildModel adds vars and constraints of every kind to model.
+func buildModel(model mip.Model, size int) {
+	for i := 0; i < size; i++ {
+		x := model.NewFloat(0, float64(i))
+		y := model.NewInt(0, int64(i))
+		z := model.NewBool()
+		c := model.NewConstraint(mip.LessThanOrEqual, float64(i))
+		c.NewTerm(1, x)
+		c.NewTerm(2, y)
+		c.NewTerm(3, z)
+	}
+}
+
+func TestArena(t *testing.T) {
+	want := mip.NewModel()
+	buildModel(want, 2000)
+
+	model := mip.NewModelWithArena(mip.ModelLimits{})
+	buildModel(model, 2000)
+	if got := fmt.Sprint(model); got != fmt.Sprint(want) {
+		t.Errorf("arena model differs from model")
+	}
+
+	fork := model.Fork()
+	model.Free()
+	if got := len(model.Vars()) + len(model.Constraints()); got != 0 {
+		t.Errorf("got %d vars and constraints after free, want 0", got)
+	}
+	if got := fmt.Sprint(fork); got != fmt.Sprint(want) {
+		t.Errorf("fork changed by freeing the model")
+	}
+
+	buildModel(model, 2000)
+	if got := fmt.Sprint(model); got != fmt.Sprint(want) {
+		t.Errorf("arena model reused after free differs from model")
+	}
+
+	// Free does nothing for models without an arena.
+	want.Free()
+	if got := len(want.Vars()); got != 6000 {
+		t.Errorf("got %d vars, want 6000", got)
+	}
+}
+
+func BenchmarkArena(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		model := mip.NewModelWithArena(mip.ModelLimits{})
+		buildModel(model, 1000)
+		model.Free()
+	}
+}
+
+func BenchmarkNoArena(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buildModel(mip.NewModel(), 1000)
+	}
+}
@@ -0,0 +1,33 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+// attributes stores the attributes of the vars and constraints of a model,
+// keyed by entity and attribute key.
+type attributes map[any]map[string]any
+
+func (a attributes) set(entity any, key string, value any) {
+	if value == nil {
+		delete(a[entity], key)
+		if len(a[entity]) == 0 {
+			delete(a, entity)
+		}
+		return
+	}
+	if a[entity] == nil {
+		a[entity] = make(map[string]any)
+	}
+	a[entity][key] = value
+}
+
+// setAttr sets the attribute key of entity, a var or constraint of the
+// invoking model, to value.
+func (m *model) setAttr(entity any, key string, value any) {
+	m.mustBeMutable()
+	m.attributes.set(entity, key, value)
+}
+
+func (a attributes) get(entity any, key string) (any, bool) {
+	value, ok := a[entity][key]
+	return value, ok
+}
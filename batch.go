@@ -0,0 +1,181 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// BatchOptions configure SolveBatch.
+type BatchOptions struct {
+	// Parallelism is the maximum number of models solved at the same time.
+	// Defaults to 1 if not positive.
+	Parallelism int `json:"parallelism"`
+	// Retries is the number of times a run is repeated if creating the
+	// solver or solving fails with an error, e.g. because a license token
+	// is temporarily unavailable. Defaults to 0 if negative.
+	Retries int `json:"retries"`
+}
+
+// BatchRun is the result of solving one model of a batch.
+type BatchRun struct {
+	// Solution of the model, nil if all attempts failed.
+	Solution Solution `json:"-"`
+	// Err is the error of the last attempt, nil if the model was solved.
+	Err error `json:"-"`
+	// Attempts is the number of attempts, 1 plus the number of retries.
+	Attempts int `json:"attempts"`
+	// Duration is the wall-clock time of all attempts of the run.
+	Duration time.Duration `json:"duration"`
+}
+
+// BatchStats aggregates the runs of a batch.
+type BatchStats struct {
+	// Runs is the number of models.
+	Runs int `json:"runs"`
+	// Failed is the number of models which were not solved.
+	Failed int `json:"failed"`
+	// Retries is the total number of retries.
+	Retries int `json:"retries"`
+	// WallClock is the wall-clock time of the batch.
+	WallClock time.Duration `json:"wall_clock"`
+	// Total is the sum of the durations of the runs.
+	Total time.Duration `json:"total"`
+	// Min is the shortest duration of a run.
+	Min time.Duration `json:"min"`
+	// Max is the longest duration of a run.
+	Max time.Duration `json:"max"`
+	// Mean is the mean duration of a run.
+	Mean time.Duration `json:"mean"`
+}
+
+// BatchResult is the result of SolveBatch.
+type BatchResult struct {
+	// Runs has the result per model, in the order of the models.
+	Runs []BatchRun `json:"runs"`
+	// Stats aggregates the runs.
+	Stats BatchStats `json:"stats"`
+}
+
+// Solutions returns the solution per model of the invoking result, nil for
+// models which were not solved.
+func (r BatchResult) Solutions() []Solution {
+	solutions := make([]Solution, len(r.Runs))
+	for i, run := range r.Runs {
+		solutions[i] = run.Solution
+	}
+	return solutions
+}
+
+// Err returns the errors of the failed runs of the invoking result joined,
+// nil if all models were solved.
+func (r BatchResult) Err() error {
+	errs := make([]error, 0, r.Stats.Failed)
+	for _, run := range r.Runs {
+		errs = append(errs, run.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// SolveBatch solves models, e.g. scenario variations of a model, each with
+// a solver of the registered provider, see NewSolver, with bounded
+// parallelism. A run which fails with an error is retried, a failing run
+// does not stop the batch. The solver of each run is closed after the run,
+// see CloseSolver, errors closing it are ignored.
+//
+//	result := mip.SolveBatch(scenarios, "highs", options, mip.BatchOptions{
+//		Parallelism: 8,
+//		Retries:     2,
+//	})
+//	if err := result.Err(); err != nil {
+//		...
+//	}
+func SolveBatch(
+	models []Model,
+	provider SolverProvider,
+	options SolveOptions,
+	batch BatchOptions,
+) BatchResult {
+	start := time.Now()
+	parallelism := batch.Parallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+	retries := max(batch.Retries, 0)
+
+	runs := make([]BatchRun, len(models))
+	semaphore := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, model := range models {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, model Model) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			runs[i] = solveRun(model, provider, options, retries)
+		}(i, model)
+	}
+	wg.Wait()
+
+	return BatchResult{
+		Runs:  runs,
+		Stats: batchStats(runs, time.Since(start)),
+	}
+}
+
+// solveRun solves model, retrying up to retries times on error.
+func solveRun(
+	model Model,
+	provider SolverProvider,
+	options SolveOptions,
+	retries int,
+) BatchRun {
+	start := time.Now()
+	run := BatchRun{}
+	for run.Attempts <= retries {
+		run.Attempts++
+		run.Solution, run.Err = solveOnce(model, provider, options)
+		if run.Err == nil {
+			break
+		}
+	}
+	run.Duration = time.Since(start)
+	return run
+}
+
+func solveOnce(model Model, provider SolverProvider, options SolveOptions) (Solution, error) {
+	solver, err := NewSolver(provider, model)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = CloseSolver(solver)
+	}()
+	return solver.Solve(options)
+}
+
+func batchStats(runs []BatchRun, wallClock time.Duration) BatchStats {
+	stats := BatchStats{
+		Runs:      len(runs),
+		WallClock: wallClock,
+	}
+	for i, run := range runs {
+		if run.Err != nil {
+			stats.Failed++
+		}
+		stats.Retries += run.Attempts - 1
+		stats.Total += run.Duration
+		if i == 0 || run.Duration < stats.Min {
+			stats.Min = run.Duration
+		}
+		if run.Duration > stats.Max {
+			stats.Max = run.Duration
+		}
+	}
+	if len(runs) > 0 {
+		stats.Mean = stats.Total / time.Duration(len(runs))
+	}
+	return stats
+}
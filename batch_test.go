@@ -0,0 +1,82 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestSolveBatch(t *testing.T) {
+	// Models with one var fail on the first attempt, models with two vars
+	// always fail.
+	var mutex sync.Mutex
+	attempts := map[mip.Model]int{}
+	mip.RegisterSolverProvider("test-batch", func(model mip.Model) (mip.Solver, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		attempts[model]++
+		vars := len(model.Vars())
+		if vars == 2 || (vars == 1 && attempts[model] == 1) {
+			return nil, errors.New("license token unavailable")
+		}
+		return &testSolver{solution: newTestSolution(float64(vars), nil)}, nil
+	})
+
+	models := make([]mip.Model, 5)
+	for i := range models {
+		models[i] = mip.NewModel()
+		for j := 0; j < i; j++ {
+			models[i].NewBool()
+		}
+	}
+	result := mip.SolveBatch(models, "test-batch", mip.SolveOptions{}, mip.BatchOptions{
+		Parallelism: 2,
+		Retries:     1,
+	})
+
+	solutions := result.Solutions()
+	for i, solution := range solutions {
+		if i == 2 {
+			if solution != nil || result.Runs[i].Err == nil {
+				t.Errorf("expected run %d to fail", i)
+			}
+			continue
+		}
+		if solution == nil || solution.ObjectiveValue() != float64(i) {
+			t.Errorf("got solution %v for run %d", solution, i)
+		}
+	}
+	if result.Runs[1].Attempts != 2 || result.Runs[3].Attempts != 1 {
+		t.Errorf("got attempts %d and %d, want 2 and 1", result.Runs[1].Attempts, result.Runs[3].Attempts)
+	}
+	stats := result.Stats
+	if stats.Runs != 5 || stats.Failed != 1 || stats.Retries != 2 {
+		t.Errorf("got stats %+v", stats)
+	}
+	if stats.Min > stats.Mean || stats.Mean > stats.Max || stats.Total < stats.Max {
+		t.Errorf("inconsistent timing %+v", stats)
+	}
+	if err := result.Err(); err == nil {
+		t.Errorf("expected the error of the failed run")
+	}
+}
+
+func TestSolveBatchNegativeRetries(t *testing.T) {
+	mip.RegisterSolverProvider("test-batch-retries", func(mip.Model) (mip.Solver, error) {
+		return &testSolver{solution: newTestSolution(1, nil)}, nil
+	})
+
+	models := []mip.Model{mip.NewModel(), mip.NewModel()}
+	result := mip.SolveBatch(models, "test-batch-retries", mip.SolveOptions{}, mip.BatchOptions{
+		Retries: -1,
+	})
+	for i, run := range result.Runs {
+		if run.Attempts != 1 || run.Solution == nil || run.Err != nil {
+			t.Errorf("got run %d %+v, want one successful attempt", i, run)
+		}
+	}
+}
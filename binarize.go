@@ -0,0 +1,35 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Binarize adds the binary expansion of v to model and returns its bits, the
+// least significant first. For the bounds [L, U] of v, the bits are new bool
+// vars b with v = L + sum(2^k b[k]), the linking constraint is added to
+// model. v keeps its bounds, so assignments of the bits exceeding U are
+// infeasible. v is not removed, terms of v can be replaced by the expansion
+// for back-ends or cut routines which need bool vars. Panics if v has an
+// infinite bound.
+//
+//	bits := mip.Binarize(model, trucks)
+func Binarize(model Model, v Int) []Bool {
+	lower, upper := v.LowerBound(), v.UpperBound()
+	if math.IsInf(lower, 0) || math.IsInf(upper, 0) {
+		panic(fmt.Sprintf("%v with infinite bounds can not be binarized", v))
+	}
+
+	width := bits.Len64(uint64(upper - lower))
+	expansion := make([]Bool, width)
+	link := model.NewConstraint(Equal, lower)
+	link.NewTerm(1, v)
+	for k := range expansion {
+		expansion[k] = model.NewBool()
+		link.NewTerm(-math.Ldexp(1, k), expansion[k])
+	}
+	return expansion
+}
@@ -0,0 +1,37 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestBinarize(t *testing.T) {
+	model := mip.NewModel()
+	v := model.NewInt(3, 8)
+	bits := mip.Binarize(model, v)
+	if len(bits) != 3 {
+		t.Fatalf("got %d bits, want 3", len(bits))
+	}
+	if got, want := fmt.Sprint(model.Constraints()[0]), "1 I0 + -1 B1 + -2 B2 + -4 B3 = 3"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Every value of v has exactly one expansion.
+	for value := 3; value <= 8; value++ {
+		assignment := map[mip.Var]float64{v: float64(value)}
+		for k, bit := range bits {
+			assignment[bit] = float64((value - 3) >> k & 1)
+		}
+		if mip.Evaluate(model, assignment).TotalViolation != 0 {
+			t.Errorf("expansion of %d violates the link", value)
+		}
+	}
+
+	if got := mip.Binarize(model, model.NewInt(5, 5)); len(got) != 0 {
+		t.Errorf("got %d bits for a fixed range, want 0", len(got))
+	}
+}
@@ -0,0 +1,228 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Binding is the result of Bind: a var per row and the constraints the rows
+// have coefficients in.
+type Binding[T any] struct {
+	// Rows are the bound rows.
+	Rows []T
+	// Vars has the var of each row, in the order of Rows.
+	Vars Vars
+	// Constraints are the constraints by name.
+	Constraints map[string]Constraint
+}
+
+// bindField is a field of a row struct with a mip tag.
+type bindField struct {
+	index      int
+	key        string
+	constraint string
+}
+
+// Bind adds a var per row of rows, a slice of structs, to model as described
+// by the mip tags of the fields of the struct, so tabular input, e.g. read
+// from a CSV file, declares a model without imperative loops:
+//
+//	type Lane struct {
+//		ID       string  `mip:"name"`
+//		Region   string  `mip:"group"`
+//		Cost     float64 `mip:"objective"`
+//		Capacity float64 `mip:"upper"`
+//		Weight   float64 `mip:"constraint=fleet"`
+//	}
+//
+//	binding, err := mip.Bind(model, lanes, map[string]mip.ConstraintSpec{
+//		"fleet": {Sense: mip.LessThanOrEqual, RightHandSide: 40},
+//	})
+//
+// The tags are
+//
+//	name               the var is named after the field, formatted with %v
+//	group              the var belongs to the group of the string field
+//	lower, upper       bounds of the var
+//	objective          coefficient of the var in the objective
+//	constraint=<name>  coefficient of the var in the constraint name
+//
+// Vars are int vars if the bound fields are integers, float vars if they are
+// floats and bool vars if the struct has no bound fields. Missing bounds are
+// 0 and infinity, fractional bounds of int vars are rounded inwards.
+// Constraints are created for constraints in the order of their names, named
+// after them, with the terms of their specs followed by the non-zero
+// coefficients of the rows. Returns an error if T is not a struct, a tag is
+// unknown or on an unexported field or a field of the wrong kind, a constraint
+// tag names a constraint without spec, or the data of a row is invalid, e.g. a
+// bound is NaN or out of range, see Model.NewIntChecked.
+func Bind[T any](
+	model Model,
+	rows []T,
+	constraints map[string]ConstraintSpec,
+) (Binding[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return Binding[T]{}, fmt.Errorf("bind: %v is not a struct", t)
+	}
+	fields, err := bindFields(t, constraints)
+	if err != nil {
+		return Binding[T]{}, err
+	}
+
+	binding := Binding[T]{
+		Rows:        rows,
+		Vars:        make(Vars, len(rows)),
+		Constraints: make(map[string]Constraint, len(constraints)),
+	}
+	terms := make(map[string][]TermSpec, len(constraints))
+	for i, row := range rows {
+		value := reflect.ValueOf(row)
+		v, err := newBoundVar(model, t, value, fields)
+		if err != nil {
+			return Binding[T]{}, fmt.Errorf("bind: row %d: %w", i, err)
+		}
+		binding.Vars[i] = v
+		for _, f := range fields {
+			field := value.Field(f.index)
+			switch f.key {
+			case "name":
+				v.SetName(fmt.Sprint(field.Interface()))
+			case "group":
+				v.SetGroup(field.String())
+			case "objective":
+				if c := numeric(field); c != 0 {
+					if _, err := model.Objective().NewTermChecked(c, v); err != nil {
+						return Binding[T]{}, fmt.Errorf("bind: row %d: %w", i, err)
+					}
+				}
+			case "constraint":
+				if c := numeric(field); c != 0 {
+					terms[f.constraint] = append(terms[f.constraint], TermSpec{Coefficient: c, Var: v})
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(constraints))
+	for name := range constraints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		spec := constraints[name]
+		if spec.Skip {
+			continue
+		}
+		c, err := model.NewConstraintChecked(spec.Sense, spec.RightHandSide)
+		if err != nil {
+			return Binding[T]{}, fmt.Errorf("bind: constraint %s: %w", name, err)
+		}
+		c.SetName(name)
+		for _, term := range append(spec.Terms, terms[name]...) {
+			if _, err := c.NewTermChecked(term.Coefficient, term.Var); err != nil {
+				return Binding[T]{}, fmt.Errorf("bind: constraint %s: %w", name, err)
+			}
+		}
+		binding.Constraints[name] = c
+	}
+	return binding, nil
+}
+
+// bindFields returns the tagged fields of t.
+func bindFields(t reflect.Type, constraints map[string]ConstraintSpec) ([]bindField, error) {
+	var fields []bindField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("mip")
+		if !ok {
+			continue
+		}
+		f := bindField{index: i, key: tag}
+		if name, found := strings.CutPrefix(tag, "constraint="); found {
+			if _, ok := constraints[name]; !ok {
+				return nil, fmt.Errorf("bind: field %s: constraint %q has no spec", sf.Name, name)
+			}
+			f.key, f.constraint = "constraint", name
+		}
+		if err := checkBindField(sf, f.key); err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func checkBindField(sf reflect.StructField, key string) error {
+	if !sf.IsExported() {
+		return fmt.Errorf("bind: field %s is not exported", sf.Name)
+	}
+	switch key {
+	case "name":
+		return nil
+	case "group":
+		if sf.Type.Kind() != reflect.String {
+			return fmt.Errorf("bind: field %s: group must be a string", sf.Name)
+		}
+		return nil
+	case "lower", "upper", "objective", "constraint":
+		if !isInteger(sf.Type.Kind()) && !isFloat(sf.Type.Kind()) {
+			return fmt.Errorf("bind: field %s: %s must be a number", sf.Name, key)
+		}
+		return nil
+	}
+	return fmt.Errorf("bind: field %s: unknown tag %q", sf.Name, key)
+}
+
+// newBoundVar adds the var of a row to model, see Bind.
+func newBoundVar(model Model, t reflect.Type, row reflect.Value, fields []bindField) (Var, error) {
+	lower, upper := 0.0, Infinity()
+	bounded, integer := false, false
+	for _, f := range fields {
+		if f.key != "lower" && f.key != "upper" {
+			continue
+		}
+		bounded = true
+		integer = isInteger(t.Field(f.index).Type.Kind())
+		if f.key == "lower" {
+			lower = numeric(row.Field(f.index))
+		} else {
+			upper = numeric(row.Field(f.index))
+		}
+	}
+	if err := checkNaN("lower bound", lower); err != nil {
+		return nil, err
+	}
+	if err := checkNaN("upper bound", upper); err != nil {
+		return nil, err
+	}
+	switch {
+	case !bounded:
+		return model.NewBoolChecked()
+	case integer:
+		return model.NewIntChecked(intLowerBound(lower), intUpperBound(upper))
+	}
+	return model.NewFloatChecked(lower, upper)
+}
+
+func isInteger(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Uint64
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func numeric(value reflect.Value) float64 {
+	switch {
+	case value.CanInt():
+		return float64(value.Int())
+	case value.CanUint():
+		return float64(value.Uint())
+	}
+	return value.Float()
+}
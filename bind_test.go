@@ -0,0 +1,135 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+type lane struct {
+	ID       string  `mip:"name"`
+	Region   string  `mip:"group"`
+	Cost     float64 `mip:"objective"`
+	Capacity float64 `mip:"upper"`
+	Weight   float64 `mip:"constraint=fleet"`
+	Note     string
+}
+
+func TestBind(t *testing.T) {
+	model := mip.NewModel()
+	lanes := []lane{
+		{ID: "a", Region: "north", Cost: 3, Capacity: 10, Weight: 2},
+		{ID: "b", Region: "south", Cost: 5, Capacity: 20},
+	}
+	extra := model.NewFloat(0, 1)
+	binding, err := mip.Bind(model, lanes, map[string]mip.ConstraintSpec{
+		"fleet": {
+			Sense:         mip.LessThanOrEqual,
+			RightHandSide: 40,
+			Terms:         []mip.TermSpec{{Coefficient: 1, Var: extra}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := binding.Vars[0], binding.Vars[1]
+	if a.Name() != "a" || a.Group() != "north" || !a.IsFloat() || a.UpperBound() != 10 {
+		t.Errorf("got var %v in group %q with bounds [%v, %v]", a, a.Group(), a.LowerBound(), a.UpperBound())
+	}
+	if term, _ := model.Objective().Term(b); term.Coefficient() != 5 {
+		t.Errorf("got objective coefficient %v, want 5", term.Coefficient())
+	}
+	fleet := binding.Constraints["fleet"]
+	if fleet.Name() != "fleet" || len(fleet.Terms()) != 2 {
+		t.Fatalf("got constraint %v named %q", fleet, fleet.Name())
+	}
+	if term, _ := fleet.Term(a); term.Coefficient() != 2 {
+		t.Errorf("got fleet coefficient %v, want 2", term.Coefficient())
+	}
+}
+
+func TestBindVarTypes(t *testing.T) {
+	type shift struct {
+		ID string `mip:"name"`
+	}
+	type crew struct {
+		Min int `mip:"lower"`
+		Max int `mip:"upper"`
+	}
+	model := mip.NewModel()
+	shifts, err := mip.Bind(model, []shift{{ID: "early"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shifts.Vars[0].IsBool() {
+		t.Errorf("expected a bool var without bounds")
+	}
+	crews, err := mip.Bind(model, []crew{{Min: 2, Max: 5}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := crews.Vars[0]; !v.IsInt() || v.LowerBound() != 2 || v.UpperBound() != 5 {
+		t.Errorf("got var with bounds [%v, %v], want int in [2, 5]", v.LowerBound(), v.UpperBound())
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	type unknown struct {
+		X float64 `mip:"coefficient"`
+	}
+	type missing struct {
+		X float64 `mip:"constraint=capacity"`
+	}
+	type wrongKind struct {
+		X string `mip:"objective"`
+	}
+	model := mip.NewModel()
+	if _, err := mip.Bind(model, []unknown{{}}, nil); err == nil {
+		t.Error("expected error for an unknown tag")
+	}
+	if _, err := mip.Bind(model, []missing{{}}, nil); err == nil {
+		t.Error("expected error for a constraint without spec")
+	}
+	if _, err := mip.Bind(model, []wrongKind{{}}, nil); err == nil {
+		t.Error("expected error for a non-numeric coefficient")
+	}
+	if _, err := mip.Bind(model, []int{1}, nil); err == nil {
+		t.Error("expected error for rows which are not structs")
+	}
+	if len(model.Vars()) != 0 {
+		t.Errorf("failed bindings added vars")
+	}
+}
+
+func TestBindInvalidData(t *testing.T) {
+	type route struct {
+		Lower float64 `mip:"lower"`
+		Upper float64 `mip:"upper"`
+		Cost  float64 `mip:"objective"`
+	}
+	type shift struct {
+		Lower float64 `mip:"lower"`
+		Upper int64   `mip:"upper"`
+	}
+	model := mip.NewModel()
+	if _, err := mip.Bind(model, []route{{Upper: math.NaN()}}, nil); !errors.Is(err, mip.ErrNaN) {
+		t.Errorf("got error %v for a NaN bound, want %v", err, mip.ErrNaN)
+	}
+	if _, err := mip.Bind(model, []route{{Upper: 1, Cost: math.NaN()}}, nil); !errors.Is(err, mip.ErrNaN) {
+		t.Errorf("got error %v for a NaN cost, want %v", err, mip.ErrNaN)
+	}
+
+	// Fractional bounds of int vars are rounded inwards.
+	binding, err := mip.Bind(model, []shift{{Lower: 1.5, Upper: 4}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := binding.Vars[0]; !v.IsInt() || v.LowerBound() != 2 || v.UpperBound() != 4 {
+		t.Errorf("got var with bounds [%v, %v], want int in [2, 4]", v.LowerBound(), v.UpperBound())
+	}
+}
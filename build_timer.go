@@ -0,0 +1,58 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrBuildTimeLimit is returned by back-ends which exceed
+// SolveOptions.BuildDuration while translating a model. It is an
+// ErrTranslation.
+var ErrBuildTimeLimit = NewKindError(ErrTranslation, "build time limit exceeded")
+
+// BuildTimer bounds the translation of a model to a back-end by
+// SolveOptions.BuildDuration. For large models the translation can take most
+// of the time budget, back-ends check the timer periodically while
+// translating and abort if it expired:
+//
+//	timer := mip.NewBuildTimer(options)
+//	for i, c := range model.Constraints() {
+//		if i%1000 == 0 {
+//			if err := timer.Check(); err != nil {
+//				return nil, err
+//			}
+//		}
+//		...
+//	}
+//	phases.Translation = timer.Elapsed()
+type BuildTimer struct {
+	start time.Time
+	limit time.Duration
+}
+
+// NewBuildTimer starts a timer for the translation limit of options.
+func NewBuildTimer(options SolveOptions) BuildTimer {
+	return BuildTimer{
+		start: time.Now(),
+		limit: options.BuildDuration,
+	}
+}
+
+// Elapsed returns the time since the invoking timer started.
+func (t BuildTimer) Elapsed() time.Duration {
+	return time.Since(t.start)
+}
+
+// Check returns an error wrapping ErrBuildTimeLimit if the translation took
+// longer than the limit, nil if there is no limit.
+func (t BuildTimer) Check() error {
+	if t.limit <= 0 {
+		return nil
+	}
+	if elapsed := t.Elapsed(); elapsed > t.limit {
+		return fmt.Errorf("%w: translating took %v, limit %v", ErrBuildTimeLimit, elapsed, t.limit)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestBuildTimer(t *testing.T) {
+	if err := mip.NewBuildTimer(mip.SolveOptions{}).Check(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	timer := mip.NewBuildTimer(mip.SolveOptions{BuildDuration: time.Hour})
+	if err := timer.Check(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	timer = mip.NewBuildTimer(mip.SolveOptions{BuildDuration: time.Millisecond})
+	time.Sleep(2 * time.Millisecond)
+	if err := timer.Check(); !errors.Is(err, mip.ErrBuildTimeLimit) {
+		t.Errorf("got error %v, want %v", err, mip.ErrBuildTimeLimit)
+	}
+	if timer.Elapsed() < 2*time.Millisecond {
+		t.Errorf("got elapsed %v, want at least 2ms", timer.Elapsed())
+	}
+}
@@ -0,0 +1,71 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+// AtMostK adds the constraint to model that at most k of vars are 1 and
+// returns it. The vars are expected to be bool vars, for other vars the
+// constraint limits the sum of their values. The terms are allocated in bulk.
+//
+//	mip.AtMostK(model, shifts, 5) // at most 5 shifts per week
+func AtMostK(model Model, vars Vars, k int) Constraint {
+	return cardinality(model, vars, LessThanOrEqual, k)
+}
+
+// AtLeastK adds the constraint to model that at least k of vars are 1 and
+// returns it, see AtMostK.
+func AtLeastK(model Model, vars Vars, k int) Constraint {
+	return cardinality(model, vars, GreaterThanOrEqual, k)
+}
+
+// ExactlyK adds the constraint to model that exactly k of vars are 1 and
+// returns it, see AtMostK.
+func ExactlyK(model Model, vars Vars, k int) Constraint {
+	return cardinality(model, vars, Equal, k)
+}
+
+// cardinality adds the constraint that the sum of vars relates to k by
+// sense.
+func cardinality(model Model, vars Vars, sense Sense, k int) Constraint {
+	c := model.NewConstraint(sense, float64(k))
+	if impl, ok := c.(*constraint); ok {
+		if err := impl.newUnitTerms(vars); err != nil {
+			panic(err)
+		}
+		return c
+	}
+	for _, v := range vars {
+		c.NewTerm(1, v)
+	}
+	return c
+}
+
+// newUnitTerms adds a term with coefficient 1 per var to the invoking
+// constraint, allocating the terms at once.
+func (c *constraint) newUnitTerms(vars Vars) error {
+	if err := c.model.checkMutable(); err != nil {
+		return err
+	}
+	count := c.model.nonZeros + len(vars)
+	if err := checkLimit("non-zeros", count, c.model.limits.NonZeros); err != nil {
+		return err
+	}
+	c.model.nonZeros = count
+
+	if c.model.float32 {
+		for _, v := range vars {
+			c.compact = append(c.compact, compactTerm{index: int32(v.Index()), coefficient: 1})
+		}
+		return nil
+	}
+	c.own()
+	terms := make([]term, len(vars))
+	c.terms = append(make(Terms, 0, len(c.terms)+len(vars)), c.terms...)
+	for i, v := range vars {
+		terms[i] = term{
+			coefficient: 1,
+			variable:    v,
+		}
+		c.terms = append(c.terms, &terms[i])
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestCardinality(t *testing.T) {
+	model := mip.NewModel()
+	vars := mip.Vars{model.NewBool(), model.NewBool(), model.NewBool()}
+
+	tests := []struct {
+		constraint mip.Constraint
+		want       string
+	}{
+		{mip.AtMostK(model, vars, 2), "1 B0 + 1 B1 + 1 B2 <= 2"},
+		{mip.AtLeastK(model, vars[1:], 1), "1 B1 + 1 B2 >= 1"},
+		{mip.ExactlyK(model, vars, 1), "1 B0 + 1 B1 + 1 B2 = 1"},
+	}
+	for _, test := range tests {
+		if got := fmt.Sprint(test.constraint); got != test.want {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+	}
+
+	// The terms count towards the limits of the model.
+	limited := mip.NewModelWithLimits(mip.ModelLimits{NonZeros: 2})
+	vars = mip.Vars{limited.NewBool(), limited.NewBool(), limited.NewBool()}
+	defer func() {
+		var limitErr *mip.ModelLimitError
+		if err, ok := recover().(error); !ok || !errors.As(err, &limitErr) {
+			t.Errorf("got %v, want a *mip.ModelLimitError panic", err)
+		}
+	}()
+	mip.AtMostK(limited, vars, 1)
+}
+
+func BenchmarkAtMostK(b *testing.B) {
+	model := mip.NewModel()
+	vars := make(mip.Vars, 1000)
+	for i := range vars {
+		vars[i] = model.NewBool()
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mip.AtMostK(model, vars, 10)
+	}
+}
@@ -0,0 +1,118 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// NormalTermSpec specifies a term of a ChanceConstraintSpec whose
+// coefficient is normally distributed.
+type NormalTermSpec struct {
+	// Mean of the coefficient.
+	Mean float64
+	// StdDev is the standard deviation of the coefficient, 0 for a certain
+	// coefficient.
+	StdDev float64
+	// Var of the term.
+	Var Var
+}
+
+// ChanceConstraintSpec specifies a constraint with independent normally
+// distributed coefficients and right-hand side which must hold with a given
+// probability, added by NewChanceConstraint.
+type ChanceConstraintSpec struct {
+	// Sense of the constraint, LessThanOrEqual or GreaterThanOrEqual.
+	Sense Sense
+	// RightHandSideMean is the mean of the right-hand side.
+	RightHandSideMean float64
+	// RightHandSideStdDev is the standard deviation of the right-hand side.
+	RightHandSideStdDev float64
+	// Terms of the constraint.
+	Terms []NormalTermSpec
+	// Confidence is the probability with which the constraint must hold, in
+	// [0.5, 1).
+	Confidence float64
+}
+
+// NewChanceConstraint adds a deterministic linear approximation of the chance
+// constraint spec to model. With the quantile z of the standard normal
+// distribution at the confidence level, P(Σ a_j x_j <= b) >= α becomes
+//
+//	Σ μ_j x_j + z Σ σ_j |x_j| <= μ_b - z σ_b
+//
+// which is exact if only the right-hand side is uncertain. With uncertain
+// coefficients the exact counterpart is the second-order cone constraint
+// Σ μ_j x_j + z sqrt(Σ σ_j² x_j² + σ_b²) <= μ_b, which the approximation
+// implies: solutions of the approximation satisfy the chance constraint, but
+// it may cut off some which do. Absolute values of vars which can be
+// negative are modeled with an additional var and two rows. The returned
+// constraint is the approximation, which the caller may name. Returns an
+// error if the sense is Equal, the confidence is not in [0.5, 1), a standard
+// deviation is negative or a number is NaN.
+//
+//	c, err := mip.NewChanceConstraint(model, mip.ChanceConstraintSpec{
+//		Sense:               mip.GreaterThanOrEqual,
+//		RightHandSideMean:   demand.Mean,
+//		RightHandSideStdDev: demand.StdDev,
+//		Terms:               supply, // production with yield uncertainty
+//		Confidence:          0.95,
+//	})
+func NewChanceConstraint(model Model, spec ChanceConstraintSpec) (Constraint, error) {
+	if spec.Sense == Equal {
+		return nil, errors.New("chance constraint must be an inequality")
+	}
+	if math.IsNaN(spec.Confidence) || spec.Confidence < 0.5 || spec.Confidence >= 1 {
+		return nil, fmt.Errorf("confidence %v not in [0.5, 1)", spec.Confidence)
+	}
+	if err := checkNormal("right-hand side", spec.RightHandSideMean, spec.RightHandSideStdDev); err != nil {
+		return nil, err
+	}
+	for _, t := range spec.Terms {
+		if err := checkNormal("coefficient", t.Mean, t.StdDev); err != nil {
+			return nil, err
+		}
+	}
+
+	// The safety margin is added to the left-hand side of a <= constraint
+	// and subtracted from the left-hand side of a >= constraint.
+	z := math.Sqrt2 * math.Erfinv(2*spec.Confidence-1)
+	sign := 1.0
+	if spec.Sense == GreaterThanOrEqual {
+		sign = -1.0
+	}
+	c := model.NewConstraint(
+		spec.Sense,
+		spec.RightHandSideMean-sign*z*spec.RightHandSideStdDev,
+	)
+	for _, t := range spec.Terms {
+		c.NewTerm(t.Mean, t.Var)
+	}
+	if z == 0 {
+		return c, nil
+	}
+	for _, t := range spec.Terms {
+		if t.StdDev == 0 {
+			continue
+		}
+		c.NewTerm(sign*z*t.StdDev, absoluteValue(model, t.Var))
+	}
+	return c, nil
+}
+
+// checkNormal returns an error if the mean or the standard deviation of a
+// normal distribution is NaN or the standard deviation is negative.
+func checkNormal(what string, mean, stdDev float64) error {
+	if err := checkNaN(what+" mean", mean); err != nil {
+		return err
+	}
+	if err := checkNaN(what+" standard deviation", stdDev); err != nil {
+		return err
+	}
+	if stdDev < 0 {
+		return fmt.Errorf("%s standard deviation %v is negative", what, stdDev)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"math"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestNewChanceConstraint(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 100)
+	y := model.NewFloat(-10, 10)
+	c, err := mip.NewChanceConstraint(model, mip.ChanceConstraintSpec{
+		Sense:               mip.LessThanOrEqual,
+		RightHandSideMean:   50,
+		RightHandSideStdDev: 10,
+		Terms: []mip.NormalTermSpec{
+			{Mean: 2, StdDev: 0.5, Var: x},
+			{Mean: 1, StdDev: 1, Var: y},
+		},
+		Confidence: 0.975,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const z = 1.959963984540054
+	if rhs := c.RightHandSide(); math.Abs(rhs-(50-z*10)) > 1e-9 {
+		t.Errorf("got right-hand side %v, want %v", rhs, 50-z*10)
+	}
+	if term, _ := c.Term(x); math.Abs(term.Coefficient()-(2+z*0.5)) > 1e-9 {
+		t.Errorf("got coefficient of x %v, want %v", term.Coefficient(), 2+z*0.5)
+	}
+	// y can be negative, its absolute value is an additional var.
+	if len(model.Vars()) != 3 || len(model.Constraints()) != 3 {
+		t.Fatalf("got %d vars and %d constraints, want 3 and 3", len(model.Vars()), len(model.Constraints()))
+	}
+	if term, _ := c.Term(model.Vars()[2]); math.Abs(term.Coefficient()-z) > 1e-9 {
+		t.Errorf("got coefficient of |y| %v, want %v", term.Coefficient(), z)
+	}
+}
+
+func TestNewChanceConstraintGreaterThanOrEqual(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 100)
+	c, err := mip.NewChanceConstraint(model, mip.ChanceConstraintSpec{
+		Sense:               mip.GreaterThanOrEqual,
+		RightHandSideMean:   30,
+		RightHandSideStdDev: 5,
+		Terms:               []mip.NormalTermSpec{{Mean: 1, Var: x}},
+		Confidence:          0.5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.RightHandSide() != 30 {
+		t.Errorf("got right-hand side %v, want the mean 30", c.RightHandSide())
+	}
+}
+
+func TestNewChanceConstraintErrors(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 1)
+	for name, spec := range map[string]mip.ChanceConstraintSpec{
+		"equal":      {Sense: mip.Equal, Confidence: 0.9},
+		"confidence": {Confidence: 1},
+		"std dev":    {Confidence: 0.9, Terms: []mip.NormalTermSpec{{Mean: 1, StdDev: -1, Var: x}}},
+		"nan":        {Confidence: 0.9, RightHandSideMean: math.NaN()},
+	} {
+		if _, err := mip.NewChanceConstraint(model, spec); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
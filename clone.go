@@ -0,0 +1,31 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "fmt"
+
+// CloneForSolve returns n independent copies of model to be solved
+// concurrently, e.g. with different seeds or options, each by its own
+// solver in its own goroutine. The copies are forks, see Model.Fork, which
+// share the terms of the constraints with model until either changes them,
+// so cloning costs little more than copying the vars and constraints
+// without their terms. Each copy may be changed and solved concurrently
+// with the others and with model. Panics if n is negative.
+//
+//	clones := mip.CloneForSolve(model, len(seeds))
+//	for i, seed := range seeds {
+//		go func(clone mip.Model, seed int) {
+//			solver, err := factory(clone)
+//			...
+//		}(clones[i], seed)
+//	}
+func CloneForSolve(model Model, n int) []Model {
+	if n < 0 {
+		panic(fmt.Sprintf("mip: CloneForSolve with %d clones", n))
+	}
+	clones := make([]Model, n)
+	for i := range clones {
+		clones[i] = model.Fork()
+	}
+	return clones
+}
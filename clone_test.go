@@ -0,0 +1,74 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"sync"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestCloneForSolve(t *testing.T) {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	vars := make(mip.Vars, 4)
+	c := model.NewConstraint(mip.LessThanOrEqual, 2)
+	for i := range vars {
+		vars[i] = model.NewBool()
+		c.NewTerm(1, vars[i])
+		model.Objective().NewTerm(float64(i+1), vars[i])
+	}
+
+	clones := mip.CloneForSolve(model, 4)
+	if len(clones) != 4 {
+		t.Fatalf("got %d clones, want 4", len(clones))
+	}
+	values := make([]float64, len(clones))
+	var wg sync.WaitGroup
+	for i, clone := range clones {
+		wg.Add(1)
+		go func(i int, clone mip.Model) {
+			defer wg.Done()
+			// Clone i allows i + 1 vars.
+			clone.Constraints()[0].NewTerm(0, clone.Vars()[0])
+			clone.Constraints()[0].SetTerm(clone.Vars()[0], 1)
+			limit := clone.NewConstraint(mip.LessThanOrEqual, float64(i+1))
+			for _, v := range clone.Vars() {
+				limit.NewTerm(1, v)
+			}
+			solution, err := enumeratingSolver{model: clone}.Solve(mip.SolveOptions{})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			// Solutions are read from several goroutines.
+			var readers sync.WaitGroup
+			for range clone.Vars() {
+				readers.Add(1)
+				go func() {
+					defer readers.Done()
+					_ = solution.Value(clone.Vars()[0])
+				}()
+			}
+			readers.Wait()
+			values[i] = solution.ObjectiveValue()
+		}(i, clone)
+	}
+	// The model is changed while its clones are solved.
+	c.SetTerm(vars[3], 2)
+	wg.Wait()
+
+	want := []float64{4, 7, 7, 7}
+	for i, value := range values {
+		if value != want[i] {
+			t.Errorf("got objective value %v of clone %d, want %v", value, i, want[i])
+		}
+	}
+	if len(model.Constraints()) != 1 {
+		t.Errorf("got %d constraints in model, want 1", len(model.Constraints()))
+	}
+	if _, definitions := model.Constraints()[0].Term(vars[0]); definitions != 1 {
+		t.Errorf("got %d terms of %v, want 1", definitions, vars[0])
+	}
+}
@@ -0,0 +1,286 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParallelColumns are vars whose columns, their coefficients in the
+// constraints and the objective, are proportional. The model only depends on
+// the weighted sum of their values, so they can be merged into one var, see
+// MergeParallelColumns.
+type ParallelColumns struct {
+	// Vars with proportional columns, ordered by index.
+	Vars Vars
+	// Factors of the columns, the column of Vars[i] is Factors[i] times the
+	// column of Vars[0]. Factors[0] is 1.
+	Factors []float64
+}
+
+// DetectParallelColumns returns the groups of at least two vars of model with
+// proportional columns, including identical columns, ordered by the index of
+// their first var. Vars are only grouped with vars of a compatible type:
+// float vars with float vars by any non-zero factor, and int and bool vars
+// with int and bool vars by a factor of 1 or -1, so a merged value can be
+// split into integral values. Fixed vars, vars of quadratic objective terms
+// and vars without non-zero coefficients are not grouped.
+func DetectParallelColumns(model Model) []ParallelColumns {
+	vars := model.Vars()
+	columns := make([][]float64, len(vars))
+	rows := make([][]int, len(vars))
+	add := func(row int, terms Terms) {
+		for _, t := range terms {
+			if t.Coefficient() == 0 {
+				continue
+			}
+			index := t.Var().Index()
+			rows[index] = append(rows[index], row)
+			columns[index] = append(columns[index], t.Coefficient())
+		}
+	}
+	for i, c := range model.Constraints() {
+		add(i, c.Terms())
+	}
+	add(-1, model.Objective().Terms())
+
+	excluded := make(map[int]bool)
+	for _, t := range model.Objective().QuadraticTerms() {
+		excluded[t.Var1().Index()] = true
+		excluded[t.Var2().Index()] = true
+	}
+
+	groups := make(map[string]int)
+	var result []ParallelColumns
+	for i, v := range vars {
+		if _, fixed := v.FixedValue(); fixed || excluded[i] || len(columns[i]) == 0 {
+			continue
+		}
+		key := columnKey(v, rows[i], columns[i])
+		factor := columns[i][0]
+		group, ok := groups[key]
+		if !ok {
+			groups[key] = len(result)
+			result = append(result, ParallelColumns{Vars: Vars{v}, Factors: []float64{factor}})
+			continue
+		}
+		result[group].Vars = append(result[group].Vars, v)
+		result[group].Factors = append(result[group].Factors, factor)
+	}
+
+	parallel := result[:0]
+	for _, group := range result {
+		if len(group.Vars) < 2 {
+			continue
+		}
+		first := group.Factors[0]
+		for i := range group.Factors {
+			group.Factors[i] /= first
+		}
+		parallel = append(parallel, group)
+	}
+	return parallel
+}
+
+// columnKey returns a key identifying the column of v with the coefficients
+// in rows, the objective being row -1, up to a factor.
+func columnKey(v Var, rows []int, coefficients []float64) string {
+	var sb strings.Builder
+	if v.Type() == Continuous {
+		sb.WriteString("F ")
+	} else {
+		// Int and bool vars are only grouped by a factor of 1 or -1.
+		sb.WriteString("I")
+		sb.WriteString(strconv.FormatFloat(math.Abs(coefficients[0]), 'g', 12, 64))
+		sb.WriteByte(' ')
+	}
+	for i, row := range rows {
+		sb.WriteString(strconv.Itoa(row))
+		sb.WriteByte(':')
+		sb.WriteString(strconv.FormatFloat(coefficients[i]/coefficients[0], 'g', 12, 64))
+		sb.WriteByte(' ')
+	}
+	return sb.String()
+}
+
+// ColumnMerge maps a model with merged parallel columns back to the model it
+// was created from, see MergeParallelColumns.
+type ColumnMerge struct {
+	// Groups are the merged parallel columns of the original model.
+	Groups []ParallelColumns
+	// merged are the vars of the merged model by the index of the vars of
+	// the original model.
+	merged Vars
+	// groups are the indices of the groups by the index of the vars of the
+	// original model, -1 for vars which are not merged.
+	groups []int
+}
+
+// MergeParallelColumns returns a copy of model in which each group of
+// parallel columns, see DetectParallelColumns, is replaced by one var and
+// the merge to split a solution of the copy into values of the vars of
+// model, see ColumnMerge.Split. The var of a group takes the value of the sum
+// of the factors times the values of its vars. It is a float var if the vars
+// are float vars and an int var otherwise, its bounds are the ones of the
+// sum and it takes the name of the first var of the group. Vars which are not
+// merged keep their type, bounds, fixed value, name and group, and
+// constraints keep their names. Other data, such as attributes and params,
+// is not copied.
+//
+//	merged, merge := mip.MergeParallelColumns(model)
+//	solution, err := solver(merged).Solve(options)
+//	split := merge.Split(solution) // values of the vars of model
+func MergeParallelColumns(model Model) (Model, *ColumnMerge) {
+	vars := model.Vars()
+	merge := &ColumnMerge{
+		Groups: DetectParallelColumns(model),
+		merged: make(Vars, len(vars)),
+		groups: make([]int, len(vars)),
+	}
+	for i := range merge.groups {
+		merge.groups[i] = -1
+	}
+	for g, group := range merge.Groups {
+		for _, v := range group.Vars {
+			merge.groups[v.Index()] = g
+		}
+	}
+
+	merged := NewModel()
+	for i, v := range vars {
+		g := merge.groups[i]
+		switch {
+		case g < 0:
+			merge.merged[i] = newVarLike(merged, v)
+			merge.merged[i].SetGroup(v.Group())
+		case merge.Groups[g].Vars[0] == v:
+			merge.merged[i] = newMergedVar(merged, merge.Groups[g])
+		default:
+			merge.merged[i] = merge.merged[merge.Groups[g].Vars[0].Index()]
+			continue
+		}
+		merge.merged[i].SetName(v.Name())
+	}
+
+	// Only the first var of a group keeps its terms, which are the terms of
+	// the merged var.
+	mergedTerms := func(terms Terms, add func(coefficient float64, v Var)) {
+		for _, t := range terms {
+			index := t.Var().Index()
+			if g := merge.groups[index]; t.Coefficient() == 0 ||
+				g >= 0 && merge.Groups[g].Vars[0].Index() != index {
+				continue
+			}
+			add(t.Coefficient(), merge.merged[index])
+		}
+	}
+
+	objective := merged.Objective()
+	if model.Objective().IsMaximize() {
+		objective.SetMaximize()
+	}
+	mergedTerms(model.Objective().Terms(), func(coefficient float64, v Var) {
+		objective.NewTerm(coefficient, v)
+	})
+	addMappedQuadraticTerms(objective, model.Objective().QuadraticTerms(), merge.merged, 1)
+	for _, c := range model.Constraints() {
+		constraint := merged.NewConstraint(c.Sense(), c.RightHandSide())
+		constraint.SetName(c.Name())
+		mergedTerms(c.Terms(), func(coefficient float64, v Var) {
+			constraint.NewTerm(coefficient, v)
+		})
+	}
+	return merged, merge
+}
+
+// newMergedVar adds the var replacing group to model.
+func newMergedVar(model Model, group ParallelColumns) Var {
+	lower, upper := 0.0, 0.0
+	continuous := true
+	for i, v := range group.Vars {
+		low, high := contributionBounds(v, group.Factors[i])
+		lower += low
+		upper += high
+		continuous = continuous && v.Type() == Continuous
+	}
+	if continuous {
+		return model.NewFloat(lower, upper)
+	}
+	return model.NewInt(intLowerBound(lower), intUpperBound(upper))
+}
+
+// contributionBounds returns the bounds of factor times v.
+func contributionBounds(v Var, factor float64) (float64, float64) {
+	low, high := factor*v.LowerBound(), factor*v.UpperBound()
+	if factor < 0 {
+		low, high = high, low
+	}
+	return low, high
+}
+
+// Split returns solution of the merged model as a solution of the original
+// model, see MergeParallelColumns. The value of a merged var is split among
+// the vars of its group: each var starts at the bound at which it
+// contributes least to the sum, and the rest of the value is assigned to the
+// vars in order, each up to its other bound. Vars with infinite bounds start
+// at their finite bound or at 0. Int values of a group are integral if the
+// value of the merged var is.
+func (m *ColumnMerge) Split(solution Solution) Solution {
+	return &splitSolution{
+		Solution: solution,
+		merge:    m,
+	}
+}
+
+// values returns the values of the vars of group given the value of their
+// merged var.
+func (group ParallelColumns) values(value float64) []float64 {
+	values := make([]float64, len(group.Vars))
+	contributions := make([]float64, len(group.Vars))
+	rest := value
+	for i, v := range group.Vars {
+		low, high := contributionBounds(v, group.Factors[i])
+		switch {
+		case !math.IsInf(low, 0):
+			contributions[i] = low
+		case !math.IsInf(high, 0):
+			contributions[i] = high
+		}
+		rest -= contributions[i]
+	}
+	for i, v := range group.Vars {
+		low, high := contributionBounds(v, group.Factors[i])
+		contribution := math.Max(low, math.Min(high, contributions[i]+rest))
+		rest -= contribution - contributions[i]
+		values[i] = contribution / group.Factors[i]
+	}
+	return values
+}
+
+// splitSolution is a solution of a merged model whose values are accessed
+// with the vars of the original model.
+type splitSolution struct {
+	Solution
+	merge *ColumnMerge
+}
+
+func (s *splitSolution) Value(variable Var) float64 {
+	if !s.Solution.HasValues() {
+		return math.MaxFloat64
+	}
+	index := variable.Index()
+	value := s.Solution.Value(s.merge.merged[index])
+	g := s.merge.groups[index]
+	if g < 0 {
+		return value
+	}
+	group := s.merge.Groups[g]
+	for i, v := range group.Vars {
+		if v.Index() == index {
+			return group.values(value)[i]
+		}
+	}
+	return value
+}
@@ -0,0 +1,111 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestMergeParallelColumns(t *testing.T) {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	x := model.NewFloat(0, 5)
+	y := model.NewFloat(0, 3)
+	q := model.NewFloat(0, 1)
+	z := model.NewInt(0, 4)
+	w := model.NewBool()
+	u := model.NewInt(0, 10)
+	x.SetName("x")
+	z.SetName("z")
+	u.SetName("u")
+
+	capacity := model.NewConstraint(mip.LessThanOrEqual, 6)
+	capacity.SetName("capacity")
+	capacity.NewTerm(1, x)
+	capacity.NewTerm(1, y)
+	capacity.NewTerm(2, q)
+	capacity.NewTerm(1, z)
+	capacity.NewTerm(2, u)
+	capacity.NewTerm(-1, w)
+	balance := model.NewConstraint(mip.GreaterThanOrEqual, 1)
+	balance.NewTerm(1, z)
+	balance.NewTerm(-1, w)
+	balance.NewTerm(1, u)
+	model.Objective().NewTerm(2, x)
+	model.Objective().NewTerm(2, y)
+	model.Objective().NewTerm(4, q)
+	model.Objective().NewTerm(1, z)
+
+	// z and w have proportional columns by -1 without the objective term of
+	// z, and u by 2, which int vars are not grouped by.
+	groups := mip.DetectParallelColumns(model)
+	if len(groups) != 1 || fmt.Sprint(groups[0].Vars) != "[x F1 F2]" ||
+		fmt.Sprint(groups[0].Factors) != "[1 1 2]" {
+		t.Fatalf("got groups %+v, want x, F1 and F2", groups)
+	}
+	model.Objective().NewTerm(-1, z)
+	groups = mip.DetectParallelColumns(model)
+	if len(groups) != 2 || fmt.Sprint(groups[1].Vars) != "[z B4]" ||
+		fmt.Sprint(groups[1].Factors) != "[1 -1]" {
+		t.Fatalf("got groups %+v, want z and B4 second", groups)
+	}
+
+	merged, merge := mip.MergeParallelColumns(model)
+	vars := merged.Vars()
+	if len(vars) != 3 {
+		t.Fatalf("got vars %v, want 3", vars)
+	}
+	if vars[0].Name() != "x" || !vars[0].IsFloat() || vars[0].LowerBound() != 0 || vars[0].UpperBound() != 10 {
+		t.Errorf("got merged var %v in [%v, %v], want float x in [0, 10]",
+			vars[0], vars[0].LowerBound(), vars[0].UpperBound())
+	}
+	if vars[1].Name() != "z" || !vars[1].IsInt() || vars[1].LowerBound() != -1 || vars[1].UpperBound() != 4 {
+		t.Errorf("got merged var %v in [%v, %v], want int z in [-1, 4]",
+			vars[1], vars[1].LowerBound(), vars[1].UpperBound())
+	}
+	constraints := merged.Constraints()
+	if fmt.Sprint(constraints) != "[1 x + 1 z + 2 u <= 6 1 z + 1 u >= 1]" ||
+		constraints[0].Name() != "capacity" {
+		t.Errorf("got constraints %v, want merged constraints", constraints)
+	}
+	if terms := merged.Objective().Terms(); len(terms) != 1 || terms[0].Coefficient() != 2 ||
+		!merged.Objective().IsMaximize() {
+		t.Errorf("got objective %v, want maximize 2 x", terms)
+	}
+
+	split := merge.Split(newTestSolution(12, map[mip.Var]float64{
+		vars[0]: 6.5,
+		vars[1]: -1,
+		vars[2]: 3,
+	}))
+	want := map[mip.Var]float64{x: 5, y: 1.5, q: 0, z: 0, w: 1, u: 3}
+	for v, value := range want {
+		if got := split.Value(v); got != value {
+			t.Errorf("got split value %v of %v, want %v", got, v, value)
+		}
+	}
+	if split.ObjectiveValue() != 12 {
+		t.Errorf("got objective value %v, want 12", split.ObjectiveValue())
+	}
+}
+
+func TestMergeParallelColumnsRetypedVars(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0.5, 1.5)
+	y := model.NewFloat(0, 2)
+	for _, v := range []mip.Var{x, y} {
+		model.SetVarType(v, mip.Integer)
+	}
+	c := model.NewConstraint(mip.LessThanOrEqual, 3)
+	c.NewTerm(1, x)
+	c.NewTerm(1, y)
+
+	merged, _ := mip.MergeParallelColumns(model)
+	vars := merged.Vars()
+	if len(vars) != 1 || !vars[0].IsInt() || vars[0].LowerBound() != 1 || vars[0].UpperBound() != 3 {
+		t.Errorf("got merged vars %v, want one int var in [1, 3]", vars)
+	}
+}
@@ -0,0 +1,158 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"math"
+	"sort"
+)
+
+// Literal is a bool var taking a value, x = 1 or x = 0.
+type Literal struct {
+	// Var is a var of type Binary.
+	Var Var
+	// Value is true for x = 1, false for x = 0.
+	Value bool
+}
+
+// ConflictGraph holds the pairs of literals which can not both hold in a
+// feasible solution of a model, see NewConflictGraph. A conflict graph is
+// not modified after its creation and is safe for concurrent reads.
+type ConflictGraph struct {
+	edges map[Literal]map[Literal]bool
+	count int
+}
+
+// NewConflictGraph returns the conflicts between the literals of the binary
+// vars of model implied by single constraints. For a constraint, written as
+// sum(a x) <= b, the minimum activity is the sum of the smallest values of
+// the terms given the bounds of their vars. A literal of a binary var raises
+// the activity by |a| if it is the value with the larger term, 1 for a > 0,
+// 0 for a < 0, and two literals conflict if raising the minimum activity by
+// both exceeds b. Constraints with an unbounded minimum activity imply no
+// conflicts. Two literals of the same var always conflict but are not part
+// of the graph. Conflict graphs are used for clique cuts, symmetry analysis
+// and propagation, e.g. x = 1 forces all neighbors of x = 1 to their other
+// value.
+func NewConflictGraph(model Model) ConflictGraph {
+	graph := ConflictGraph{edges: make(map[Literal]map[Literal]bool)}
+	for _, c := range model.Constraints() {
+		terms := c.Terms()
+		switch c.Sense() {
+		case LessThanOrEqual:
+			graph.addRow(terms, 1, c.RightHandSide())
+		case GreaterThanOrEqual:
+			graph.addRow(terms, -1, -c.RightHandSide())
+		default:
+			graph.addRow(terms, 1, c.RightHandSide())
+			graph.addRow(terms, -1, -c.RightHandSide())
+		}
+	}
+	return graph
+}
+
+// conflictLiteral is the literal of a binary var which raises the activity
+// of a row by raise.
+type conflictLiteral struct {
+	literal Literal
+	raise   float64
+}
+
+// addRow adds the conflicts of the row sign * terms <= rightHandSide.
+func (g *ConflictGraph) addRow(terms Terms, sign float64, rightHandSide float64) {
+	minimum := 0.0
+	literals := make([]conflictLiteral, 0, len(terms))
+	for _, t := range terms {
+		a := sign * t.Coefficient()
+		if t.Var().Type() == Binary {
+			minimum += math.Min(0, a)
+			if a != 0 {
+				literals = append(literals, conflictLiteral{
+					literal: Literal{Var: t.Var(), Value: a > 0},
+					raise:   math.Abs(a),
+				})
+			}
+			continue
+		}
+		switch {
+		case a > 0:
+			minimum += a * t.Var().LowerBound()
+		case a < 0:
+			minimum += a * t.Var().UpperBound()
+		}
+	}
+	if math.IsInf(minimum, 0) || math.IsNaN(minimum) {
+		return
+	}
+
+	sort.SliceStable(literals, func(i, j int) bool {
+		return literals[i].raise > literals[j].raise
+	})
+	for i := 0; i+1 < len(literals); i++ {
+		a := literals[i]
+		if minimum+a.raise+literals[i+1].raise <= rightHandSide {
+			return
+		}
+		for _, b := range literals[i+1:] {
+			if minimum+a.raise+b.raise <= rightHandSide {
+				break
+			}
+			g.add(a.literal, b.literal)
+		}
+	}
+}
+
+func (g *ConflictGraph) add(a, b Literal) {
+	for _, l := range [2]Literal{a, b} {
+		if g.edges[l] == nil {
+			g.edges[l] = make(map[Literal]bool)
+		}
+	}
+	if !g.edges[a][b] {
+		g.count++
+	}
+	g.edges[a][b] = true
+	g.edges[b][a] = true
+}
+
+// Conflicts returns true if literals a and b can not both hold.
+func (g ConflictGraph) Conflicts(a, b Literal) bool {
+	return g.edges[a][b]
+}
+
+// Neighbors returns the literals which conflict with l, ordered by the index
+// of their var and value.
+func (g ConflictGraph) Neighbors(l Literal) []Literal {
+	neighbors := make([]Literal, 0, len(g.edges[l]))
+	for neighbor := range g.edges[l] {
+		neighbors = append(neighbors, neighbor)
+	}
+	sortLiterals(neighbors)
+	return neighbors
+}
+
+// Literals returns the literals which have a conflict, ordered by the index
+// of their var and value.
+func (g ConflictGraph) Literals() []Literal {
+	literals := make([]Literal, 0, len(g.edges))
+	for l := range g.edges {
+		literals = append(literals, l)
+	}
+	sortLiterals(literals)
+	return literals
+}
+
+// Len returns the number of conflicts, the edges of the graph.
+func (g ConflictGraph) Len() int {
+	return g.count
+}
+
+func sortLiterals(literals []Literal) {
+	sort.Slice(literals, func(i, j int) bool {
+		a, b := literals[i], literals[j]
+		if a.Var.Index() != b.Var.Index() {
+			return a.Var.Index() < b.Var.Index()
+		}
+		return !a.Value && b.Value
+	})
+}
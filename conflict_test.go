@@ -0,0 +1,54 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestConflictGraph(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewBool()
+	y := model.NewBool()
+	z := model.NewBool()
+	model.NewFloat(0, 10)
+
+	// add adds a constraint with the coefficients of the vars in order.
+	add := func(sense mip.Sense, rhs float64, coefficients ...float64) {
+		c := model.NewConstraint(sense, rhs)
+		for i, coefficient := range coefficients {
+			c.NewTerm(coefficient, model.Vars()[i])
+		}
+	}
+	add(mip.LessThanOrEqual, 4, 3, 2, 1)     // x = 1 and y = 1
+	add(mip.LessThanOrEqual, 0, 0, -1, 1)    // z = 1 and y = 0
+	add(mip.GreaterThanOrEqual, 1, 1, 0, 1)  // x = 0 and z = 0
+	add(mip.LessThanOrEqual, 4, 5, 5, 0, -1) // none, the float can be 10
+
+	graph := mip.NewConflictGraph(model)
+	want := map[mip.Literal]string{
+		{Var: x, Value: false}: "[{B2 false}]",
+		{Var: x, Value: true}:  "[{B1 true}]",
+		{Var: y, Value: false}: "[{B2 true}]",
+		{Var: y, Value: true}:  "[{B0 true}]",
+		{Var: z, Value: false}: "[{B0 false}]",
+		{Var: z, Value: true}:  "[{B1 false}]",
+	}
+	if graph.Len() != 3 {
+		t.Errorf("got %d conflicts, want 3", graph.Len())
+	}
+	for l, neighbors := range want {
+		if got := fmt.Sprint(graph.Neighbors(l)); got != neighbors {
+			t.Errorf("%v: got neighbors %s, want %s", l, got, neighbors)
+		}
+	}
+	if got := len(graph.Literals()); got != 6 {
+		t.Errorf("got %d literals, want 6", got)
+	}
+	if !graph.Conflicts(mip.Literal{Var: y, Value: false}, mip.Literal{Var: z, Value: true}) {
+		t.Errorf("y = 0 and z = 1 do not conflict")
+	}
+}
@@ -44,6 +44,32 @@ const (
 //
 //	2.5 * x and 3.5 * y are 2 terms in this example
 type Constraint interface {
+	// Attr returns the value of the attribute key of the invoking
+	// constraint. The second return value is false if the attribute has not
+	// been set.
+	Attr(key string) (any, bool)
+	// BindRightHandSide sets the right-hand side of the invoking constraint
+	// to the value of param and keeps it in sync when the value changes, see
+	// Model.SetParam.
+	BindRightHandSide(param Param)
+	// MakeSoft turns the invoking constraint into a soft constraint which may
+	// be violated at a cost. It adds non-negative violation vars to the
+	// constraint, under for the amount the left-hand side falls short of the
+	// right-hand side and over for the amount it exceeds it, and adds them
+	// to the objective with the non-negative penalties, as costs when
+	// minimizing and as losses when maximizing. Only the violation vars the
+	// sense allows are created, under for GreaterThanOrEqual, over for
+	// LessThanOrEqual and both for Equal, the other one is nil. Invoking
+	// MakeSoft on a soft constraint returns its violation vars unchanged.
+	// Changing the direction of the objective afterwards negates the
+	// coefficients of the violation vars, so violations remain penalized.
+	// Panics if a penalty is NaN or negative. See SoftViolations for the
+	// violations in a solution.
+	//
+	// 		c := m.NewConstraint(mip.LessThanOrEqual, 40) // driving hours
+	// 		c.NewTerm(1.0, hours)
+	// 		_, overtime := c.MakeSoft(0, 25.0)
+	MakeSoft(penaltyUnder, penaltyOver float64) (under Var, over Var)
 	// Name returns assigned name. If no name has been set it will return
 	// a unique auto-generated name.
 	Name() string
@@ -59,12 +85,41 @@ type Constraint interface {
 	// 		c.NewTerm(1.0, x)  	 // results in 1.0 * x <= 123.4 in solver
 	// 		c.NewTerm(2.0, x)    // results in 3.0 * x <= 123.4 in solver
 	NewTerm(coefficient float64, variable Var) Term
+	// NewTermChecked adds a term like NewTerm but returns an error instead of
+	// panicking if coefficient is NaN or the model would exceed its limit of
+	// non-zeros.
+	NewTermChecked(coefficient float64, variable Var) (Term, error)
+	// NewParamTerm sets the coefficient of variable in the invoking
+	// constraint to the value of param, like SetTerm, and keeps it in sync
+	// when the value changes, see Model.SetParam. Other terms of variable
+	// are replaced.
+	NewParamTerm(param Param, variable Var) Term
+	// RemoveTerm removes all terms of variable from the invoking constraint.
+	RemoveTerm(variable Var)
 	// RightHandSide returns the right-hand side of the invoking constraint.
 	RightHandSide() float64
 	// Sense returns the sense of the invoking constraint.
 	Sense() Sense
+	// SetAttr sets the attribute key of the invoking constraint to value,
+	// e.g. the ID of the shift the constraint belongs to. A nil value removes
+	// the attribute.
+	SetAttr(key string, value any)
 	// SetName assigns name to invoking constraint
 	SetName(name string)
+	// SetTerm sets the coefficient of variable in the invoking constraint,
+	// replacing the terms added for variable before instead of adding to
+	// them, e.g. to update coefficients in an iterative algorithm. A zero
+	// coefficient removes the terms of variable. Panics like NewTerm.
+	//
+	// 		c.NewTerm(1.0, x)  	 // results in 1.0 * x <= 123.4 in solver
+	// 		c.NewTerm(2.0, x)    // results in 3.0 * x <= 123.4 in solver
+	// 		c.SetTerm(x, 5.0)    // results in 5.0 * x <= 123.4 in solver
+	SetTerm(variable Var, coefficient float64) Term
+	// SetNamef assigns a name formatted according to format and args to the
+	// invoking constraint, see fmt.Sprintf.
+	//
+	// 		c.SetNamef("capacity[%d][%d]", plant, period)
+	SetNamef(format string, args ...any)
 	// Term returns a term for variable with the sum of all coefficients of
 	// defined terms for variable. The second return argument defines how many
 	// terms have been defined on the objective for variable.
@@ -79,28 +134,161 @@ type Constraint interface {
 // Constraints slice of Constraint instances.
 type Constraints []Constraint
 
+// NameConstraints names a generated family of constraints. Each constraint is
+// named by formatting format with the arguments returned by args for the
+// position of the constraint in constraints.
+//
+//	mip.NameConstraints(capacities, "capacity[%d][%d]", func(i int) []any {
+//		return []any{i / periods, i % periods}
+//	})
+func NameConstraints(
+	constraints Constraints,
+	format string,
+	args func(i int) []any,
+) {
+	for i, c := range constraints {
+		c.SetNamef(format, args(i)...)
+	}
+}
+
 type constraint struct {
-	model         *model
-	terms         Terms
+	model *model
+	terms Terms
+	// compact are the terms of a model created by
+	// NewModelWithFloat32Coefficients, terms is empty then.
+	compact       []compactTerm
 	rightHandSide float64
 	sense         Sense
+	name          string
+	// shared is true if terms is shared with a fork or the model it was
+	// forked from, see Model.Fork. The vars of shared terms may belong to
+	// the other model, they are mapped by index.
+	shared bool
+}
+
+// own replaces shared terms by a copy with the vars of the model of the
+// invoking constraint, so they can be changed.
+func (c *constraint) own() {
+	if !c.shared {
+		return
+	}
+	c.terms = c.ownTerms()
+	c.shared = false
+}
+
+// ownTerms returns the terms of the invoking constraint with the vars of its
+// model.
+func (c *constraint) ownTerms() Terms {
+	if c.model.float32 {
+		return c.compactTerms()
+	}
+	if !c.shared {
+		return c.terms
+	}
+	terms := make(Terms, len(c.terms))
+	for i, t := range c.terms {
+		terms[i] = &term{
+			coefficient: t.Coefficient(),
+			variable:    c.model.vars[t.Var().Index()],
+		}
+	}
+	return terms
 }
 
 func (c *constraint) NewTerm(
 	coefficient float64,
 	variable Var,
 ) Term {
-	if math.IsNaN(coefficient) {
-		panic("constraint term coefficient is NaN")
+	t, err := c.NewTermChecked(coefficient, variable)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (c *constraint) NewTermChecked(
+	coefficient float64,
+	variable Var,
+) (Term, error) {
+	if err := checkNaN("constraint term coefficient", coefficient); err != nil {
+		return nil, err
+	}
+	if err := c.model.checkMutable(); err != nil {
+		return nil, err
+	}
+	err := checkLimit("non-zeros", c.model.nonZeros+1, c.model.limits.NonZeros)
+	if err != nil {
+		return nil, err
 	}
-	term := &term{
+	if c.model.float32 {
+		if err := checkFloat32(coefficient); err != nil {
+			return nil, err
+		}
+		if err := checkLimit("vars", variable.Index()+1, math.MaxInt32); err != nil {
+			return nil, err
+		}
+		c.model.nonZeros++
+		c.compact = append(c.compact, compactTerm{
+			index:       int32(variable.Index()),
+			coefficient: float32(coefficient),
+		})
+		return &term{
+			coefficient: float64(float32(coefficient)),
+			variable:    variable,
+		}, nil
+	}
+	c.model.nonZeros++
+
+	t := c.model.arena.term()
+	*t = term{
 		coefficient: coefficient,
 		variable:    variable,
 	}
 
-	c.terms = append(c.terms, term)
+	c.own()
+	c.terms = append(c.terms, t)
 
-	return term
+	return t, nil
+}
+
+func (c *constraint) RemoveTerm(variable Var) {
+	c.model.mustBeMutable()
+	if c.model.float32 {
+		kept := c.compact[:0]
+		for _, t := range c.compact {
+			if int(t.index) != variable.Index() {
+				kept = append(kept, t)
+			}
+		}
+		c.model.nonZeros -= len(c.compact) - len(kept)
+		c.compact = kept
+		return
+	}
+	c.own()
+	kept := c.terms[:0]
+	for _, t := range c.terms {
+		if t.Var().Index() != variable.Index() {
+			kept = append(kept, t)
+		}
+	}
+	// Clear the tail so the removed terms can be garbage collected.
+	clear(c.terms[len(kept):])
+	c.model.nonZeros -= len(c.terms) - len(kept)
+	c.terms = kept
+}
+
+func (c *constraint) SetTerm(variable Var, coefficient float64) Term {
+	if err := checkNaN("constraint term coefficient", coefficient); err != nil {
+		panic(err)
+	}
+	c.RemoveTerm(variable)
+	if coefficient == 0 {
+		return &term{
+			coefficient: coefficient,
+			variable:    variable,
+		}
+	}
+	return c.NewTerm(coefficient, variable)
 }
 
 func (c *constraint) RightHandSide() float64 {
@@ -121,6 +309,12 @@ func (c *constraint) Term(variable Var) (Term, int) {
 			coefficient += t.Coefficient()
 		}
 	}
+	for _, t := range c.compact {
+		if int(t.index) == variable.Index() {
+			definitions++
+			coefficient += float64(t.coefficient)
+		}
+	}
 
 	return &term{
 		coefficient: coefficient,
@@ -129,15 +323,28 @@ func (c *constraint) Term(variable Var) (Term, int) {
 }
 
 func (c *constraint) Terms() Terms {
-	return makeLinearTermsUnique(c.terms)
+	return makeLinearTermsUnique(c.ownTerms())
+}
+
+func (c *constraint) Attr(key string) (any, bool) {
+	return c.model.attributes.get(c, key)
+}
+
+func (c *constraint) SetAttr(key string, value any) {
+	c.model.setAttr(c, key, value)
 }
 
 func (c *constraint) Name() string {
-	return c.model.getConstraintName(c)
+	return c.name
 }
 
 func (c *constraint) SetName(name string) {
-	c.model.setConstraintName(c, name)
+	c.model.mustBeMutable()
+	c.name = name
+}
+
+func (c *constraint) SetNamef(format string, args ...any) {
+	c.SetName(fmt.Sprintf(format, args...))
 }
 
 func (c *constraint) String() string {
@@ -0,0 +1,60 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "fmt"
+
+// ConstraintSpec specifies a constraint generated by ForEach.
+type ConstraintSpec struct {
+	// Sense of the constraint.
+	Sense Sense
+	// RightHandSide of the constraint.
+	RightHandSide float64
+	// Terms of the constraint.
+	Terms []TermSpec
+	// Skip is true if no constraint is generated for the key.
+	Skip bool
+}
+
+// TermSpec specifies a term of a ConstraintSpec.
+type TermSpec struct {
+	// Coefficient of the term.
+	Coefficient float64
+	// Var of the term.
+	Var Var
+}
+
+// ForEach adds a constraint to model for each of keys as specified by spec,
+// e.g. a capacity constraint per plant. Each constraint is named name[key],
+// formatting the key with %v, so constraint names can be traced back to
+// business entities. Returns the generated constraints in the order of keys,
+// skipped keys have no constraint.
+//
+//	capacity := mip.ForEach(model, "capacity", plants, func(p Plant) mip.ConstraintSpec {
+//		spec := mip.ConstraintSpec{Sense: mip.LessThanOrEqual, RightHandSide: p.Capacity}
+//		for _, product := range products {
+//			spec.Terms = append(spec.Terms, mip.TermSpec{Coefficient: 1, Var: x.Get(p, product)})
+//		}
+//		return spec
+//	})
+func ForEach[K comparable](
+	model Model,
+	name string,
+	keys []K,
+	spec func(key K) ConstraintSpec,
+) Constraints {
+	constraints := make(Constraints, 0, len(keys))
+	for _, key := range keys {
+		s := spec(key)
+		if s.Skip {
+			continue
+		}
+		c := model.NewConstraint(s.Sense, s.RightHandSide)
+		for _, t := range s.Terms {
+			c.NewTerm(t.Coefficient, t.Var)
+		}
+		c.SetName(fmt.Sprintf("%s[%v]", name, key))
+		constraints = append(constraints, c)
+	}
+	return constraints
+}
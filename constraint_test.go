@@ -0,0 +1,36 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestSetTermNonZeros(t *testing.T) {
+	model := mip.NewModelWithLimits(mip.ModelLimits{NonZeros: 2})
+	x := model.NewFloat(0, 1)
+	y := model.NewFloat(0, 1)
+	c := model.NewConstraint(mip.LessThanOrEqual, 1)
+
+	// Updating a coefficient repeatedly does not grow the non-zeros.
+	for i := 0; i < 10; i++ {
+		c.SetTerm(x, float64(i+1))
+	}
+	if _, err := c.NewTermChecked(1, y); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := c.NewTermChecked(1, y); err == nil {
+		t.Errorf("expected the non-zero limit to be exceeded")
+	}
+
+	c.RemoveTerm(y)
+	c.SetTerm(x, 0)
+	if len(c.Terms()) != 0 {
+		t.Errorf("got terms %v, want none", c.Terms())
+	}
+	if _, count := c.Term(x); count != 0 {
+		t.Errorf("got %d terms of x, want 0", count)
+	}
+}
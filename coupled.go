@@ -0,0 +1,152 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// SolveCoupled solves model with an exact solver and a heuristic solver (e.g.
+// diving or large neighborhood search) running concurrently on copies of the
+// model. Incumbents found by one solver are handed to the other one if the
+// former is an IncumbentNotifier and the latter an IncumbentAcceptor, which
+// improves the anytime behavior on hard instances. Both solvers receive
+// options. As soon as one solver finishes conclusively, i.e. proves
+// optimality, infeasibility or unboundedness, the other one is interrupted
+// if it is an Interrupter. Returns the best solution of both solvers; the
+// solution of the exact solver if it is optimal. If one solver fails, the
+// solution of the other one is returned together with an error wrapping the
+// failure, failures of an interrupted solver are ignored. Returns
+// ErrNotDeterministic if options request deterministic results, as the
+// exchange of incumbents depends on the timing of both solvers.
+func SolveCoupled(
+	model Model,
+	exact SolverFactory,
+	heuristic SolverFactory,
+	options SolveOptions,
+) (Solution, error) {
+	if options.Deterministic {
+		return nil, fmt.Errorf("%w: coupled solve", ErrNotDeterministic)
+	}
+
+	exactSolver, err := exact(model.Copy())
+	if err != nil {
+		return nil, err
+	}
+	heuristicSolver, err := heuristic(model.Copy())
+	if err != nil {
+		return nil, err
+	}
+
+	forwardIncumbents(exactSolver, heuristicSolver)
+	forwardIncumbents(heuristicSolver, exactSolver)
+
+	exactRun := &coupledRun{name: "exact", solver: exactSolver}
+	heuristicRun := &coupledRun{name: "heuristic", solver: heuristicSolver}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		heuristicRun.solve(options, exactRun)
+	}()
+	exactRun.solve(options, heuristicRun)
+	wg.Wait()
+
+	exactErr, heuristicErr := exactRun.failure(), heuristicRun.failure()
+	switch {
+	case exactErr != nil && heuristicErr != nil:
+		return nil, errors.Join(exactErr, heuristicErr)
+	case exactRun.err != nil:
+		return heuristicRun.solution, exactErr
+	case heuristicRun.err != nil:
+		return exactRun.solution, heuristicErr
+	}
+
+	return bestSolution(
+		model.Objective().IsMaximize(),
+		exactRun.solution,
+		heuristicRun.solution,
+	), nil
+}
+
+// coupledRun is the solve of one of the solvers of SolveCoupled.
+type coupledRun struct {
+	name        string
+	solver      Solver
+	solution    Solution
+	err         error
+	interrupted atomic.Bool
+}
+
+// solve solves with the invoking solver and interrupts other if the solve
+// is conclusive.
+func (r *coupledRun) solve(options SolveOptions, other *coupledRun) {
+	r.solution, r.err = r.solver.Solve(options)
+	if r.err != nil || r.solution == nil || !isConclusive(r.solution) {
+		return
+	}
+	if interrupter, ok := UnwrapSolver(other.solver).(Interrupter); ok {
+		other.interrupted.Store(true)
+		interrupter.Interrupt()
+	}
+}
+
+// failure returns the error of the invoking run, nil if the run succeeded
+// or has been interrupted.
+func (r *coupledRun) failure() error {
+	if r.err == nil || r.interrupted.Load() {
+		return nil
+	}
+	return fmt.Errorf("%s solver: %w", r.name, r.err)
+}
+
+// isConclusive returns true if solution proves optimality, infeasibility or
+// unboundedness.
+func isConclusive(solution Solution) bool {
+	return solution.IsOptimal() || solution.IsInfeasible() || solution.IsUnbounded()
+}
+
+// forwardIncumbents hands the incumbents reported by from to to, if from
+// reports incumbents and to accepts them.
+func forwardIncumbents(from Solver, to Solver) {
+	notifier, ok := UnwrapSolver(from).(IncumbentNotifier)
+	if !ok {
+		return
+	}
+	acceptor, ok := UnwrapSolver(to).(IncumbentAcceptor)
+	if !ok {
+		return
+	}
+	notifier.OnIncumbent(acceptor.AcceptIncumbent)
+}
+
+// bestSolution returns the better of preferred and other. Preferred is
+// returned if it is optimal or has an objective value which is at least as
+// good as the one of other.
+func bestSolution(maximize bool, preferred Solution, other Solution) Solution {
+	switch {
+	case preferred == nil || !preferred.HasValues():
+		if other != nil && other.HasValues() {
+			return other
+		}
+		return preferred
+	case preferred.IsOptimal():
+		return preferred
+	case other == nil || !other.HasValues():
+		return preferred
+	}
+
+	if maximize {
+		if other.ObjectiveValue() > preferred.ObjectiveValue() {
+			return other
+		}
+		return preferred
+	}
+	if other.ObjectiveValue() < preferred.ObjectiveValue() {
+		return other
+	}
+	return preferred
+}
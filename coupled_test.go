@@ -0,0 +1,156 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestSolveCoupled(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewInt(0, 10)
+	model.Objective().NewTerm(1.0, x)
+
+	exactSolution := newTestSolution(5.0, map[mip.Var]float64{x: 5.0})
+	exactSolution.optimal = false
+	exact := &testSolver{solution: exactSolution}
+
+	heuristicSolution := newTestSolution(4.0, map[mip.Var]float64{x: 4.0})
+	heuristicSolution.optimal = false
+	heuristic := &testSolver{
+		solution: heuristicSolution,
+		incumbents: []mip.Incumbent{
+			{ObjectiveValue: 4.0, Values: []float64{4.0}},
+		},
+	}
+
+	solution, err := mip.SolveCoupled(
+		model,
+		exact.factory(),
+		heuristic.factory(),
+		mip.SolveOptions{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solution != heuristicSolution {
+		t.Errorf("expected heuristic solution with better objective")
+	}
+	if len(exact.accepted) != 1 || exact.accepted[0].ObjectiveValue != 4.0 {
+		t.Errorf("expected exact solver to accept incumbent, got %v",
+			exact.accepted,
+		)
+	}
+
+	exactSolution.optimal = true
+	solution, err = mip.SolveCoupled(
+		model,
+		exact.factory(),
+		heuristic.factory(),
+		mip.SolveOptions{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solution != exactSolution {
+		t.Errorf("expected optimal exact solution")
+	}
+
+	exact.err = errors.New("exact failed")
+	heuristic.err = errors.New("heuristic failed")
+	_, err = mip.SolveCoupled(
+		model,
+		exact.factory(),
+		heuristic.factory(),
+		mip.SolveOptions{},
+	)
+	if err == nil {
+		t.Errorf("expected error if both solvers fail")
+	}
+}
+
+func TestSolveCoupledDeterministic(t *testing.T) {
+	model := mip.NewModel()
+	solver := &testSolver{solution: newTestSolution(0, nil)}
+
+	options := mip.SolveOptions{}
+	options.SetDeterministic(true)
+	_, err := mip.SolveCoupled(model, solver.factory(), solver.factory(), options)
+	if !errors.Is(err, mip.ErrNotDeterministic) {
+		t.Errorf("got error %v, want %v", err, mip.ErrNotDeterministic)
+	}
+}
+
+// interruptibleSolver is a Solver whose solve runs until it is interrupted.
+type interruptibleSolver struct {
+	solution    mip.Solution
+	interrupted chan struct{}
+	once        sync.Once
+}
+
+func (s *interruptibleSolver) Solve(_ mip.SolveOptions) (mip.Solution, error) {
+	<-s.interrupted
+	return s.solution, nil
+}
+
+func (s *interruptibleSolver) Interrupt() {
+	s.once.Do(func() { close(s.interrupted) })
+}
+
+func TestSolveCoupledInterrupt(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewInt(0, 10)
+	model.Objective().NewTerm(1.0, x)
+
+	exactSolution := newTestSolution(3.0, map[mip.Var]float64{x: 3.0})
+	exact := &testSolver{solution: exactSolution}
+	heuristicSolution := newTestSolution(4.0, map[mip.Var]float64{x: 4.0})
+	heuristicSolution.optimal = false
+	heuristic := &interruptibleSolver{
+		solution:    heuristicSolution,
+		interrupted: make(chan struct{}),
+	}
+
+	// The heuristic only returns once the optimal exact solve interrupts it.
+	solution, err := mip.SolveCoupled(
+		model,
+		exact.factory(),
+		func(mip.Model) (mip.Solver, error) { return heuristic, nil },
+		mip.SolveOptions{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solution != exactSolution {
+		t.Errorf("expected optimal exact solution")
+	}
+}
+
+func TestSolveCoupledPartialFailure(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewInt(0, 10)
+	model.Objective().NewTerm(1.0, x)
+
+	exactErr := errors.New("exact failed")
+	exact := &testSolver{err: exactErr}
+	heuristicSolution := newTestSolution(4.0, map[mip.Var]float64{x: 4.0})
+	heuristicSolution.optimal = false
+	heuristic := &testSolver{solution: heuristicSolution}
+
+	solution, err := mip.SolveCoupled(
+		model,
+		exact.factory(),
+		heuristic.factory(),
+		mip.SolveOptions{},
+	)
+	if solution != heuristicSolution {
+		t.Errorf("expected heuristic solution")
+	}
+	if !errors.Is(err, exactErr) {
+		t.Errorf("got error %v, want %v", err, exactErr)
+	}
+}
@@ -0,0 +1,73 @@
+// © 2019-present nextmv.io inc
+
+package cuts
+
+import (
+	"fmt"
+	"sort"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// Cliques returns the clique cuts of model violated by values, indexed by
+// Var.Index. A clique is a set of pairwise conflicting literals of bool
+// vars, see mip.NewConflictGraph, at most one of them can hold. A literal
+// x = 1 enters the cut as x, a literal x = 0 as 1 - x. Cliques are grown
+// greedily from the literals with the largest values.
+func Cliques(model mip.Model, values []float64) []Cut {
+	graph := mip.NewConflictGraph(model)
+	value := func(l mip.Literal) float64 {
+		if l.Value {
+			return values[l.Var.Index()]
+		}
+		return 1 - values[l.Var.Index()]
+	}
+
+	candidates := make([]mip.Literal, 0, len(graph.Literals()))
+	for _, l := range graph.Literals() {
+		if value(l) > minViolation {
+			candidates = append(candidates, l)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return value(candidates[i]) > value(candidates[j])
+	})
+
+	var cuts []Cut
+	seen := make(map[string]bool)
+	for _, start := range candidates {
+		clique := []mip.Literal{start}
+		for _, l := range candidates {
+			if l != start && conflictsWithAll(graph, l, clique) {
+				clique = append(clique, l)
+			}
+		}
+		cut := Cut{Kind: Clique, RightHandSide: 1}
+		for _, l := range clique {
+			if l.Value {
+				cut.Terms = append(cut.Terms, Term{Var: l.Var, Coefficient: 1})
+				continue
+			}
+			cut.Terms = append(cut.Terms, Term{Var: l.Var, Coefficient: -1})
+			cut.RightHandSide--
+		}
+		sortTerms(cut.Terms)
+		key := fmt.Sprint(cut.Terms)
+		if len(clique) < 2 || seen[key] || cut.Violation(values) <= minViolation {
+			continue
+		}
+		seen[key] = true
+		cuts = append(cuts, cut)
+	}
+	return cuts
+}
+
+// conflictsWithAll returns true if l conflicts with all literals of clique.
+func conflictsWithAll(graph mip.ConflictGraph, l mip.Literal, clique []mip.Literal) bool {
+	for _, other := range clique {
+		if !graph.Conflicts(l, other) {
+			return false
+		}
+	}
+	return true
+}
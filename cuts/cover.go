@@ -0,0 +1,75 @@
+// © 2019-present nextmv.io inc
+
+package cuts
+
+import (
+	"sort"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// Covers returns the knapsack cover cuts of model violated by values,
+// indexed by Var.Index. A cover of a knapsack constraint, whose terms are
+// bool vars with non-negative coefficients, is a set of its vars whose
+// coefficients exceed the right-hand side, so not all of them can be 1. The
+// cut sum(cover) <= |cover| - 1 is separated with the greedy heuristic of
+// Crowder, Johnson and Padberg and the cover is made minimal. Constraints
+// with other terms are skipped.
+func Covers(model mip.Model, values []float64) []Cut {
+	var cuts []Cut
+	for _, c := range model.Constraints() {
+		for _, r := range rows(c) {
+			if !r.isKnapsack() {
+				continue
+			}
+			if cut, ok := cover(r, values); ok {
+				cuts = append(cuts, cut)
+			}
+		}
+	}
+	return cuts
+}
+
+// cover returns the cover cut of knapsack row r most likely to be violated
+// by values, false if it is not violated.
+func cover(r row, values []float64) (Cut, bool) {
+	items := make([]Term, len(r.terms))
+	copy(items, r.terms)
+	// Prefer items which are 1 in values and have a large coefficient.
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		return (1-values[a.Var.Index()])*b.Coefficient <
+			(1-values[b.Var.Index()])*a.Coefficient
+	})
+
+	weight := 0.0
+	size := 0
+	for size < len(items) && weight <= r.rightHandSide {
+		weight += items[size].Coefficient
+		size++
+	}
+	if weight <= r.rightHandSide {
+		return Cut{}, false
+	}
+
+	// Make the cover minimal, dropping the items with the lowest values
+	// first.
+	covering := items[:size]
+	sort.SliceStable(covering, func(i, j int) bool {
+		return values[covering[i].Var.Index()] < values[covering[j].Var.Index()]
+	})
+	cut := Cut{Kind: Cover}
+	for _, item := range covering {
+		if weight-item.Coefficient > r.rightHandSide {
+			weight -= item.Coefficient
+			continue
+		}
+		cut.Terms = append(cut.Terms, Term{Var: item.Var, Coefficient: 1})
+	}
+	cut.RightHandSide = float64(len(cut.Terms) - 1)
+	if cut.Violation(values) <= minViolation {
+		return Cut{}, false
+	}
+	sortTerms(cut.Terms)
+	return cut, true
+}
@@ -0,0 +1,124 @@
+// © 2019-present nextmv.io inc
+
+package cuts
+
+import (
+	"math"
+	"sort"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// minViolation is the amount by which a point must violate a cut for the cut
+// to be separated.
+const minViolation = 1e-6
+
+// Kind is the family of a cut.
+type Kind string
+
+const (
+	// Cover is a knapsack cover cut, see Covers.
+	Cover Kind = "cover"
+	// Clique is a clique cut, see Cliques.
+	Clique Kind = "clique"
+	// MIR is a mixed-integer rounding cut, see MIRs.
+	MIR Kind = "mir"
+)
+
+// Term is a term of a cut.
+type Term struct {
+	// Var of the term.
+	Var mip.Var
+	// Coefficient of the var.
+	Coefficient float64
+}
+
+// Cut is a valid inequality sum(Coefficient * Var) <= RightHandSide for all
+// integer feasible points of a model.
+type Cut struct {
+	// Kind of the cut.
+	Kind Kind
+	// Terms of the cut, ordered by the index of their var.
+	Terms []Term
+	// RightHandSide of the cut.
+	RightHandSide float64
+}
+
+// Violation returns the amount by which values, indexed by Var.Index,
+// violate the invoking cut, negative if they satisfy it.
+func (c Cut) Violation(values []float64) float64 {
+	activity := 0.0
+	for _, t := range c.Terms {
+		activity += t.Coefficient * values[t.Var.Index()]
+	}
+	return activity - c.RightHandSide
+}
+
+// Add adds the invoking cut to model as a constraint.
+func (c Cut) Add(model mip.Model) mip.Constraint {
+	constraint := model.NewConstraint(mip.LessThanOrEqual, c.RightHandSide)
+	for _, t := range c.Terms {
+		constraint.NewTerm(t.Coefficient, t.Var)
+	}
+	return constraint
+}
+
+// Separate returns the cover, clique and MIR cuts of model violated by
+// values, indexed by Var.Index, in this order.
+func Separate(model mip.Model, values []float64) []Cut {
+	cuts := Covers(model, values)
+	cuts = append(cuts, Cliques(model, values)...)
+	return append(cuts, MIRs(model, values)...)
+}
+
+// row is a constraint or a cut in the form sum(terms) <= rightHandSide.
+type row struct {
+	terms         []Term
+	rightHandSide float64
+}
+
+// rows returns c as rows, two for an equality constraint.
+func rows(c mip.Constraint) []row {
+	terms := c.Terms()
+	less := row{
+		terms:         make([]Term, 0, len(terms)),
+		rightHandSide: c.RightHandSide(),
+	}
+	for _, t := range terms {
+		if t.Coefficient() != 0 {
+			less.terms = append(less.terms, Term{Var: t.Var(), Coefficient: t.Coefficient()})
+		}
+	}
+	greater := row{
+		terms:         make([]Term, len(less.terms)),
+		rightHandSide: -less.rightHandSide,
+	}
+	for i, t := range less.terms {
+		greater.terms[i] = Term{Var: t.Var, Coefficient: -t.Coefficient}
+	}
+	switch c.Sense() {
+	case mip.LessThanOrEqual:
+		return []row{less}
+	case mip.GreaterThanOrEqual:
+		return []row{greater}
+	}
+	return []row{less, greater}
+}
+
+// isKnapsack returns true if r only has bool vars with positive
+// coefficients.
+func (r row) isKnapsack() bool {
+	for _, t := range r.terms {
+		if t.Var.Type() != mip.Binary || t.Coefficient < 0 {
+			return false
+		}
+	}
+	return len(r.terms) > 0 && !math.IsInf(r.rightHandSide, 0)
+}
+
+// sortTerms orders terms by the index of their var.
+func sortTerms(terms []Term) {
+	sort.Slice(terms, func(i, j int) bool {
+		return terms[i].Var.Index() < terms[j].Var.Index()
+	})
+}
@@ -0,0 +1,127 @@
+// © 2019-present nextmv.io inc
+
+package cuts_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+	"github.com/nextmv-io/go-mip/cuts"
+)
+
+// format formats cut like a constraint.
+func format(cut cuts.Cut) string {
+	s := ""
+	for i, t := range cut.Terms {
+		if i > 0 {
+			s += " + "
+		}
+		s += fmt.Sprintf("%v %v", t.Coefficient, t.Var)
+	}
+	return fmt.Sprintf("%s: %s <= %v", cut.Kind, s, cut.RightHandSide)
+}
+
+func TestCovers(t *testing.T) {
+	model := mip.NewModel()
+	x := mip.Vars{model.NewBool(), model.NewBool(), model.NewBool(), model.NewBool()}
+	c := model.NewConstraint(mip.LessThanOrEqual, 12)
+	for i, weight := range []float64{5, 5, 5, 1} {
+		c.NewTerm(weight, x[i])
+	}
+
+	separated := cuts.Covers(model, []float64{0.8, 0.8, 0.8, 0})
+	if len(separated) != 1 {
+		t.Fatalf("got %d cuts, want 1", len(separated))
+	}
+	if got, want := format(separated[0]), "cover: 1 B0 + 1 B1 + 1 B2 <= 2"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// The cut is valid for all feasible assignments.
+	for assignment := 0; assignment < 16; assignment++ {
+		values := make([]float64, 4)
+		weight := 0.0
+		for i := range values {
+			values[i] = float64(assignment >> i & 1)
+			weight += values[i] * []float64{5, 5, 5, 1}[i]
+		}
+		if weight <= 12 && separated[0].Violation(values) > 0 {
+			t.Errorf("cut removes feasible assignment %v", values)
+		}
+	}
+
+	if got := cuts.Covers(model, []float64{1, 0.5, 0.5, 1}); len(got) != 0 {
+		t.Errorf("got cuts %v for a point satisfying all covers", got)
+	}
+}
+
+func TestCliques(t *testing.T) {
+	model := mip.NewModel()
+	x := mip.Vars{model.NewBool(), model.NewBool(), model.NewBool()}
+	for i := range x {
+		c := model.NewConstraint(mip.LessThanOrEqual, 1)
+		c.NewTerm(1, x[i])
+		c.NewTerm(1, x[(i+1)%3])
+	}
+
+	separated := cuts.Cliques(model, []float64{0.5, 0.5, 0.5})
+	if len(separated) != 1 {
+		t.Fatalf("got %d cuts, want 1", len(separated))
+	}
+	if got, want := format(separated[0]), "clique: 1 B0 + 1 B1 + 1 B2 <= 1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	c := separated[0].Add(model)
+	if c.Sense() != mip.LessThanOrEqual || len(c.Terms()) != 3 {
+		t.Errorf("got constraint %v", c)
+	}
+}
+
+func TestMIRs(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewInt(0, 10)
+	y := model.NewFloat(0, 10)
+	c := model.NewConstraint(mip.LessThanOrEqual, 1.5)
+	c.NewTerm(1, x)
+	c.NewTerm(-1, y)
+
+	separated := cuts.MIRs(model, []float64{1.5, 0})
+	if len(separated) != 1 {
+		t.Fatalf("got %d cuts, want 1", len(separated))
+	}
+	if got, want := format(separated[0]), "mir: 1 I0 + -2 F1 <= 1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// The cut is valid for all feasible points with integral x.
+	for xValue := 0.0; xValue <= 10; xValue++ {
+		yValue := math.Max(0, xValue-1.5)
+		if separated[0].Violation([]float64{xValue, yValue}) > 1e-9 {
+			t.Errorf("cut removes feasible point x = %v, y = %v", xValue, yValue)
+		}
+	}
+
+	// A negative lower bound disqualifies the constraint.
+	free := mip.NewModel()
+	c = free.NewConstraint(mip.LessThanOrEqual, 1.5)
+	c.NewTerm(1, free.NewInt(0, 10))
+	c.NewTerm(-1, free.NewFloat(-1, 10))
+	if got := cuts.MIRs(free, []float64{1.5, 0}); len(got) != 0 {
+		t.Errorf("got cuts %v for a constraint with a negative lower bound", got)
+	}
+}
+
+func TestSeparate(t *testing.T) {
+	model := mip.NewModel()
+	x := mip.Vars{model.NewBool(), model.NewBool()}
+	c := model.NewConstraint(mip.LessThanOrEqual, 1)
+	c.NewTerm(1, x[0])
+	c.NewTerm(1, x[1])
+
+	if got := cuts.Separate(model, []float64{0.5, 0.5}); len(got) != 0 {
+		t.Errorf("got cuts %v for a point of the integer hull", got)
+	}
+}
@@ -0,0 +1,19 @@
+// © 2019-present nextmv.io inc
+
+// Package cuts separates valid inequalities which cut off fractional points
+// of the LP relaxation of a model: knapsack covers, cliques and mixed-integer
+// rounding (MIR) cuts. Points are the values of the vars indexed by
+// Var.Index, e.g. the root relaxation values of mip.RelaxationValues. Adding
+// the cuts strengthens the model for back-ends with weak cut generation.
+//
+//	for round := 0; round < 5; round++ {
+//		values := relax(model) // values of the LP relaxation
+//		separated := cuts.Separate(model, values)
+//		if len(separated) == 0 {
+//			break
+//		}
+//		for _, cut := range separated {
+//			cut.Add(model)
+//		}
+//	}
+package cuts
@@ -0,0 +1,108 @@
+// © 2019-present nextmv.io inc
+
+package cuts
+
+import (
+	"math"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// minFraction is the minimum distance of the fractional part of the scaled
+// right-hand side to 0 and 1 for an MIR cut, closer fractions give cuts with
+// large coefficients which are numerically unsafe.
+const minFraction = 0.01
+
+// MIRs returns the mixed-integer rounding cuts of model violated by values,
+// indexed by Var.Index, at most one per constraint. A constraint qualifies
+// if its int and bool vars have a lower bound of 0 and its continuous vars a
+// non-negative lower bound. The constraint is divided by 1 and by the
+// coefficients of its fractional int vars and the most violated rounding,
+// relative to the norm of the cut, is kept, following Marchand and Wolsey.
+func MIRs(model mip.Model, values []float64) []Cut {
+	var cuts []Cut
+	for _, c := range model.Constraints() {
+		for _, r := range rows(c) {
+			if cut, ok := mir(r, values); ok {
+				cuts = append(cuts, cut)
+			}
+		}
+	}
+	return cuts
+}
+
+// mir returns the most violated MIR cut of r, false if none is violated or
+// r does not qualify.
+func mir(r row, values []float64) (Cut, bool) {
+	if math.IsInf(r.rightHandSide, 0) {
+		return Cut{}, false
+	}
+	divisors := []float64{1}
+	for _, t := range r.terms {
+		lower := t.Var.LowerBound()
+		if t.Var.Type() == mip.Continuous {
+			if lower < 0 {
+				return Cut{}, false
+			}
+			continue
+		}
+		if lower != 0 {
+			return Cut{}, false
+		}
+		value := values[t.Var.Index()]
+		if math.Abs(value-math.Round(value)) > minViolation {
+			divisors = append(divisors, math.Abs(t.Coefficient))
+		}
+	}
+
+	best, bestViolation := Cut{}, minViolation
+	for _, divisor := range divisors {
+		cut, ok := round(r, divisor)
+		if !ok {
+			continue
+		}
+		if violation := cut.Violation(values) / norm(cut); violation > bestViolation {
+			best, bestViolation = cut, violation
+		}
+	}
+	return best, best.Terms != nil
+}
+
+// round returns the MIR cut of r divided by divisor, multiplied by divisor.
+// For sum(a x) + sum(c y) <= b with int vars x >= 0 and continuous vars
+// y >= 0 and f the fractional part of b, the cut is sum((floor(a) + max(0,
+// frac(a) - f) / (1 - f)) x) + sum(min(0, c) / (1 - f) y) <= floor(b).
+func round(r row, divisor float64) (Cut, bool) {
+	b := r.rightHandSide / divisor
+	f := b - math.Floor(b)
+	if f < minFraction || f > 1-minFraction {
+		return Cut{}, false
+	}
+	cut := Cut{
+		Kind:          MIR,
+		RightHandSide: math.Floor(b) * divisor,
+	}
+	for _, t := range r.terms {
+		a := t.Coefficient / divisor
+		coefficient := 0.0
+		if t.Var.Type() == mip.Continuous {
+			coefficient = math.Min(0, a) / (1 - f)
+		} else {
+			coefficient = math.Floor(a) + math.Max(0, a-math.Floor(a)-f)/(1-f)
+		}
+		if coefficient != 0 {
+			cut.Terms = append(cut.Terms, Term{Var: t.Var, Coefficient: coefficient * divisor})
+		}
+	}
+	sortTerms(cut.Terms)
+	return cut, len(cut.Terms) > 0
+}
+
+// norm returns the Euclidean norm of the coefficients of cut.
+func norm(cut Cut) float64 {
+	sum := 0.0
+	for _, t := range cut.Terms {
+		sum += t.Coefficient * t.Coefficient
+	}
+	return math.Sqrt(sum)
+}
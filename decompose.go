@@ -0,0 +1,305 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Block is a set of vars and constraints of a model which does not share a
+// var with any other block, see Blocks.
+type Block struct {
+	// Vars of the block, ordered by Var.Index.
+	Vars Vars
+	// Constraints of the block, in the order of Model.Constraints.
+	Constraints Constraints
+}
+
+// Blocks partitions model into independent blocks: two vars are in the same
+// block if a constraint or a quadratic objective term has terms of both.
+// Vars which are not in any constraint are collected in a single block
+// without constraints, constraints without terms are not in any block. The
+// blocks are ordered by the smallest index of their vars. A model with a
+// single block can not be decomposed.
+func Blocks(model Model) []Block {
+	vars := model.Vars()
+	parents := make([]int, len(vars))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	union := func(i, j int) {
+		i, j = find(i), find(j)
+		if i < j {
+			parents[j] = i
+		} else {
+			parents[i] = j
+		}
+	}
+
+	constrained := make([]bool, len(vars))
+	constraints := model.Constraints()
+	for _, c := range constraints {
+		terms := c.Terms()
+		for _, t := range terms {
+			constrained[t.Var().Index()] = true
+			union(terms[0].Var().Index(), t.Var().Index())
+		}
+	}
+	for _, t := range model.Objective().QuadraticTerms() {
+		union(t.Var1().Index(), t.Var2().Index())
+	}
+
+	// Components without constrained vars are collected in a single block.
+	constrainedRoots := map[int]bool{}
+	for i := range vars {
+		if constrained[i] {
+			constrainedRoots[find(i)] = true
+		}
+	}
+	free := -1
+	for i := range vars {
+		if constrainedRoots[find(i)] {
+			continue
+		}
+		if free < 0 {
+			free = i
+		}
+		union(free, i)
+	}
+
+	indices := map[int]int{}
+	var blocks []Block
+	for i, v := range vars {
+		root := find(i)
+		index, ok := indices[root]
+		if !ok {
+			index = len(blocks)
+			indices[root] = index
+			blocks = append(blocks, Block{})
+		}
+		blocks[index].Vars = append(blocks[index].Vars, v)
+	}
+	for _, c := range constraints {
+		terms := c.Terms()
+		if len(terms) == 0 {
+			continue
+		}
+		index := indices[find(terms[0].Var().Index())]
+		blocks[index].Constraints = append(blocks[index].Constraints, c)
+	}
+	return blocks
+}
+
+// SolveDecomposed solves the independent blocks of model, see Blocks, as
+// separate models in parallel, each with a solver created by factory, and
+// combines their solutions. Models consisting of independent regions solve
+// in a fraction of the wall-clock time this way. At most parallelism blocks
+// are solved at the same time, runtime.GOMAXPROCS if not positive, which
+// also bounds the number of back-end instances and licenses in use. All
+// solvers receive options, the duration limit applies to each block.
+//
+// The combined solution is optimal if the solutions of all blocks are, and
+// infeasible, unbounded or timed out if the solution of any block is. It has
+// values if the solutions of all blocks have values, the objective value is
+// the sum of the objective values of the blocks. If a constraint without
+// terms is violated, the blocks are not solved and the combined solution is
+// infeasible without values.
+func SolveDecomposed(
+	model Model,
+	factory SolverFactory,
+	options SolveOptions,
+	parallelism int,
+) (Solution, error) {
+	start := time.Now()
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	// Constraints without terms are in no block.
+	for _, c := range model.Constraints() {
+		if len(c.Terms()) == 0 && constraintViolation(c, 0) > 0 {
+			return &decomposedSolution{runTime: time.Since(start), infeasible: true}, nil
+		}
+	}
+	blocks := Blocks(model)
+	solutions := make([]Solution, len(blocks))
+	blockVars := make([]Vars, len(blocks))
+	errs := make([]error, len(blocks))
+
+	semaphore := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, block := range blocks {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, block Block) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			sub, vars := blockModel(model, block)
+			blockVars[i] = vars
+			solver, err := factory(sub)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			solutions[i], errs[i] = solver.Solve(options)
+		}(i, block)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	solution := &decomposedSolution{
+		values:  make(map[int]float64, len(model.Vars())),
+		runTime: time.Since(start),
+		optimal: true,
+	}
+	solution.combine(blocks, blockVars, solutions)
+	return solution, nil
+}
+
+// blockModel returns a model with the vars and constraints of block and the
+// objective terms of its vars. The returned vars of the model correspond to
+// block.Vars.
+func blockModel(model Model, block Block) (Model, Vars) {
+	sub := NewModel()
+	indices := make(map[int]int, len(block.Vars))
+	vars := make(Vars, len(block.Vars))
+	for i, v := range block.Vars {
+		indices[v.Index()] = i
+		vars[i] = newVarLike(sub, v)
+		vars[i].SetName(v.Name())
+	}
+
+	objective := sub.Objective()
+	if model.Objective().IsMaximize() {
+		objective.SetMaximize()
+	}
+	for _, t := range model.Objective().Terms() {
+		if i, ok := indices[t.Var().Index()]; ok {
+			objective.NewTerm(t.Coefficient(), vars[i])
+		}
+	}
+	for _, t := range model.Objective().QuadraticTerms() {
+		if i, ok := indices[t.Var1().Index()]; ok {
+			objective.NewQuadraticTerm(t.Coefficient(), vars[i], vars[indices[t.Var2().Index()]])
+		}
+	}
+
+	for _, c := range block.Constraints {
+		constraint := sub.NewConstraint(c.Sense(), c.RightHandSide())
+		constraint.SetName(c.Name())
+		for _, t := range c.Terms() {
+			constraint.NewTerm(t.Coefficient(), vars[indices[t.Var().Index()]])
+		}
+	}
+	return sub, vars
+}
+
+// decomposedSolution combines the solutions of the blocks of a model.
+type decomposedSolution struct {
+	values           map[int]float64
+	objectiveValue   float64
+	provider         SolverProvider
+	runTime          time.Duration
+	hasValues        bool
+	infeasible       bool
+	numericalFailure bool
+	optimal          bool
+	timeOut          bool
+	unbounded        bool
+}
+
+// combine combines the solutions of blocks, solutions[i] assigns values to
+// blockVars[i], the vars of the model of blocks[i].
+func (s *decomposedSolution) combine(
+	blocks []Block,
+	blockVars []Vars,
+	solutions []Solution,
+) {
+	s.hasValues = true
+	for i, solution := range solutions {
+		if solution == nil {
+			s.hasValues = false
+			s.optimal = false
+			continue
+		}
+		if s.provider == "" {
+			s.provider = solution.Provider()
+		}
+		s.infeasible = s.infeasible || solution.IsInfeasible()
+		s.numericalFailure = s.numericalFailure || solution.IsNumericalFailure()
+		s.timeOut = s.timeOut || solution.IsTimeOut()
+		s.unbounded = s.unbounded || solution.IsUnbounded()
+		s.optimal = s.optimal && solution.IsOptimal()
+		if !s.hasValues || !solution.HasValues() {
+			s.hasValues = false
+			continue
+		}
+		s.objectiveValue += solution.ObjectiveValue()
+		for j, v := range blocks[i].Vars {
+			s.values[v.Index()] = solution.Value(blockVars[i][j])
+		}
+	}
+	if !s.hasValues {
+		s.values = nil
+		s.objectiveValue = 0
+	}
+}
+
+func (s *decomposedSolution) HasValues() bool {
+	return s.hasValues
+}
+
+func (s *decomposedSolution) IsInfeasible() bool {
+	return s.infeasible
+}
+
+func (s *decomposedSolution) IsNumericalFailure() bool {
+	return s.numericalFailure
+}
+
+func (s *decomposedSolution) IsOptimal() bool {
+	return s.optimal && !s.infeasible && !s.unbounded
+}
+
+func (s *decomposedSolution) IsSubOptimal() bool {
+	return s.hasValues && !s.IsOptimal()
+}
+
+func (s *decomposedSolution) IsTimeOut() bool {
+	return s.timeOut
+}
+
+func (s *decomposedSolution) IsUnbounded() bool {
+	return s.unbounded
+}
+
+func (s *decomposedSolution) ObjectiveValue() float64 {
+	return s.objectiveValue
+}
+
+func (s *decomposedSolution) Provider() SolverProvider {
+	return s.provider
+}
+
+func (s *decomposedSolution) RunTime() time.Duration {
+	return s.runTime
+}
+
+func (s *decomposedSolution) Value(variable Var) float64 {
+	if !s.hasValues {
+		return math.MaxFloat64
+	}
+	return s.values[variable.Index()]
+}
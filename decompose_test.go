@@ -0,0 +1,145 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// enumeratingSolver solves models of bool vars by enumerating all
+// assignments.
+type enumeratingSolver struct {
+	model mip.Model
+}
+
+func (s enumeratingSolver) Solve(mip.SolveOptions) (mip.Solution, error) {
+	vars := s.model.Vars()
+	maximize := s.model.Objective().IsMaximize()
+	var best map[mip.Var]float64
+	bestValue := math.Inf(1)
+	for mask := 0; mask < 1<<len(vars); mask++ {
+		assignment := make(map[mip.Var]float64, len(vars))
+		for i, v := range vars {
+			assignment[v] = float64(mask >> i & 1)
+		}
+		evaluation := mip.Evaluate(s.model, assignment)
+		value := evaluation.ObjectiveValue
+		if maximize {
+			value = -value
+		}
+		if evaluation.TotalViolation == 0 && value < bestValue {
+			best, bestValue = assignment, value
+		}
+	}
+	if best == nil {
+		return &testSolution{}, nil
+	}
+	if maximize {
+		bestValue = -bestValue
+	}
+	return newTestSolution(bestValue, best), nil
+}
+
+func TestSolveDecomposed(t *testing.T) {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	// Two independent knapsacks and an unconstrained var.
+	var items mip.Vars
+	for region := 0; region < 2; region++ {
+		c := model.NewConstraint(mip.LessThanOrEqual, 6)
+		for i, weight := range []float64{5, 4, 1} {
+			item := model.NewBool()
+			items = append(items, item)
+			model.Objective().NewTerm([]float64{10, 7, 3}[i], item)
+			c.NewTerm(weight, item)
+		}
+	}
+	bonus := model.NewBool()
+	model.Objective().NewTerm(1, bonus)
+
+	blocks := mip.Blocks(model)
+	if len(blocks) != 3 {
+		t.Fatalf("got %d blocks, want 3", len(blocks))
+	}
+	if len(blocks[2].Vars) != 1 || len(blocks[2].Constraints) != 0 {
+		t.Errorf("got unconstrained block %v", blocks[2])
+	}
+
+	var solves atomic.Int64
+	solution, err := mip.SolveDecomposed(model, func(m mip.Model) (mip.Solver, error) {
+		solves.Add(1)
+		return enumeratingSolver{model: m}, nil
+	}, mip.SolveOptions{Duration: time.Second}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solves.Load() != 3 {
+		t.Errorf("got %d solves, want 3", solves.Load())
+	}
+	if !solution.IsOptimal() || solution.ObjectiveValue() != 27 {
+		t.Errorf("got optimal %v, objective %v, want true, 27", solution.IsOptimal(), solution.ObjectiveValue())
+	}
+	want := []float64{1, 0, 1, 1, 0, 1}
+	for i, item := range items {
+		if solution.Value(item) != want[i] {
+			t.Errorf("item %d = %v, want %v", i, solution.Value(item), want[i])
+		}
+	}
+	if solution.Value(bonus) != 1 {
+		t.Errorf("bonus = %v, want 1", solution.Value(bonus))
+	}
+}
+
+func TestSolveDecomposedConstraintWithoutTerms(t *testing.T) {
+	model := mip.NewModel()
+	model.Objective().NewTerm(1, model.NewBool())
+	model.NewConstraint(mip.LessThanOrEqual, 1)
+	factory := func(m mip.Model) (mip.Solver, error) {
+		return enumeratingSolver{model: m}, nil
+	}
+	solution, err := mip.SolveDecomposed(model, factory, mip.SolveOptions{}, 0)
+	if err != nil || !solution.IsOptimal() {
+		t.Fatalf("got solution %v and error %v, want optimal", solution, err)
+	}
+
+	model.NewConstraint(mip.GreaterThanOrEqual, 1)
+	solution, err = mip.SolveDecomposed(model, factory, mip.SolveOptions{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !solution.IsInfeasible() || solution.IsOptimal() || solution.HasValues() {
+		t.Errorf("got infeasible %v, optimal %v, values %v, want an infeasible solution",
+			solution.IsInfeasible(), solution.IsOptimal(), solution.HasValues())
+	}
+}
+
+func TestSolveDecomposedParallelism(t *testing.T) {
+	model := mip.NewModel()
+	for i := 0; i < 8; i++ {
+		model.NewConstraint(mip.LessThanOrEqual, 1).NewTerm(1, model.NewBool())
+	}
+	var running, peak atomic.Int64
+	_, err := mip.SolveDecomposed(model, func(m mip.Model) (mip.Solver, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return enumeratingSolver{model: m}, nil
+	}, mip.SolveOptions{}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peak.Load() > 2 {
+		t.Errorf("got %d concurrent solvers, want at most 2", peak.Load())
+	}
+}
@@ -0,0 +1,53 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ErrNotDeterministic is returned when deterministic results are requested
+// from a solve which can not guarantee them. It is an ErrUnsupportedFeature.
+var ErrNotDeterministic = NewKindError(ErrUnsupportedFeature, "deterministic solve not supported")
+
+// deterministicProviders are the providers which honor
+// SolveOptions.Deterministic. HiGHS and FICO Xpress both run their parallel
+// search deterministically given a fixed seed.
+var deterministicProviders = struct {
+	sync.RWMutex
+	providers map[SolverProvider]bool
+}{
+	providers: map[SolverProvider]bool{
+		"highs":  true,
+		"xpress": true,
+	},
+}
+
+// SetDeterministicSupport declares whether provider honors
+// SolveOptions.Deterministic. Back-ends not known to this package register
+// themselves when they can guarantee reproducible results.
+func SetDeterministicSupport(provider SolverProvider, supported bool) {
+	deterministicProviders.Lock()
+	defer deterministicProviders.Unlock()
+	deterministicProviders.providers[provider] = supported
+}
+
+// SupportsDeterministic returns true if provider honors
+// SolveOptions.Deterministic.
+func SupportsDeterministic(provider SolverProvider) bool {
+	deterministicProviders.RLock()
+	defer deterministicProviders.RUnlock()
+	return deterministicProviders.providers[provider]
+}
+
+// CheckDeterministic returns an error wrapping ErrNotDeterministic if options
+// request deterministic results and provider does not honor them. Back-ends
+// invoke it before solving, so a run which must be reproducible never
+// silently produces results which are not.
+func CheckDeterministic(provider SolverProvider, options SolveOptions) error {
+	if options.Deterministic && !SupportsDeterministic(provider) {
+		return fmt.Errorf("%w: provider %q", ErrNotDeterministic, provider)
+	}
+	return nil
+}
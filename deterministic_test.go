@@ -0,0 +1,31 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestCheckDeterministic(t *testing.T) {
+	options := mip.SolveOptions{}
+	if err := mip.CheckDeterministic("test-deterministic", options); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	options.SetDeterministic(true)
+	if err := mip.CheckDeterministic("highs", options); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	err := mip.CheckDeterministic("test-deterministic", options)
+	if !errors.Is(err, mip.ErrNotDeterministic) {
+		t.Errorf("got error %v, want %v", err, mip.ErrNotDeterministic)
+	}
+
+	mip.SetDeterministicSupport("test-deterministic", true)
+	if err := mip.CheckDeterministic("test-deterministic", options); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
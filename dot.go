@@ -0,0 +1,97 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// DOTOptions configure WriteDOT.
+type DOTOptions struct {
+	// CollapseGroups draws the vars of a group, see Var.SetGroup, as a
+	// single node. Edges between a group and a constraint are labeled with
+	// the number of terms they represent if there is more than one. Vars
+	// without a group are drawn individually.
+	CollapseGroups bool `json:"collapse_groups"`
+}
+
+// WriteDOT writes the structure of model to w as an undirected bipartite
+// graph in the Graphviz DOT language. Vars are drawn as ellipses and
+// constraints as boxes, an edge connects a var and a constraint if the
+// constraint has a term of the var. Visualizing the graph reveals blocks of
+// vars and constraints and the vars and constraints coupling them:
+//
+//	err := mip.WriteDOT(f, model, mip.DOTOptions{CollapseGroups: true})
+//
+//	$ sfdp -Tsvg model.dot > model.svg
+func WriteDOT(w io.Writer, model Model, options DOTOptions) error {
+	writer := bufio.NewWriter(w)
+	vars := model.Vars()
+	constraints := model.Constraints()
+
+	nodes := make([]string, len(vars))
+	groups := map[string]string{}
+	var groupNames []string
+	for i, v := range vars {
+		nodes[i] = fmt.Sprintf("v%d", i)
+		if !options.CollapseGroups || v.Group() == "" {
+			continue
+		}
+		if _, ok := groups[v.Group()]; !ok {
+			groups[v.Group()] = ""
+			groupNames = append(groupNames, v.Group())
+		}
+	}
+	sort.Strings(groupNames)
+	for i, group := range groupNames {
+		groups[group] = fmt.Sprintf("g%d", i)
+	}
+
+	fmt.Fprintln(writer, "graph model {")
+	fmt.Fprintln(writer, "  node [shape=ellipse];")
+	for i, v := range vars {
+		if group, ok := groups[v.Group()]; ok {
+			nodes[i] = group
+			continue
+		}
+		fmt.Fprintf(writer, "  %s [label=%s];\n", nodes[i], strconv.Quote(fmt.Sprint(v)))
+	}
+	for _, group := range groupNames {
+		fmt.Fprintf(writer, "  %s [label=%s, style=bold];\n", groups[group], strconv.Quote(group))
+	}
+
+	fmt.Fprintln(writer, "  node [shape=box];")
+	for i, c := range constraints {
+		name := c.Name()
+		if name == "" {
+			name = fmt.Sprintf("c%d", i)
+		}
+		fmt.Fprintf(writer, "  c%d [label=%s];\n", i, strconv.Quote(name))
+	}
+
+	for i, c := range constraints {
+		counts := map[string]int{}
+		var adjacent []string
+		for _, t := range sortedTerms(c.Terms()) {
+			node := nodes[t.Var().Index()]
+			if counts[node] == 0 {
+				adjacent = append(adjacent, node)
+			}
+			counts[node]++
+		}
+		for _, node := range adjacent {
+			if counts[node] > 1 {
+				fmt.Fprintf(writer, "  %s -- c%d [label=%d];\n", node, i, counts[node])
+				continue
+			}
+			fmt.Fprintf(writer, "  %s -- c%d;\n", node, i)
+		}
+	}
+
+	fmt.Fprintln(writer, "}")
+	return writer.Flush()
+}
@@ -0,0 +1,181 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Enumeration is the result of Enumerate.
+type Enumeration struct {
+	// Solutions in the order they were found, the first one is optimal.
+	// Their values are the values of the vars of the enumerated model.
+	Solutions []Solution
+	// Distances are the number of bool vars whose values differ between
+	// two solutions, the Hamming distance, by the indices of the solutions.
+	Distances [][]int
+	// MinDistance is the smallest distance between two solutions, 0 if
+	// there are less than two.
+	MinDistance int
+	// MeanDistance is the mean distance between two solutions, 0 if there
+	// are less than two.
+	MeanDistance float64
+}
+
+// Enumerate returns up to limit solutions of model whose objective values
+// are within the relative gap of the optimal objective value, e.g. to offer
+// planners alternative plans. Solutions differ in the values of the bool
+// vars, each solution is found with a solver of provider after adding a
+// no-good cut which excludes the bool assignments of the solutions found
+// before, see ExcludeSolution. The solver interface does not expose the
+// solution pools of back-ends, so they are not used. Model is not changed,
+// the cuts are added to a copy. Enumeration stops early if no further
+// solution exists. Returns an error if the objective is not linear, model has
+// no bool vars and limit exceeds 1, a solve fails or the first solution is
+// not optimal, e.g. because a limit of options stopped the solve.
+//
+//	enumeration, err := mip.Enumerate(model, "highs", options, 0.01, 10)
+func Enumerate(
+	model Model,
+	provider SolverProvider,
+	options SolveOptions,
+	gap float64,
+	limit int,
+) (Enumeration, error) {
+	enumeration := Enumeration{}
+	if !model.Objective().IsLinear() {
+		return enumeration, errors.New("enumerated model must have a linear objective")
+	}
+	binaries := binaryVars(model)
+	if len(binaries) == 0 && limit > 1 {
+		return enumeration, fmt.Errorf("enumerated model has no bool vars to tell %d solutions apart", limit)
+	}
+
+	sub := model.Copy()
+	vars := sub.Vars()
+	subBinaries := binaryVars(sub)
+	for len(enumeration.Solutions) < limit {
+		solution, err := solveOnce(sub, provider, options)
+		if err != nil {
+			return enumeration, err
+		}
+		if !solution.HasValues() {
+			break
+		}
+		mapped := &mappedSolution{Solution: solution, vars: vars}
+		if len(enumeration.Solutions) == 0 {
+			if !solution.IsOptimal() {
+				return enumeration, errors.New("first enumerated solution is not optimal")
+			}
+			withinGap(sub, solution.ObjectiveValue(), gap)
+		}
+		enumeration.Solutions = append(enumeration.Solutions, mapped)
+		excludeAssignment(sub, subBinaries, solution.Value)
+	}
+
+	enumeration.diversity(binaries)
+	return enumeration, nil
+}
+
+// withinGap constrains the objective value of model to be within the
+// relative gap of optimum.
+func withinGap(model Model, optimum float64, gap float64) {
+	objective := model.Objective()
+	sense := LessThanOrEqual
+	bound := optimum + gap*math.Abs(optimum)
+	if objective.IsMaximize() {
+		sense = GreaterThanOrEqual
+		bound = optimum - gap*math.Abs(optimum)
+	}
+	c := model.NewConstraint(sense, bound)
+	for _, t := range objective.Terms() {
+		c.NewTerm(t.Coefficient(), t.Var())
+	}
+}
+
+// binaryVars returns the vars of model of type Binary.
+func binaryVars(model Model) Vars {
+	var binaries Vars
+	for _, v := range model.Vars() {
+		if v.Type() == Binary {
+			binaries = append(binaries, v)
+		}
+	}
+	return binaries
+}
+
+// ExcludeSolution adds the no-good cut to model which excludes the values of
+// the bool vars in solution, e.g. to ask for a different plan. At least one
+// bool var must change its value, the values of the other vars are not
+// restricted. Returns the cut, an error wrapping ErrNoValues if solution has
+// no values and an error if model has no bool vars, as the cut would make
+// the model infeasible.
+//
+//	if _, err := mip.ExcludeSolution(model, solution); err != nil {
+//		...
+//	}
+//	alternative, err := solver.Solve(options)
+func ExcludeSolution(model Model, solution Solution) (Constraint, error) {
+	if !solution.HasValues() {
+		return nil, ErrNoValues
+	}
+	binaries := binaryVars(model)
+	if len(binaries) == 0 {
+		return nil, errors.New("model has no bool vars to exclude a solution")
+	}
+	return excludeAssignment(model, binaries, solution.Value), nil
+}
+
+// excludeAssignment adds the no-good cut to model which excludes the
+// assignment of binaries by value: the number of vars which change their
+// value, the sum of the vars which are 0 plus the sum of 1 minus the vars
+// which are 1, is at least 1. Returns the cut.
+func excludeAssignment(model Model, binaries Vars, value func(Var) float64) Constraint {
+	ones := 0
+	for _, v := range binaries {
+		if value(v) > 0.5 {
+			ones++
+		}
+	}
+	c := model.NewConstraint(GreaterThanOrEqual, float64(1-ones))
+	for _, v := range binaries {
+		if value(v) > 0.5 {
+			c.NewTerm(-1, v)
+			continue
+		}
+		c.NewTerm(1, v)
+	}
+	return c
+}
+
+// diversity sets the distances between the solutions of the invoking
+// enumeration in the values of binaries.
+func (e *Enumeration) diversity(binaries Vars) {
+	n := len(e.Solutions)
+	e.Distances = make([][]int, n)
+	for i := range e.Distances {
+		e.Distances[i] = make([]int, n)
+	}
+	pairs, total := 0, 0
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			distance := 0
+			for _, v := range binaries {
+				if (e.Solutions[i].Value(v) > 0.5) != (e.Solutions[j].Value(v) > 0.5) {
+					distance++
+				}
+			}
+			e.Distances[i][j], e.Distances[j][i] = distance, distance
+			if pairs == 0 || distance < e.MinDistance {
+				e.MinDistance = distance
+			}
+			pairs++
+			total += distance
+		}
+	}
+	if pairs > 0 {
+		e.MeanDistance = float64(total) / float64(pairs)
+	}
+}
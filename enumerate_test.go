@@ -0,0 +1,100 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestEnumerate(t *testing.T) {
+	mip.RegisterSolverProvider("test-enumerate", func(model mip.Model) (mip.Solver, error) {
+		return enumeratingSolver{model: model}, nil
+	})
+
+	// Pick two of four items, the values of the items are 10, 9, 5 and 1.
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	c := model.NewConstraint(mip.Equal, 2)
+	for _, value := range []float64{10, 9, 5, 1} {
+		item := model.NewBool()
+		model.Objective().NewTerm(value, item)
+		c.NewTerm(1, item)
+	}
+
+	enumeration, err := mip.Enumerate(model, "test-enumerate", mip.SolveOptions{}, 0.3, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 19, 15 and 14 are within 30% of 19, 11 is not.
+	var values []float64
+	for _, solution := range enumeration.Solutions {
+		values = append(values, solution.ObjectiveValue())
+	}
+	if len(values) != 3 || values[0] != 19 || values[1] != 15 || values[2] != 14 {
+		t.Fatalf("got objective values %v, want [19 15 14]", values)
+	}
+	if enumeration.MinDistance != 2 || enumeration.Distances[1][2] != 2 {
+		t.Errorf("got distances %v, want at least 2", enumeration.Distances)
+	}
+	if len(model.Constraints()) != 1 {
+		t.Errorf("enumerating changed the model")
+	}
+
+	enumeration, err = mip.Enumerate(model, "test-enumerate", mip.SolveOptions{}, 1, 2)
+	if err != nil || len(enumeration.Solutions) != 2 {
+		t.Errorf("got %d solutions, %v, want 2", len(enumeration.Solutions), err)
+	}
+}
+
+// suboptimalSolver is an enumeratingSolver which does not prove optimality,
+// e.g. because it hit a limit.
+type suboptimalSolver struct {
+	enumeratingSolver
+}
+
+func (s suboptimalSolver) Solve(options mip.SolveOptions) (mip.Solution, error) {
+	solution, err := s.enumeratingSolver.Solve(options)
+	solution.(*testSolution).optimal = false
+	return solution, err
+}
+
+func TestEnumerateNotOptimal(t *testing.T) {
+	mip.RegisterSolverProvider("test-enumerate-not-optimal", func(model mip.Model) (mip.Solver, error) {
+		return suboptimalSolver{enumeratingSolver{model: model}}, nil
+	})
+	model := mip.NewModel()
+	model.Objective().NewTerm(1, model.NewBool())
+
+	enumeration, err := mip.Enumerate(model, "test-enumerate-not-optimal", mip.SolveOptions{}, 0.1, 2)
+	if err == nil || len(enumeration.Solutions) != 0 {
+		t.Errorf("got %d solutions and error %v, want an error", len(enumeration.Solutions), err)
+	}
+}
+
+func TestExcludeSolution(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewBool()
+	y := model.NewBool()
+	model.NewFloat(0, 1)
+
+	c, err := mip.ExcludeSolution(model, newTestSolution(0, map[mip.Var]float64{x: 1, y: 0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(c), "-1 B0 + 1 B1 >= 0"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if _, err := mip.ExcludeSolution(model, &testSolution{}); !errors.Is(err, mip.ErrNoValues) {
+		t.Errorf("got error %v, want %v", err, mip.ErrNoValues)
+	}
+	floats := mip.NewModel()
+	floats.NewFloat(0, 1)
+	if _, err := mip.ExcludeSolution(floats, newTestSolution(0, nil)); err == nil {
+		t.Errorf("expected an error for a model without bool vars")
+	}
+}
@@ -0,0 +1,99 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of errors returned by back-ends. Back-ends wrap them, see
+// SolverError and NewKindError, so callers branch on the kind of an error
+// with errors.Is instead of matching its message:
+//
+//	solution, err := solver.Solve(options)
+//	switch {
+//	case errors.Is(err, mip.ErrLicense):
+//		// Retry on another machine.
+//	case errors.Is(err, mip.ErrInterrupted):
+//		// Report the cancellation.
+//	}
+var (
+	// ErrUnsupportedFeature is returned when a model or options use a
+	// feature the back-end does not support, e.g. a quadratic objective or
+	// deterministic results.
+	ErrUnsupportedFeature = errors.New("unsupported feature")
+	// ErrLicense is returned when the license of a back-end is missing,
+	// expired or can not be acquired.
+	ErrLicense = errors.New("license error")
+	// ErrTranslation is returned when the model can not be translated to
+	// the back-end.
+	ErrTranslation = errors.New("translation error")
+	// ErrNumerical is returned when the back-end fails for numerical
+	// reasons, e.g. an ill-conditioned basis.
+	ErrNumerical = errors.New("numerical error")
+	// ErrInterrupted is returned when a solve is interrupted before it
+	// reaches a conclusion, e.g. by a signal or a cancelled context.
+	ErrInterrupted = errors.New("solve interrupted")
+)
+
+// kindError is an error with its own message which wraps its kind.
+type kindError struct {
+	kind error
+	text string
+}
+
+func (e *kindError) Error() string {
+	return e.text
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// NewKindError returns an error with text which wraps kind, one of the
+// kinds such as ErrLicense. Back-ends use it to define their own sentinel
+// errors, which callers can check for themselves or for their kind:
+//
+//	var ErrNoTokens = mip.NewKindError(mip.ErrLicense, "no license tokens available")
+func NewKindError(kind error, text string) error {
+	return &kindError{kind: kind, text: text}
+}
+
+// SolverError is an error reported by a back-end. It wraps its kind, such as
+// ErrNumerical, and the error of the back-end, if any, so both can be
+// checked with errors.Is and the details retrieved with errors.As:
+//
+//	var solverErr *mip.SolverError
+//	if errors.As(err, &solverErr) {
+//		log.Printf("%s failed with code %d", solverErr.Provider, solverErr.Code)
+//	}
+type SolverError struct {
+	// Provider is the back-end reporting the error.
+	Provider SolverProvider
+	// Kind is the kind of the error, e.g. ErrNumerical.
+	Kind error
+	// Code is the status or error code of the back-end, 0 if it has none.
+	Code int
+	// Err is the underlying error, nil if there is none.
+	Err error
+}
+
+func (e *SolverError) Error() string {
+	message := fmt.Sprintf("%s: %v", e.Provider, e.Kind)
+	if e.Code != 0 {
+		message += fmt.Sprintf(" (code %d)", e.Code)
+	}
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Unwrap returns the kind and the underlying error of the invoking error.
+func (e *SolverError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
@@ -0,0 +1,66 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestKindError(t *testing.T) {
+	errNoTokens := mip.NewKindError(mip.ErrLicense, "no license tokens available")
+	err := fmt.Errorf("%w: gurobi", errNoTokens)
+	if !errors.Is(err, errNoTokens) || !errors.Is(err, mip.ErrLicense) {
+		t.Errorf("got error %v, want it to be %v and %v", err, errNoTokens, mip.ErrLicense)
+	}
+	if errors.Is(err, mip.ErrNumerical) {
+		t.Errorf("got error %v, want it not to be %v", err, mip.ErrNumerical)
+	}
+	if got, want := errNoTokens.Error(), "no license tokens available"; got != want {
+		t.Errorf("got message %q, want %q", got, want)
+	}
+
+	kinds := []struct {
+		err  error
+		kind error
+	}{
+		{mip.ErrNotDeterministic, mip.ErrUnsupportedFeature},
+		{mip.ErrConfigNotSupported, mip.ErrUnsupportedFeature},
+		{mip.ErrTranslateNotSupported, mip.ErrUnsupportedFeature},
+		{mip.ErrBuildTimeLimit, mip.ErrTranslation},
+	}
+	for _, kind := range kinds {
+		if !errors.Is(kind.err, kind.kind) {
+			t.Errorf("got error %v, want it to be %v", kind.err, kind.kind)
+		}
+	}
+}
+
+func TestSolverError(t *testing.T) {
+	cause := errors.New("singular basis")
+	err := fmt.Errorf("solving: %w", &mip.SolverError{
+		Provider: "highs",
+		Kind:     mip.ErrNumerical,
+		Code:     7,
+		Err:      cause,
+	})
+	if !errors.Is(err, mip.ErrNumerical) || !errors.Is(err, cause) {
+		t.Errorf("got error %v, want it to be %v and %v", err, mip.ErrNumerical, cause)
+	}
+	var solverErr *mip.SolverError
+	if !errors.As(err, &solverErr) || solverErr.Code != 7 {
+		t.Fatalf("got error %v, want a solver error with code 7", err)
+	}
+	want := "solving: highs: numerical error (code 7): singular basis"
+	if err.Error() != want {
+		t.Errorf("got message %q, want %q", err, want)
+	}
+
+	interrupted := &mip.SolverError{Provider: "xpress", Kind: mip.ErrInterrupted}
+	if !errors.Is(interrupted, mip.ErrInterrupted) || interrupted.Error() != "xpress: solve interrupted" {
+		t.Errorf("got error %v, want xpress: solve interrupted", interrupted)
+	}
+}
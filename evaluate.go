@@ -0,0 +1,85 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+// ConstraintEvaluation is the evaluation of a constraint for an assignment.
+type ConstraintEvaluation struct {
+	// Constraint which is evaluated.
+	Constraint Constraint
+	// Activity is the value of the left-hand side of the constraint.
+	Activity float64
+	// Slack is the distance of the activity to the right-hand side in the
+	// feasible direction. For a less than or equal constraint this is the
+	// right-hand side minus the activity, for a greater than or equal
+	// constraint the activity minus the right-hand side and for an equality
+	// constraint the negated absolute difference. A negative slack indicates
+	// a violated constraint.
+	Slack float64
+	// Violation is the amount by which the constraint is violated, 0 if the
+	// constraint is satisfied.
+	Violation float64
+}
+
+// Evaluation is the result of Evaluate.
+type Evaluation struct {
+	// Constraints holds an evaluation for each constraint of the model, in
+	// the order of Model.Constraints.
+	Constraints []ConstraintEvaluation
+	// ObjectiveValue is the value of the objective for the assignment.
+	ObjectiveValue float64
+	// TotalViolation is the sum of the violations of all constraints.
+	TotalViolation float64
+}
+
+// Violated returns the evaluations of the constraints which are violated by
+// more than tolerance.
+func (e Evaluation) Violated(tolerance float64) []ConstraintEvaluation {
+	violated := make([]ConstraintEvaluation, 0)
+	for _, c := range e.Constraints {
+		if c.Violation > tolerance {
+			violated = append(violated, c)
+		}
+	}
+	return violated
+}
+
+// Evaluate scores assignment against model without invoking a solver. Vars
+// which are not present in assignment are treated as zero. Bounds and
+// integrality of vars are not evaluated, use Verify to certify a solution.
+func Evaluate(model Model, assignment map[Var]float64) Evaluation {
+	value := func(v Var) float64 {
+		return assignment[v]
+	}
+
+	constraints := model.Constraints()
+	evaluation := Evaluation{
+		Constraints:    make([]ConstraintEvaluation, len(constraints)),
+		ObjectiveValue: objectiveValue(model.Objective(), value),
+	}
+
+	for i, c := range constraints {
+		activity := constraintActivity(c, value)
+		violation := constraintViolation(c, activity)
+		evaluation.Constraints[i] = ConstraintEvaluation{
+			Constraint: c,
+			Activity:   activity,
+			Slack:      constraintSlack(c, activity),
+			Violation:  violation,
+		}
+		evaluation.TotalViolation += violation
+	}
+
+	return evaluation
+}
+
+// constraintSlack returns the slack of c given the activity of c.
+func constraintSlack(c Constraint, activity float64) float64 {
+	switch c.Sense() {
+	case LessThanOrEqual:
+		return c.RightHandSide() - activity
+	case GreaterThanOrEqual:
+		return activity - c.RightHandSide()
+	default:
+		return -constraintViolation(c, activity)
+	}
+}
@@ -79,6 +79,51 @@ func ExampleConstraint_terms() {
 	// 3 B0 2
 }
 
+func ExampleConstraint_SetTerm() {
+	model := mip.NewModel()
+
+	x := model.NewFloat(0, 10)
+	y := model.NewFloat(0, 10)
+	c := model.NewConstraint(mip.LessThanOrEqual, 5.0)
+	c.NewTerm(1.0, x)
+	c.NewTerm(2.0, x)
+	c.NewTerm(1.0, y)
+	fmt.Println(c)
+
+	c.SetTerm(x, 0.5)
+	fmt.Println(c)
+
+	c.RemoveTerm(y)
+	fmt.Println(c)
+	// Output:
+	// 3 F0 + 1 F1 <= 5
+	// 0.5 F0 + 1 F1 <= 5
+	// 0.5 F0 <= 5
+}
+
+func ExampleNameConstraints() {
+	model := mip.NewModel()
+
+	constraints := make(mip.Constraints, 4)
+	for i := range constraints {
+		constraints[i] = model.NewConstraint(mip.LessThanOrEqual, 1.0)
+	}
+
+	mip.NameConstraints(constraints, "capacity[%d][%d]", func(i int) []any {
+		return []any{i / 2, i % 2}
+	})
+	constraints[3].SetNamef("overflow[%s]", "plant")
+
+	for _, c := range model.Constraints() {
+		fmt.Println(c.Name())
+	}
+	// Output:
+	// capacity[0][0]
+	// capacity[0][1]
+	// capacity[1][0]
+	// overflow[plant]
+}
+
 func benchmarkNewConstraintNewTerms(nrTerms int, b *testing.B) {
 	model := mip.NewModel()
 	v := model.NewFloat(1.0, 2.0)
@@ -118,3 +163,32 @@ func BenchmarkNewConstraintNewTerms16(b *testing.B) {
 func BenchmarkNewConstraintNewTerms32(b *testing.B) {
 	benchmarkNewConstraintNewTerms(32, b)
 }
+
+func ExampleForEach() {
+	model := mip.NewModel()
+
+	plants := []string{"north", "south", "west"}
+	capacity := map[string]float64{"north": 10, "south": 20}
+	produce := mip.NewVarMap(plants, func(plant string) mip.Float {
+		v := model.NewFloat(0, 100)
+		v.SetName("produce_" + plant)
+		return v
+	})
+
+	constraints := mip.ForEach(model, "capacity", plants, func(plant string) mip.ConstraintSpec {
+		c, ok := capacity[plant]
+		return mip.ConstraintSpec{
+			Sense:         mip.LessThanOrEqual,
+			RightHandSide: c,
+			Terms:         []mip.TermSpec{{Coefficient: 1, Var: produce.Get(plant)}},
+			Skip:          !ok,
+		}
+	})
+
+	for _, c := range constraints {
+		fmt.Println(c.Name(), c)
+	}
+	// Output:
+	// capacity[north] 1 produce_north <= 10
+	// capacity[south] 1 produce_south <= 20
+}
@@ -0,0 +1,37 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"os"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func ExampleWriteDOT() {
+	model := mip.NewModel()
+	north := mip.Vars{model.NewBool(), model.NewBool()}
+	for _, v := range north {
+		v.SetGroup("north")
+	}
+	hub := model.NewFloat(0, 10)
+	hub.SetName("hub")
+
+	c := model.NewConstraint(mip.LessThanOrEqual, 1)
+	c.SetName("north_capacity")
+	c.NewTerm(1, north[0])
+	c.NewTerm(1, north[1])
+	c.NewTerm(1, hub)
+
+	_ = mip.WriteDOT(os.Stdout, model, mip.DOTOptions{CollapseGroups: true})
+	// Output:
+	// graph model {
+	//   node [shape=ellipse];
+	//   v2 [label="hub"];
+	//   g0 [label="north", style=bold];
+	//   node [shape=box];
+	//   c0 [label="north_capacity"];
+	//   g0 -- c0 [label=2];
+	//   v2 -- c0;
+	// }
+}
@@ -0,0 +1,41 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func ExampleEvaluate() {
+	model := mip.NewModel()
+
+	x := model.NewFloat(0.0, 10.0)
+	y := model.NewInt(0, 5)
+
+	model.Objective().NewTerm(1.0, x)
+	model.Objective().NewTerm(2.0, y)
+
+	capacity := model.NewConstraint(mip.LessThanOrEqual, 8.0)
+	capacity.NewTerm(1.0, x)
+	capacity.NewTerm(1.0, y)
+	capacity.SetName("capacity")
+
+	demand := model.NewConstraint(mip.GreaterThanOrEqual, 2.0)
+	demand.NewTerm(1.0, y)
+	demand.SetName("demand")
+
+	evaluation := mip.Evaluate(model, map[mip.Var]float64{x: 5.0, y: 4.0})
+
+	fmt.Println(evaluation.ObjectiveValue)
+	for _, c := range evaluation.Constraints {
+		fmt.Println(c.Constraint.Name(), c.Activity, c.Slack, c.Violation)
+	}
+	fmt.Println(len(evaluation.Violated(0.0)))
+	// Output:
+	// 13
+	// capacity 9 -1 1
+	// demand 4 2 0
+	// 1
+}
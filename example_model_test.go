@@ -3,7 +3,9 @@
 package mip_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
 
 	mip "github.com/nextmv-io/go-mip"
 )
@@ -68,3 +70,71 @@ func ExampleModel_copy() {
 	//       1: F1 [1, 2]
 	//       2: B2 [0, 1]
 }
+
+func ExampleNewModelWithLimits() {
+	model := mip.NewModelWithLimits(mip.ModelLimits{Vars: 2})
+
+	model.NewBool()
+	model.NewBool()
+
+	defer func() {
+		var limitError *mip.ModelLimitError
+		if err, ok := recover().(error); ok && errors.As(err, &limitError) {
+			fmt.Println(err)
+		}
+	}()
+
+	model.NewBool()
+	// Output:
+	// model size limit exceeded: more than 2 vars
+}
+
+func ExampleModel_NewFreeFloat() {
+	model := mip.NewModel()
+
+	v := model.NewFreeFloat()
+
+	fmt.Println(v.LowerBound())
+	fmt.Println(v.UpperBound())
+	fmt.Println(v.UpperBound() == mip.Infinity())
+	// Output:
+	// -Inf
+	// +Inf
+	// true
+}
+
+func ExampleModel_NewIntChecked() {
+	model := mip.NewModel()
+
+	_, err := model.NewIntChecked(0, mip.MaxIntBound+1)
+	fmt.Println(errors.Is(err, mip.ErrIntBoundOutOfRange))
+
+	v, err := model.NewIntChecked(math.MinInt64, mip.MaxIntBound)
+	fmt.Println(err)
+	fmt.Println(v.LowerBound(), v.UpperBound())
+	// Output:
+	// true
+	// <nil>
+	// -Inf 9.007199254740992e+15
+}
+
+func ExampleModel_checked() {
+	model := mip.NewModelWithLimits(mip.ModelLimits{NonZeros: 1})
+
+	_, err := model.NewFloatChecked(math.NaN(), 1.0)
+	fmt.Println(err, errors.Is(err, mip.ErrNaN))
+
+	x, _ := model.NewFloatChecked(0.0, 1.0)
+	c, _ := model.NewConstraintChecked(mip.LessThanOrEqual, 1.0)
+	_, err = c.NewTermChecked(1.0, x)
+	fmt.Println(err)
+	_, err = c.NewTermChecked(1.0, x)
+	fmt.Println(err)
+	_, err = model.Objective().NewTermChecked(math.NaN(), x)
+	fmt.Println(err)
+	// Output:
+	// lower bound is NaN true
+	// <nil>
+	// model size limit exceeded: more than 1 non-zeros
+	// objective term coefficient is NaN
+}
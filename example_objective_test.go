@@ -97,6 +97,25 @@ func ExampleObjective_termsToString() {
 	// 0 B3 0
 }
 
+func ExampleObjective_Clear() {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewFloat(0, 10)
+	slack := model.NewFloat(0, 10)
+
+	// Feasibility phase: minimize the slack.
+	model.Objective().NewTerm(1.0, slack)
+	fmt.Println(model.Objective())
+
+	// Cost phase: minimize the cost of x and y.
+	model.Objective().Clear()
+	model.Objective().SetTerms([]float64{2.0, 3.0}, mip.Vars{x, y})
+	fmt.Println(model.Objective())
+	// Output:
+	// minimize   1 F2
+	// minimize   2 F0 + 3 F1
+}
+
 func benchmarkObjectiveNewTerms(nrTerms int, b *testing.B) {
 	model := mip.NewModel()
 	v := model.NewFloat(1.0, 2.0)
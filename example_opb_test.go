@@ -0,0 +1,34 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"os"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func ExampleWriteOPB() {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	x := mip.Vars{model.NewBool(), model.NewBool(), model.NewBool()}
+	for i, v := range x {
+		model.Objective().NewTerm(float64(i+1), v)
+	}
+	c := model.NewConstraint(mip.LessThanOrEqual, 2)
+	c.NewTerm(1, x[0])
+	c.NewTerm(2, x[1])
+	c.NewTerm(1, x[2])
+	x[2].Fix(1)
+
+	fmt.Println(mip.IsPseudoBoolean(model))
+	_ = mip.WriteOPB(os.Stdout, model)
+	// Output:
+	// true
+	// * #variable= 3 #constraint= 2
+	// * written by go-mip
+	// min: -1 x1 -2 x2 -3 x3 ;
+	// -1 x1 -2 x2 -1 x3 >= -2 ;
+	// +1 x3 = 1 ;
+}
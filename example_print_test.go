@@ -0,0 +1,82 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"os"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func ExampleModel_Fprint() {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	x.SetName("x")
+	y := model.NewInt(0, 5)
+	y.SetName("y")
+	y.SetGroup("trucks")
+	z := model.NewBool()
+
+	model.Objective().SetMaximize()
+	model.Objective().NewTerm(1.0/3, x)
+	model.Objective().NewTerm(-2, y)
+
+	c := model.NewConstraint(mip.LessThanOrEqual, 12)
+	c.SetName("capacity")
+	c.NewTerm(1, x)
+	c.NewTerm(2, y)
+	c.NewTerm(3, z)
+	model.NewConstraint(mip.GreaterThanOrEqual, 1).NewTerm(1, x)
+
+	_ = model.Fprint(os.Stdout, mip.PrintOptions{TermsPerLine: 2, Precision: 3})
+	_ = model.Fprint(os.Stdout, mip.PrintOptions{Group: "trucks"})
+	// Output:
+	// maximize
+	//   obj: + 0.333 x - 2 y
+	// subject to
+	//   capacity: + 1 x + 2 y
+	//             + 3 B2 <= 12
+	//   C1: + 1 x >= 1
+	// bounds
+	//   0 <= x <= 10 (float)
+	//   0 <= y <= 5 (int)
+	//   0 <= B2 <= 1 (bool)
+	// maximize
+	//   obj: - 2 y
+	// subject to
+	//   capacity: + 1 x + 2 y + 3 B2 <= 12
+	// bounds
+	//   0 <= y <= 5 (int)
+}
+
+func ExampleModel_Summary() {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewInt(0, 5)
+	model.Objective().NewTerm(1, x)
+
+	for i := 0; i < 12; i++ {
+		c := model.NewConstraint(mip.LessThanOrEqual, float64(i))
+		c.NewTerm(1, x)
+		c.NewTerm(2, y)
+	}
+
+	fmt.Print(model.Summary())
+	// Output:
+	// 2 vars (1 continuous, 1 integer, 0 binary), 12 constraints, 24 non-zeros
+	// minimize 1 terms, 0 quadratic terms
+	//       0: 1 F0 + 2 I1 <= 0
+	//       1: 1 F0 + 2 I1 <= 1
+	//       2: 1 F0 + 2 I1 <= 2
+	//       3: 1 F0 + 2 I1 <= 3
+	//       4: 1 F0 + 2 I1 <= 4
+	//       5: 1 F0 + 2 I1 <= 5
+	//       6: 1 F0 + 2 I1 <= 6
+	//       7: 1 F0 + 2 I1 <= 7
+	//       8: 1 F0 + 2 I1 <= 8
+	//       9: 1 F0 + 2 I1 <= 9
+	//     ...: 2 more constraints
+	//       0: F0 [0, 10]
+	//       1: I1 [0, 5]
+}
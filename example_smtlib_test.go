@@ -0,0 +1,40 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"os"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func ExampleWriteSMTLIB() {
+	model := mip.NewModel()
+	x := model.NewBool()
+	x.SetName("x")
+	y := model.NewInt(0, 5)
+	y.SetName("y")
+
+	c := model.NewConstraint(mip.GreaterThanOrEqual, 7)
+	c.SetName("demand")
+	c.NewTerm(1, x)
+	c.NewTerm(1, y)
+	half := model.NewConstraint(mip.LessThanOrEqual, 2.5)
+	half.NewTerm(0.5, y)
+
+	_ = mip.WriteSMTLIB(os.Stdout, model)
+	// Output:
+	// ; written by go-mip
+	// (set-option :produce-unsat-cores true)
+	// (set-logic QF_LIRA)
+	// (declare-fun |x| () Int)
+	// (declare-fun |y| () Int)
+	// (assert (! (>= |x| 0) :named |lower x|))
+	// (assert (! (<= |x| 1) :named |upper x|))
+	// (assert (! (>= |y| 0) :named |lower y|))
+	// (assert (! (<= |y| 5) :named |upper y|))
+	// (assert (! (>= (+ (* 1 |x|) (* 1 |y|)) 7) :named |demand|))
+	// (assert (! (<= (* 0.5 (to_real |y|)) 2.5) :named |c1|))
+	// (check-sat)
+	// (exit)
+}
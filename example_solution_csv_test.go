@@ -0,0 +1,37 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"os"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func ExampleWriteSolutionCSV() {
+	model := mip.NewModel()
+
+	x := model.NewFloat(0.0, 10.0)
+	x.SetName("x")
+	y := model.NewInt(0, 5)
+	z := model.NewBool()
+
+	solution := newTestSolution(
+		3.5,
+		map[mip.Var]float64{x: 2.5, y: 1.0, z: 0.0},
+	)
+
+	err := mip.WriteSolutionCSV(
+		os.Stdout,
+		model,
+		solution,
+		mip.SolutionCSVOptions{SkipZeros: true},
+	)
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// name,index,type,value,reduced_cost
+	// x,0,float,2.5,
+	// I1,1,int,1,
+}
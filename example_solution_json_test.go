@@ -0,0 +1,35 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func ExampleMarshalSolution() {
+	model := mip.NewModel()
+
+	x := model.NewFloat(0.0, 10.0)
+	x.SetName("x")
+	y := model.NewBool()
+	z := model.NewBool()
+
+	solution := newTestSolution(
+		3.5,
+		map[mip.Var]float64{x: 2.5, y: 1.0, z: 0.0},
+	)
+
+	b, err := mip.MarshalSolution(
+		model,
+		solution,
+		mip.SolutionJSONOptions{SkipZeros: true},
+	)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(b))
+	// Output:
+	// {"objective_value":3.5,"provider":"test","run_time":1,"statistics":{"constraints":0,"variables":3},"status":"optimal","values":{"B1":1,"x":2.5}}
+}
@@ -0,0 +1,170 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// relaxationSolution is a testSolution with root LP relaxation values.
+type relaxationSolution struct {
+	*testSolution
+	relaxation map[int]float64
+}
+
+func (s *relaxationSolution) HasRelaxationValues() bool {
+	return s.relaxation != nil
+}
+
+func (s *relaxationSolution) RelaxationValue(variable mip.Var) float64 {
+	return s.relaxation[variable.Index()]
+}
+
+func ExampleRelaxationValues() {
+	model := mip.NewModel()
+
+	x := model.NewBool()
+	y := model.NewBool()
+
+	solution := newTestSolution(1.0, map[mip.Var]float64{x: 1.0, y: 0.0})
+
+	_, ok := mip.RelaxationValues(model, solution)
+	fmt.Println(ok)
+
+	values, ok := mip.RelaxationValues(model, &relaxationSolution{
+		testSolution: solution,
+		relaxation:   map[int]float64{0: 0.5, 1: 0.5},
+	})
+	fmt.Println(ok)
+	fmt.Println(values)
+	// Output:
+	// false
+	// true
+	// [0.5 0.5]
+}
+
+func ExampleGroupSums() {
+	model := mip.NewModel()
+
+	produced := make(mip.Vars, 4)
+	for i := range produced {
+		produced[i] = model.NewFloat(0.0, 10.0)
+		produced[i].SetGroup(fmt.Sprintf("plant-%d", i%2))
+		model.Objective().NewTerm(2.0, produced[i])
+	}
+	open := model.NewBool()
+
+	solution := newTestSolution(22.0, map[mip.Var]float64{
+		produced[0]: 1.0,
+		produced[1]: 2.0,
+		produced[2]: 3.0,
+		produced[3]: 5.0,
+		open:        1.0,
+	})
+
+	sums := mip.GroupSums(model, solution)
+	fmt.Println(len(sums))
+	fmt.Printf("%+v\n", sums["plant-0"])
+	fmt.Printf("%+v\n", sums["plant-1"])
+	// Output:
+	// 2
+	// {ObjectiveContribution:8 Value:4 Vars:2}
+	// {ObjectiveContribution:14 Value:7 Vars:2}
+}
+
+func ExampleValueByName() {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	x.SetName("x")
+
+	solution := newTestSolution(0, map[mip.Var]float64{x: 4})
+
+	fmt.Println(mip.ValueByName(model, solution, "x"))
+	fmt.Println(mip.ValueByName(model, solution, "y"))
+	// Output:
+	// 4 true
+	// 0 false
+}
+
+func ExampleNonZeroValues() {
+	model := mip.NewModel()
+	assign := make(mip.Vars, 1000)
+	for i := range assign {
+		assign[i] = model.NewBool()
+	}
+
+	solution := newTestSolution(0, map[mip.Var]float64{
+		assign[17]:  1,
+		assign[512]: 1,
+		assign[600]: 1e-9,
+	})
+
+	for _, value := range mip.NonZeroValues(model, solution) {
+		fmt.Println(value.Var, value.Value)
+	}
+	// Output:
+	// B17 1
+	// B512 1
+}
+
+func ExampleDiffSolutions() {
+	model := mip.NewModel()
+	trucks := model.NewInt(0, 10)
+	trucks.SetGroup("trucks")
+	routes := make(mip.Vars, 3)
+	for i := range routes {
+		routes[i] = model.NewBool()
+		routes[i].SetGroup("routes")
+	}
+	model.NewFloat(0, 1)
+
+	previous := newTestSolution(10, map[mip.Var]float64{
+		trucks:    3,
+		routes[0]: 1,
+		routes[1]: 0,
+	})
+	current := newTestSolution(12.5, map[mip.Var]float64{
+		trucks:    3,
+		routes[0]: 0,
+		routes[1]: 1,
+	})
+
+	diff := mip.DiffSolutions(model, previous, current, 1e-6)
+	fmt.Println(diff.ObjectiveDelta, diff.Len())
+	for _, change := range diff.Changes["routes"] {
+		fmt.Println(change.Var, change.From, "->", change.To)
+	}
+	// Output:
+	// 2.5 2
+	// B1 1 -> 0
+	// B2 0 -> 1
+}
+
+func ExampleObjectiveBreakdown() {
+	model := mip.NewModel()
+
+	trucks := model.NewInt(0, 10)
+	trucks.SetName("trucks")
+	trucks.SetGroup("fleet")
+	overtime := model.NewFloat(0, 100)
+	overtime.SetName("overtime")
+	idle := model.NewBool()
+	idle.SetName("idle")
+	model.Objective().NewTerm(500, trucks)
+	model.Objective().NewTerm(40, overtime)
+	model.Objective().NewTerm(10, idle)
+
+	solution := newTestSolution(2300, map[mip.Var]float64{
+		trucks:   3,
+		overtime: 20,
+		idle:     0,
+	})
+	for _, c := range mip.ObjectiveBreakdown(model, solution) {
+		fmt.Printf("%+v\n", c)
+	}
+	// Output:
+	// {Name:trucks Group:fleet Coefficient:500 Value:3 Contribution:1500}
+	// {Name:overtime Group: Coefficient:40 Value:20 Contribution:800}
+}
@@ -0,0 +1,42 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"log/slog"
+	"os"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func ExampleSolveLogger() {
+	model := mip.NewModel()
+	x := model.NewBool()
+	model.Objective().NewTerm(1.0, x)
+
+	handler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			// Remove attributes which change between runs.
+			if a.Key == slog.TimeKey || a.Key == "elapsed" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	options := mip.SolveOptions{}
+	options.SetLogger(slog.New(handler))
+
+	logger := mip.NewSolveLogger(options, "test", model)
+	logger.ModelHandedToBackend()
+	logger.PresolveDone(1, 0)
+	logger.Incumbent(1.0)
+	logger.Incumbent(0.0)
+	logger.Finished(newTestSolution(0.0, map[mip.Var]float64{x: 0.0}), nil)
+	// Output:
+	// level=INFO msg="model handed to backend" provider=test vars=1 constraints=0
+	// level=DEBUG msg="presolve done" provider=test vars=1 constraints=0 presolved_vars=1 presolved_constraints=0
+	// level=DEBUG msg="first incumbent" provider=test vars=1 constraints=0 objective_value=1 incumbents=1
+	// level=DEBUG msg="improved incumbent" provider=test vars=1 constraints=0 objective_value=0 incumbents=2
+	// level=INFO msg=finished provider=test vars=1 constraints=0 status=optimal run_time=1s objective_value=0
+}
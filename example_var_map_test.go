@@ -0,0 +1,62 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func ExampleNewVarMap() {
+	model := mip.NewModel()
+
+	plants := []string{"north", "south"}
+	open := mip.NewVarMap(plants, func(plant string) mip.Bool {
+		v := model.NewBool()
+		v.SetName("open_" + plant)
+		return v
+	})
+
+	fmt.Println(open.Get("south"))
+	fmt.Println(open.Len(), open.Keys())
+	solution := newTestSolution(1.0, map[mip.Var]float64{
+		open.Get("north"): 1.0,
+		open.Get("south"): 0.0,
+	})
+	fmt.Println(open.Values(solution))
+	// Output:
+	// open_south
+	// 2 [north south]
+	// map[north:1 south:0]
+}
+
+func ExampleNewVarMatrix() {
+	model := mip.NewModel()
+
+	drivers := []string{"ann", "bob"}
+	shifts := []int{1, 2, 3}
+	x := mip.NewVarMatrix(drivers, shifts, func(driver string, shift int) mip.Bool {
+		v := model.NewBool()
+		v.SetName(fmt.Sprintf("x_%s_%d", driver, shift))
+		return v
+	})
+
+	// Each driver works at most one shift.
+	for _, driver := range x.Rows() {
+		c := model.NewConstraint(mip.LessThanOrEqual, 1.0)
+		for _, v := range x.Row(driver) {
+			c.NewTerm(1.0, v)
+		}
+	}
+
+	fmt.Println(x.Get("bob", 2))
+	fmt.Println(x.Col(3))
+	fmt.Println(len(x.Vars()))
+	fmt.Println(model.Constraints()[0])
+	// Output:
+	// x_bob_2
+	// [x_ann_3 x_bob_3]
+	// 6
+	// 1 x_ann_1 + 1 x_ann_2 + 1 x_ann_3 <= 1
+}
@@ -110,3 +110,59 @@ func BenchmarkNewInt(b *testing.B) {
 		model.NewInt(-1, 1)
 	}
 }
+
+func ExampleVar_group() {
+	model := mip.NewModel()
+
+	v := model.NewFloat(0.0, 1.0)
+	fmt.Printf("%q\n", v.Group())
+	v.SetGroup("plant-a")
+	fmt.Println(v.Group())
+	fmt.Println(model.Copy().Vars()[0].Group())
+	v.SetGroup("")
+	fmt.Printf("%q\n", v.Group())
+	// Output:
+	// ""
+	// plant-a
+	// plant-a
+	// ""
+}
+
+func ExampleVar_fix() {
+	model := mip.NewModel()
+
+	v := model.NewInt(0, 10)
+	v.Fix(3)
+	value, fixed := v.FixedValue()
+	fmt.Println(value, fixed)
+	fmt.Println(v.LowerBound(), v.UpperBound())
+	v.Unfix()
+	_, fixed = v.FixedValue()
+	fmt.Println(fixed)
+	// Output:
+	// 3 true
+	// 0 10
+	// false
+}
+
+func ExampleVar_attr() {
+	model := mip.NewModel()
+
+	v := model.NewBool()
+	v.SetAttr("customer", 42)
+	c := model.NewConstraint(mip.LessThanOrEqual, 1.0)
+	c.SetAttr("shift", "early")
+
+	customer, ok := v.Attr("customer")
+	fmt.Println(customer, ok)
+	copied := model.Copy()
+	fmt.Println(copied.Vars()[0].Attr("customer"))
+	fmt.Println(copied.Constraints()[0].Attr("shift"))
+	v.SetAttr("customer", nil)
+	fmt.Println(v.Attr("customer"))
+	// Output:
+	// 42 true
+	// 42 true
+	// early true
+	// <nil> false
+}
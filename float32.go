@@ -0,0 +1,83 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// NewModelWithFloat32Coefficients creates a new model which stores the
+// coefficients of constraint terms as float32 together with the index of
+// their var, instead of a term value per coefficient. A term takes 8 bytes
+// instead of about 40, which matters for models with hundreds of millions of
+// non-zeros on memory-constrained deployments.
+//
+// The precision of float32 is about 7 significant digits: integers up to
+// 2^24 are exact, other coefficients such as 0.1 are rounded, and so are
+// large big-Ms and coefficients of very different magnitudes in a
+// constraint. The model is solved with the rounded coefficients, which can
+// make a solution slightly violate the constraints with the original
+// coefficients, see Evaluate. Coefficients beyond the range of float32 are
+// refused with ErrCoefficientOutOfRange. Bounds, right-hand sides and the
+// objective keep float64 precision. Copies of the model store their
+// coefficients in the same way, forks copy the compact terms instead of
+// sharing them. Vars with an index beyond math.MaxInt32 can not be used in
+// constraints.
+//
+//	model := mip.NewModelWithFloat32Coefficients(mip.ModelLimits{})
+func NewModelWithFloat32Coefficients(limits ModelLimits) Model {
+	m := NewModelWithLimits(limits).(*model)
+	m.float32 = true
+	return m
+}
+
+// ErrCoefficientOutOfRange is returned for finite coefficients of a model
+// created by NewModelWithFloat32Coefficients which exceed the range of
+// float32.
+var ErrCoefficientOutOfRange = errors.New("coefficient out of float32 range")
+
+// compactTerm is a constraint term of a model created by
+// NewModelWithFloat32Coefficients.
+type compactTerm struct {
+	index       int32
+	coefficient float32
+}
+
+// checkFloat32 returns an error wrapping ErrCoefficientOutOfRange if
+// coefficient is finite but exceeds the range of float32.
+func checkFloat32(coefficient float64) error {
+	if !math.IsInf(coefficient, 0) && math.Abs(coefficient) > math.MaxFloat32 {
+		return fmt.Errorf(
+			"%w: %v exceeds %v in magnitude",
+			ErrCoefficientOutOfRange,
+			coefficient,
+			math.MaxFloat32,
+		)
+	}
+	return nil
+}
+
+// compactTerms returns the compact terms of the invoking constraint as terms
+// with the vars of its model.
+func (c *constraint) compactTerms() Terms {
+	terms := make([]term, len(c.compact))
+	result := make(Terms, len(c.compact))
+	for i, t := range c.compact {
+		terms[i] = term{
+			coefficient: float64(t.coefficient),
+			variable:    c.model.vars[t.index],
+		}
+		result[i] = &terms[i]
+	}
+	return result
+}
+
+// termCount returns the number of terms added to the invoking constraint.
+func (c *constraint) termCount() int {
+	if c.model.float32 {
+		return len(c.compact)
+	}
+	return len(c.terms)
+}
@@ -0,0 +1,75 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestNewModelWithFloat32Coefficients(t *testing.T) {
+	model := mip.NewModelWithFloat32Coefficients(mip.ModelLimits{})
+	x := model.NewFloat(0, 10)
+	y := model.NewInt(0, 5)
+	b := model.NewBool()
+
+	c := model.NewConstraint(mip.LessThanOrEqual, 0.1)
+	term := c.NewTerm(0.1, x)
+	if term.Coefficient() != float64(float32(0.1)) {
+		t.Errorf("got coefficient %v, want %v", term.Coefficient(), float64(float32(0.1)))
+	}
+	c.NewTerm(2, y)
+	c.NewTerm(3, y)
+	if got, definitions := c.Term(y); got.Coefficient() != 5 || definitions != 2 {
+		t.Errorf("got term %v with %d definitions, want 5 with 2", got, definitions)
+	}
+	c.SetTerm(y, 4)
+	c.NewTerm(1, b)
+	c.RemoveTerm(b)
+	if got := fmt.Sprint(c); got != fmt.Sprintf("%v F0 + 4 I1 <= 0.1", float64(float32(0.1))) {
+		t.Errorf("got constraint %v", got)
+	}
+
+	if _, err := c.NewTermChecked(1e300, b); !errors.Is(err, mip.ErrCoefficientOutOfRange) {
+		t.Errorf("got error %v, want ErrCoefficientOutOfRange", err)
+	}
+	if _, err := c.NewTermChecked(math.Inf(1), b); err != nil {
+		t.Errorf("got error %v for infinite coefficient, want none", err)
+	}
+	c.RemoveTerm(b)
+
+	atMost := mip.AtMostK(model, mip.Vars{x, y, b}, 2)
+	for _, copied := range []mip.Model{model.Copy(), model.Fork()} {
+		constraints := copied.Constraints()
+		if fmt.Sprint(constraints) != fmt.Sprint(model.Constraints()) {
+			t.Errorf("got copied constraints %v, want %v", constraints, model.Constraints())
+		}
+		constraints[1].SetTerm(copied.Vars()[0], 7)
+		if fmt.Sprint(atMost) != "1 F0 + 1 I1 + 1 B2 <= 2" {
+			t.Errorf("got constraint %v changed by its copy", atMost)
+		}
+	}
+
+	compact := mip.NewModelWithFloat32Coefficients(mip.ModelLimits{})
+	regular := mip.NewModel()
+	for _, m := range []mip.Model{compact, regular} {
+		vars := make(mip.Vars, 100)
+		for i := range vars {
+			vars[i] = m.NewFloat(0, 1)
+		}
+		for i := 0; i < 100; i++ {
+			c := m.NewConstraint(mip.LessThanOrEqual, 1)
+			for _, v := range vars {
+				c.NewTerm(0.5, v)
+			}
+		}
+	}
+	if compact.MemoryFootprint() > regular.MemoryFootprint()/2 {
+		t.Errorf("got footprint %v, want at most half of %v",
+			compact.MemoryFootprint(), regular.MemoryFootprint())
+	}
+}
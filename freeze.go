@@ -0,0 +1,149 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrFrozen is returned by the checked constructors and the methods returning
+// an error when a frozen model is changed, see Model.Freeze. Methods
+// without an error panic with it.
+var ErrFrozen = errors.New("model is frozen")
+
+// ErrInvalidModel is returned by Model.Freeze for a model which fails
+// validation.
+var ErrInvalidModel = errors.New("invalid model")
+
+// maxValidationProblems is the number of problems reported by an error
+// wrapping ErrInvalidModel or ErrInvalidOptions.
+const maxValidationProblems = 10
+
+func (m *model) Freeze() error {
+	if m.frozen {
+		return nil
+	}
+	if err := m.validate(); err != nil {
+		return err
+	}
+	m.frozen = true
+	m.objective.(*objective).frozen = true
+	return nil
+}
+
+func (m *model) Frozen() bool {
+	return m.frozen
+}
+
+// checkMutable returns an error wrapping ErrFrozen if the invoking model is
+// frozen.
+func (m *model) checkMutable() error {
+	if m.frozen {
+		return ErrFrozen
+	}
+	return nil
+}
+
+// checkModelMutable returns an error wrapping ErrFrozen if m is frozen.
+func checkModelMutable(m Model) error {
+	if impl, ok := m.(*model); ok {
+		return impl.checkMutable()
+	}
+	return nil
+}
+
+// mustBeMutable panics with ErrFrozen if the invoking model is frozen.
+func (m *model) mustBeMutable() {
+	if m.frozen {
+		panic(ErrFrozen)
+	}
+}
+
+// mustBeMutableVar panics with ErrFrozen if m is frozen and with an error
+// wrapping ErrStreamedVar if v has been passed to the sink of a ModelStream.
+func (m *model) mustBeMutableVar(v Var) {
+	m.mustBeMutable()
+	if v.Index() < m.streamed {
+		panic(fmt.Errorf("%w: %v", ErrStreamedVar, v))
+	}
+}
+
+// validate returns an error wrapping ErrInvalidModel listing the first
+// problems of the invoking model: vars with crossed bounds, terms with vars
+// of another model or infinite coefficients and equality constraints with
+// an infinite right-hand side.
+func (m *model) validate() error {
+	var problems []string
+	report := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	for _, v := range m.vars {
+		if v.LowerBound() > v.UpperBound() {
+			report("var %v has lower bound %v above upper bound %v", v, v.LowerBound(), v.UpperBound())
+		}
+	}
+	for i, c := range m.constraints {
+		impl := c.(*constraint)
+		label := constraintLabel(c, i)
+		if c.Sense() == Equal && math.IsInf(c.RightHandSide(), 0) {
+			report("equality constraint %s has right-hand side %v", label, c.RightHandSide())
+		}
+		eachTerm(c, func(index int, coefficient float64) {
+			if math.IsInf(coefficient, 0) {
+				report("constraint %s has coefficient %v", label, coefficient)
+			}
+			if index >= len(m.vars) {
+				report("constraint %s has var %d of another model", label, index)
+			}
+		})
+		if impl.shared {
+			// Shared terms use the vars of the model they are shared with,
+			// which correspond by index.
+			continue
+		}
+		for _, t := range impl.terms {
+			if t.Var().Index() < len(m.vars) && !m.owns(t.Var()) {
+				report("constraint %s has var %v of another model", label, t.Var())
+			}
+		}
+	}
+	o := m.objective.(*objective)
+	for _, t := range o.terms {
+		if !m.owns(t.Var()) {
+			report("objective has var %v of another model", t.Var())
+		}
+		if math.IsInf(t.Coefficient(), 0) {
+			report("objective has coefficient %v", t.Coefficient())
+		}
+	}
+	for _, t := range o.quadraticTerms {
+		if !m.owns(t.Var1()) || !m.owns(t.Var2()) {
+			report("objective has quadratic term of %v and %v of another model", t.Var1(), t.Var2())
+		}
+	}
+	return validationError(ErrInvalidModel, problems)
+}
+
+// validationError returns an error wrapping sentinel which lists the first
+// problems, nil if there are none.
+func validationError(sentinel error, problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	if len(problems) > maxValidationProblems {
+		problems = append(
+			problems[:maxValidationProblems],
+			fmt.Sprintf("and %d more", len(problems)-maxValidationProblems),
+		)
+	}
+	return fmt.Errorf("%w: %s", sentinel, strings.Join(problems, "; "))
+}
+
+// owns returns true if v is a var of the invoking model.
+func (m *model) owns(v Var) bool {
+	index := v.Index()
+	return index >= 0 && index < len(m.vars) && m.vars[index] == v
+}
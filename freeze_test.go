@@ -0,0 +1,122 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestFreeze(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	b := model.NewBool()
+	c := model.NewConstraint(mip.LessThanOrEqual, 5)
+	c.NewTerm(1, x)
+	c.NewTerm(2, b)
+	model.Objective().NewTerm(1, x)
+
+	if err := model.Freeze(); err != nil {
+		t.Fatal(err)
+	}
+	if !model.Frozen() || model.Freeze() != nil {
+		t.Fatal("got model not frozen")
+	}
+
+	if _, err := model.NewFloatChecked(0, 1); !errors.Is(err, mip.ErrFrozen) {
+		t.Errorf("got error %v adding a var, want ErrFrozen", err)
+	}
+	if _, err := model.NewConstraintChecked(mip.Equal, 1); !errors.Is(err, mip.ErrFrozen) {
+		t.Errorf("got error %v adding a constraint, want ErrFrozen", err)
+	}
+	if _, err := c.NewTermChecked(1, b); !errors.Is(err, mip.ErrFrozen) {
+		t.Errorf("got error %v adding a term, want ErrFrozen", err)
+	}
+	if _, err := model.Objective().NewTermChecked(1, b); !errors.Is(err, mip.ErrFrozen) {
+		t.Errorf("got error %v adding an objective term, want ErrFrozen", err)
+	}
+	changes := mip.ModelChanges{RightHandSides: []mip.RightHandSideChange{{Constraint: c, RightHandSide: 1}}}
+	if _, err := changes.Apply(model); !errors.Is(err, mip.ErrFrozen) {
+		t.Errorf("got error %v applying changes, want ErrFrozen", err)
+	}
+
+	mutations := map[string]func(){
+		"Fix":          func() { x.Fix(1) },
+		"SetName":      func() { x.SetName("x") },
+		"SetAttr":      func() { c.SetAttr("key", 1) },
+		"SetTerm":      func() { c.SetTerm(x, 3) },
+		"MakeSoft":     func() { c.MakeSoft(0, 1) },
+		"SetVarType":   func() { model.SetVarType(b, mip.Continuous) },
+		"SetMaximize":  func() { model.Objective().SetMaximize() },
+		"NewParam":     func() { model.NewParam("p", 1) },
+		"SetVarNames":  func() { model.SetVarNames([]string{"x"}) },
+		"ConstraintID": func() { model.NewConstraintID(mip.Equal, 0) },
+	}
+	for name, mutation := range mutations {
+		func() {
+			defer func() {
+				if err, ok := recover().(error); !ok || !errors.Is(err, mip.ErrFrozen) {
+					t.Errorf("%s: got panic %v, want ErrFrozen", name, err)
+				}
+			}()
+			mutation()
+		}()
+	}
+	if c.RightHandSide() != 5 || len(c.Terms()) != 2 || x.Name() != "" {
+		t.Errorf("got changed frozen model %v", model)
+	}
+
+	fork := model.Fork()
+	if fork.Frozen() {
+		t.Error("got frozen fork")
+	}
+	fork.Constraints()[0].SetTerm(fork.Vars()[0], 3)
+	if term, _ := c.Term(x); term.Coefficient() != 1 {
+		t.Errorf("got coefficient %v changed by fork, want 1", term.Coefficient())
+	}
+
+	// Frozen models are read and forked concurrently.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fork := model.Fork()
+			fork.Constraints()[0].NewTerm(1, fork.Vars()[1])
+			_ = model.Constraints()[0].Terms()
+			_ = model.Copy()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestFreezeInvalid(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(3, 1)
+	other := mip.NewModel().NewFloat(0, 1)
+	c := model.NewConstraint(mip.Equal, math.Inf(1))
+	c.NewTerm(math.Inf(-1), x)
+	c.NewTerm(1, other)
+
+	err := model.Freeze()
+	if !errors.Is(err, mip.ErrInvalidModel) || model.Frozen() {
+		t.Fatalf("got error %v, want ErrInvalidModel and an unfrozen model", err)
+	}
+	for _, want := range []string{
+		"var F0 has lower bound 3 above upper bound 1",
+		"equality constraint C0 has right-hand side +Inf",
+		"constraint C0 has coefficient -Inf",
+		"constraint C0 has var F0 of another model",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("got error %q, want it to contain %q", err, want)
+		}
+	}
+	// The model can still be changed.
+	model.NewFloat(0, 1)
+}
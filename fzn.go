@@ -0,0 +1,382 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ReadFlatZinc reads a linear model in the FlatZinc format from r, as
+// compiled by MiniZinc, e.g. with the linear library of the MIP back-ends
+// of MiniZinc. Vars of bool, int and float domains and ranges, parameters,
+// arrays, the linear constraints int_lin_le, int_lin_eq, bool_lin_le,
+// bool_lin_eq, float_lin_le and float_lin_eq, the relations int_le, int_eq,
+// bool_le, bool_eq, float_le and float_eq and bool2int are supported.
+// Vars are named after their identifiers, a var declared with a value is
+// fixed to it or is the var it is assigned. Annotations are ignored. An
+// error is returned for other constraints, e.g. reified ones, set domains
+// and predicates, and for NaN values.
+func ReadFlatZinc(r io.Reader) (Model, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	reader := &fznReader{
+		model:  NewModel(),
+		vars:   map[string]Var{},
+		params: map[string]float64{},
+		arrays: map[string][]fznValue{},
+	}
+	for i, item := range fznItems(string(data)) {
+		if err := reader.item(item); err != nil {
+			return nil, fmt.Errorf("fzn: item %d: %w", i+1, err)
+		}
+	}
+	return reader.model, nil
+}
+
+// fznValue is a var or a constant.
+type fznValue struct {
+	variable Var
+	constant float64
+}
+
+type fznReader struct {
+	model  Model
+	vars   map[string]Var
+	params map[string]float64
+	arrays map[string][]fznValue
+}
+
+var fznSolve = regexp.MustCompile(`\b(satisfy|minimize|maximize)\s*(\S*)\s*$`)
+
+// fznItems returns the items of data without comments.
+func fznItems(data string) []string {
+	lines := strings.Split(data, "\n")
+	for i, line := range lines {
+		if j := strings.IndexByte(line, '%'); j >= 0 {
+			lines[i] = line[:j]
+		}
+	}
+	var items []string
+	for _, item := range fznSplit(strings.Join(lines, "\n"), ';') {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// fznSplit splits s at sep outside of brackets, parentheses, braces and
+// strings.
+func fznSplit(s string, sep byte) []string {
+	var parts []string
+	depth, start, quoted := 0, 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// fznStripAnnotations removes the annotations of a declaration, which start
+// with :: and end at the assignment or the end of the declaration.
+func fznStripAnnotations(s string) string {
+	i := strings.Index(s, "::")
+	if i < 0 {
+		return s
+	}
+	rest := fznSplit(s[i:], '=')
+	if len(rest) == 1 {
+		return s[:i]
+	}
+	return s[:i] + "=" + strings.Join(rest[1:], "=")
+}
+
+func (r *fznReader) item(item string) error {
+	keyword := strings.Fields(item)[0]
+	switch {
+	case keyword == "predicate":
+		return fmt.Errorf("predicates are not supported")
+	case keyword == "solve":
+		return r.solve(item)
+	case keyword == "constraint":
+		return r.constraint(strings.TrimSpace(strings.TrimPrefix(item, "constraint")))
+	}
+
+	head, rest, ok := strings.Cut(fznStripAnnotations(item), ":")
+	if !ok {
+		return fmt.Errorf("invalid item %q", item)
+	}
+	name, value, assigned := strings.Cut(rest, "=")
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	switch {
+	case strings.HasPrefix(head, "array"):
+		if !assigned {
+			return fmt.Errorf("array %s has no value", name)
+		}
+		values, err := r.array(value)
+		r.arrays[name] = values
+		return err
+	case strings.HasPrefix(head, "var"):
+		return r.declareVar(strings.TrimSpace(strings.TrimPrefix(head, "var")), name, value)
+	}
+	if strings.HasPrefix(head, "set") {
+		return nil
+	}
+	parameter, err := r.value(value)
+	if err != nil {
+		return err
+	}
+	if parameter.variable != nil {
+		return fmt.Errorf("parameter %s is assigned var", name)
+	}
+	r.params[name] = parameter.constant
+	return nil
+}
+
+func (r *fznReader) declareVar(domain, name, value string) error {
+	var assigned fznValue
+	if value != "" {
+		var err error
+		if assigned, err = r.value(value); err != nil {
+			return err
+		}
+		if assigned.variable != nil {
+			r.vars[name] = assigned.variable
+			return nil
+		}
+	}
+	v, err := r.newVar(domain)
+	if err != nil {
+		return fmt.Errorf("var %s: %w", name, err)
+	}
+	v.SetName(name)
+	if value != "" {
+		v.Fix(assigned.constant)
+	}
+	r.vars[name] = v
+	return nil
+}
+
+// newVar adds a var of domain to the model. Ranges of floats create float
+// vars, ranges of ints create int vars.
+func (r *fznReader) newVar(domain string) (Var, error) {
+	switch domain {
+	case "bool":
+		return r.model.NewBoolChecked()
+	case "int":
+		return r.model.NewIntChecked(intBound(math.Inf(-1)), intBound(math.Inf(1)))
+	case "float":
+		return r.model.NewFloatChecked(math.Inf(-1), math.Inf(1))
+	}
+	lowerText, upperText, ok := strings.Cut(domain, "..")
+	if !ok {
+		return nil, fmt.Errorf("domain %q is not supported", domain)
+	}
+	lowerText, upperText = strings.TrimSpace(lowerText), strings.TrimSpace(upperText)
+	lower, err := strconv.ParseFloat(lowerText, 64)
+	if err != nil || math.IsNaN(lower) {
+		return nil, fmt.Errorf("invalid lower bound %q", lowerText)
+	}
+	upper, err := strconv.ParseFloat(upperText, 64)
+	if err != nil || math.IsNaN(upper) {
+		return nil, fmt.Errorf("invalid upper bound %q", upperText)
+	}
+	if strings.ContainsAny(lowerText+upperText, ".eE") {
+		return r.model.NewFloatChecked(lower, upper)
+	}
+	return r.model.NewIntChecked(intBound(lower), intBound(upper))
+}
+
+// value returns the value of expression s, a literal, identifier or array
+// access.
+func (r *fznReader) value(s string) (fznValue, error) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "true":
+		return fznValue{constant: 1}, nil
+	case "false":
+		return fznValue{constant: 0}, nil
+	}
+	if constant, err := strconv.ParseFloat(s, 64); err == nil {
+		if math.IsNaN(constant) {
+			return fznValue{}, fmt.Errorf("invalid number %q", s)
+		}
+		return fznValue{constant: constant}, nil
+	}
+	if name, index, ok := strings.Cut(strings.TrimSuffix(s, "]"), "["); ok {
+		array, found := r.arrays[strings.TrimSpace(name)]
+		i, err := strconv.Atoi(strings.TrimSpace(index))
+		if !found || err != nil || i < 1 || i > len(array) {
+			return fznValue{}, fmt.Errorf("invalid array access %q", s)
+		}
+		return array[i-1], nil
+	}
+	if v, ok := r.vars[s]; ok {
+		return fznValue{variable: v}, nil
+	}
+	if constant, ok := r.params[s]; ok {
+		return fznValue{constant: constant}, nil
+	}
+	return fznValue{}, fmt.Errorf("unknown identifier %q", s)
+}
+
+// array returns the values of expression s, an array literal or identifier.
+func (r *fznReader) array(s string) ([]fznValue, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") {
+		array, ok := r.arrays[s]
+		if !ok {
+			return nil, fmt.Errorf("unknown array %q", s)
+		}
+		return array, nil
+	}
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(s, "["), "]"))
+	if s == "" {
+		return nil, nil
+	}
+	elements := fznSplit(s, ',')
+	values := make([]fznValue, len(elements))
+	for i, element := range elements {
+		value, err := r.value(element)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// fznSenses maps the supported constraints to their sense and whether they
+// are linear constraints, as opposed to relations of two values.
+var fznSenses = map[string]struct {
+	sense  Sense
+	linear bool
+}{
+	"int_lin_le":   {LessThanOrEqual, true},
+	"int_lin_eq":   {Equal, true},
+	"bool_lin_le":  {LessThanOrEqual, true},
+	"bool_lin_eq":  {Equal, true},
+	"float_lin_le": {LessThanOrEqual, true},
+	"float_lin_eq": {Equal, true},
+	"int_le":       {LessThanOrEqual, false},
+	"int_eq":       {Equal, false},
+	"bool_le":      {LessThanOrEqual, false},
+	"bool_eq":      {Equal, false},
+	"float_le":     {LessThanOrEqual, false},
+	"float_eq":     {Equal, false},
+	"bool2int":     {Equal, false},
+}
+
+func (r *fznReader) constraint(s string) error {
+	s = fznStripAnnotations(s)
+	name, args, ok := strings.Cut(s, "(")
+	end := strings.LastIndexByte(args, ')')
+	if !ok || end < 0 {
+		return fmt.Errorf("invalid constraint %q", s)
+	}
+	name = strings.TrimSpace(name)
+	kind, ok := fznSenses[name]
+	if !ok {
+		return fmt.Errorf("constraint %s is not supported", name)
+	}
+	arguments := fznSplit(args[:end], ',')
+
+	var coefficients, values []fznValue
+	var rightHandSide fznValue
+	var err error
+	switch {
+	case kind.linear && len(arguments) == 3:
+		if coefficients, err = r.array(arguments[0]); err != nil {
+			return err
+		}
+		if values, err = r.array(arguments[1]); err != nil {
+			return err
+		}
+		if rightHandSide, err = r.value(arguments[2]); err != nil {
+			return err
+		}
+	case !kind.linear && len(arguments) == 2:
+		// a <= b and a = b are a - b <= 0 and a - b = 0.
+		coefficients = []fznValue{{constant: 1}, {constant: -1}}
+		values = make([]fznValue, 2)
+		for i := range values {
+			if values[i], err = r.value(arguments[i]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("constraint %s has %d arguments", name, len(arguments))
+	}
+	if len(coefficients) != len(values) || rightHandSide.variable != nil {
+		return fmt.Errorf("constraint %s is malformed", name)
+	}
+	return r.addConstraint(kind.sense, coefficients, values, rightHandSide.constant)
+}
+
+func (r *fznReader) addConstraint(
+	sense Sense,
+	coefficients []fznValue,
+	values []fznValue,
+	rightHandSide float64,
+) error {
+	terms := make([]TermSpec, 0, len(values))
+	for i, value := range values {
+		coefficient := coefficients[i]
+		if coefficient.variable != nil {
+			return fmt.Errorf("coefficient %d is a var", i+1)
+		}
+		if value.variable == nil {
+			rightHandSide -= coefficient.constant * value.constant
+			continue
+		}
+		terms = append(terms, TermSpec{Coefficient: coefficient.constant, Var: value.variable})
+	}
+	c, err := r.model.NewConstraintChecked(sense, rightHandSide)
+	if err != nil {
+		return err
+	}
+	for _, t := range terms {
+		if _, err := c.NewTermChecked(t.Coefficient, t.Var); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fznReader) solve(s string) error {
+	match := fznSolve.FindStringSubmatch(s)
+	if match == nil {
+		return fmt.Errorf("invalid solve item %q", s)
+	}
+	if match[1] == "satisfy" {
+		return nil
+	}
+	if match[1] == "maximize" {
+		r.model.Objective().SetMaximize()
+	}
+	objective, err := r.value(match[2])
+	if err != nil {
+		return err
+	}
+	if objective.variable != nil {
+		r.model.Objective().NewTerm(1, objective.variable)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+const testFlatZinc = `% knapsack
+int: capacity = 9;
+array [1..3] of int: weights = [4, 3, 5];
+var bool: pick1 :: output_var;
+var bool: pick2 :: output_var;
+var 0..1: pick3 :: output_var;
+var 0..100: profit :: is_defined_var;
+var 0.0..2.5: spare;
+var int: fixed = 4;
+array [1..3] of var int: picks :: output_array([1..3]) = [pick1, pick2, pick3];
+constraint int_lin_le(weights, picks, capacity);
+constraint int_lin_eq([10, 7, 12, -1], [pick1, pick2, pick3, profit], 0) :: defines_var(profit);
+constraint float_lin_le([1.0, 1.0], [spare, 2.0], 3.5);
+constraint int_le(picks[3], fixed);
+solve :: int_search(picks, input_order, indomain_max, complete) maximize profit;
+`
+
+func TestReadFlatZinc(t *testing.T) {
+	model, err := mip.ReadFlatZinc(strings.NewReader(testFlatZinc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `maximize   1 profit
+      0: 4 pick1 + 3 pick2 + 5 pick3 <= 9
+      1: 10 pick1 + 7 pick2 + 12 pick3 + -1 profit = 0
+      2: 1 spare <= 1.5
+      3: 1 pick3 + -1 fixed <= 0
+      0: pick1 [0, 1]
+      1: pick2 [0, 1]
+      2: pick3 [0, 1]
+      3: profit [0, 100]
+      4: spare [0, 2.5]
+      5: fixed [-Inf, +Inf]
+`
+	if got := model.(fmt.Stringer).String(); got != want {
+		t.Errorf("got\n%v\nwant\n%v", got, want)
+	}
+	vars := model.Vars()
+	if !vars[0].IsBool() || !vars[2].IsInt() || !vars[4].IsFloat() {
+		t.Errorf("got wrong var types")
+	}
+	if value, ok := vars[5].FixedValue(); !ok || value != 4 {
+		t.Errorf("got fixed value %v, %v, want 4, true", value, ok)
+	}
+}
+
+func TestReadFlatZincErrors(t *testing.T) {
+	tests := map[string]string{
+		"reified":    "var bool: b; var 0..3: x; constraint int_le_reif(x, 2, b);",
+		"set domain": "var {1, 3}: x;",
+		"predicate":  "predicate my_constraint(var int: x);",
+		"unknown":    "constraint int_lin_le([1], [y], 2);",
+		"arity":      "var 0..3: x; constraint int_lin_le([1], [x]);",
+		"NaN domain": "var nan..1: x;",
+		"NaN fixed":  "var 0.0..1.0: x = nan;",
+		"NaN coefficient": "var 0.0..1.0: x; " +
+			"constraint float_lin_le([nan], [x], 1.0);",
+		"NaN right-hand side": "var 0.0..1.0: x; " +
+			"constraint float_lin_le([1.0], [x], nan);",
+		"infinite times zero": "var 0.0..1.0: x; " +
+			"constraint float_lin_le([1.0, inf], [x, 0.0], 1.0);",
+	}
+	for name, fzn := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := mip.ReadFlatZinc(strings.NewReader(fzn)); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+// VarID is a lightweight handle of a var, its index in the model, see
+// Var.Index. Loops adding many terms use IDs with Model.AddTermByID instead
+// of Var and Constraint values, which saves the dispatch of their interface
+// methods.
+type VarID int
+
+// ConstraintID is a lightweight handle of a constraint, its position in the
+// slice returned by Model.Constraints, see VarID.
+type ConstraintID int
+
+// IDOf returns the handle of v.
+func IDOf(v Var) VarID {
+	return VarID(v.Index())
+}
+
+func (m *model) AddTermByID(c ConstraintID, v VarID, coefficient float64) {
+	if _, err := m.constraints[c].(*constraint).NewTermChecked(coefficient, m.vars[v]); err != nil {
+		panic(err)
+	}
+}
+
+func (m *model) ConstraintByID(id ConstraintID) Constraint {
+	return m.constraints[id]
+}
+
+func (m *model) NewConstraintID(sense Sense, rhs float64) ConstraintID {
+	m.NewConstraint(sense, rhs)
+	return ConstraintID(len(m.constraints) - 1)
+}
+
+func (m *model) VarByID(id VarID) Var {
+	return m.vars[id]
+}
@@ -0,0 +1,63 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestAddTermByID(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewInt(0, 5)
+
+	id := model.NewConstraintID(mip.LessThanOrEqual, 8)
+	model.AddTermByID(id, mip.IDOf(x), 1)
+	model.AddTermByID(id, mip.IDOf(y), 2)
+	model.AddTermByID(id, mip.IDOf(y), 1)
+
+	c := model.ConstraintByID(id)
+	if c != model.Constraints()[0] {
+		t.Fatalf("got constraint %v, want the first constraint", c)
+	}
+	if got := fmt.Sprint(c); got != "1 F0 + 3 I1 <= 8" {
+		t.Errorf("got constraint %v, want 1 F0 + 3 I1 <= 8", got)
+	}
+	if model.VarByID(mip.IDOf(y)) != y {
+		t.Errorf("got var %v, want %v", model.VarByID(mip.IDOf(y)), y)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("got no panic for out of range handle")
+		}
+	}()
+	model.AddTermByID(id+1, mip.IDOf(x), 1)
+}
+
+func BenchmarkAddTerm(b *testing.B) {
+	const terms = 1000
+	b.Run("NewTerm", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			model := mip.NewModel()
+			v := model.NewFloat(0, 1)
+			c := model.NewConstraint(mip.LessThanOrEqual, 1)
+			for j := 0; j < terms; j++ {
+				c.NewTerm(1, v)
+			}
+		}
+	})
+	b.Run("AddTermByID", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			model := mip.NewModel()
+			v := mip.IDOf(model.NewFloat(0, 1))
+			c := model.NewConstraintID(mip.LessThanOrEqual, 1)
+			for j := 0; j < terms; j++ {
+				model.AddTermByID(c, v, 1)
+			}
+		}
+	})
+}
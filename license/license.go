@@ -0,0 +1,326 @@
+// © 2019-present nextmv.io inc
+
+// Package license checks the licenses of commercial back-ends before a model
+// is solved, so a missing, expired or misconfigured license surfaces as an
+// actionable error instead of a failure deep inside the back-end library.
+// It locates Gurobi, FICO Xpress and IBM CPLEX licenses the way the
+// back-ends do: from mip.SolverConfig, the environment variables of the
+// back-end and its default locations.
+//
+//	info, err := license.Check("gurobi", config)
+//	if err != nil {
+//		return err
+//	}
+//	if err := license.CheckTokenServer(ctx, info.TokenServer); err != nil {
+//		return err
+//	}
+package license
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// TokenServerSetting is the key of mip.SolverConfig.Settings holding the
+// address of a token server, as host or host:port, or a comma-separated list
+// of them. Hosts without a port use the default port of the provider. It
+// takes precedence over a token server named in the license file.
+const TokenServerSetting = "token_server"
+
+// Errors returned by Check and CheckTokenServer. The returned errors wrap
+// them and describe how to resolve the problem. ErrUnsupportedProvider is a
+// mip.ErrUnsupportedFeature, the others are a mip.ErrLicense.
+var (
+	// ErrNotFound is returned if no license file could be located.
+	ErrNotFound = mip.NewKindError(mip.ErrLicense, "license not found")
+	// ErrExpired is returned if the license has expired.
+	ErrExpired = mip.NewKindError(mip.ErrLicense, "license expired")
+	// ErrInvalid is returned if the license file can not be parsed.
+	ErrInvalid = mip.NewKindError(mip.ErrLicense, "license invalid")
+	// ErrUnsupportedProvider is returned for providers without license
+	// checks.
+	ErrUnsupportedProvider = mip.NewKindError(mip.ErrUnsupportedFeature, "license check not supported")
+	// ErrTokenServerUnreachable is returned if the token server does not
+	// accept connections.
+	ErrTokenServerUnreachable = mip.NewKindError(mip.ErrLicense, "license token server unreachable")
+)
+
+// Default ports of the token servers of the providers, used for addresses
+// which do not specify one.
+const (
+	gurobiTokenServerPort = "41954"
+	xpressTokenServerPort = "27100"
+)
+
+// Info describes a license located by Check.
+type Info struct {
+	// Provider the license belongs to.
+	Provider mip.SolverProvider `json:"provider"`
+	// Path of the license file, empty if the license is embedded in the
+	// library of the back-end.
+	Path string `json:"path,omitempty"`
+	// Expires is the expiry date of the license, zero if the license does
+	// not expire or the expiry is not stated in the license file.
+	Expires time.Time `json:"expires"`
+	// TokenServer is the address of the token server handing out licenses
+	// as host:port, a comma-separated list of addresses if the license names
+	// several servers, empty if the license is not served by a token server.
+	TokenServer string `json:"token_server,omitempty"`
+}
+
+// licenseFile locates and parses the license file of a provider.
+type licenseFile struct {
+	// environment are the environment variables naming the license file or
+	// a directory containing it, in order of precedence.
+	environment []string
+	// names are the paths of the license file relative to the directory an
+	// environment variable names, by variable, e.g. bin/xpauth.xpr for the
+	// installation directory XPRESSDIR.
+	names map[string]string
+	// defaults are the default locations of the license file relative to
+	// the home directory, or absolute.
+	defaults []string
+	// port is the default port of the token server.
+	port string
+	// parse reads the expiry and token server from a license file.
+	parse func(r io.Reader, info *Info) error
+}
+
+var licenseFiles = map[mip.SolverProvider]licenseFile{
+	"gurobi": {
+		environment: []string{"GRB_LICENSE_FILE"},
+		names:       map[string]string{"GRB_LICENSE_FILE": "gurobi.lic"},
+		defaults:    []string{"gurobi.lic", "/opt/gurobi/gurobi.lic"},
+		port:        gurobiTokenServerPort,
+		parse:       parseGurobi,
+	},
+	"xpress": {
+		environment: []string{"XPAUTH_PATH", "XPRESSDIR"},
+		names: map[string]string{
+			"XPAUTH_PATH": "xpauth.xpr",
+			"XPRESSDIR":   filepath.Join("bin", "xpauth.xpr"),
+		},
+		defaults: []string{"/opt/xpressmp/bin/xpauth.xpr"},
+		port:     xpressTokenServerPort,
+		parse:    parseXpress,
+	},
+}
+
+// Check locates the license of provider and verifies it has not expired.
+// The license file is config.LicensePath if set, otherwise the one named by
+// the environment variables of the back-end, otherwise the first existing
+// default location. CPLEX licenses are embedded in its library, Check only
+// verifies config.LibraryPath exists if it is set.
+//
+// Returns an error wrapping ErrNotFound, ErrExpired, ErrInvalid or
+// ErrUnsupportedProvider otherwise.
+func Check(provider mip.SolverProvider, config mip.SolverConfig) (Info, error) {
+	info := Info{Provider: provider}
+	if provider == "cplex" {
+		return info, checkLibrary(provider, config.LibraryPath)
+	}
+	file, ok := licenseFiles[provider]
+	if !ok {
+		return info, fmt.Errorf("%w: provider %q", ErrUnsupportedProvider, provider)
+	}
+
+	path, err := file.locate(provider, config.LicensePath)
+	if err != nil {
+		return info, err
+	}
+	info.Path = path
+
+	f, err := os.Open(path)
+	if err != nil {
+		return info, fmt.Errorf("%w: %s license %s: %v", ErrNotFound, provider, path, err)
+	}
+	defer f.Close()
+	if err := file.parse(f, &info); err != nil {
+		return info, fmt.Errorf("%w: %s license %s: %v", ErrInvalid, provider, path, err)
+	}
+
+	if server, ok := config.Settings[TokenServerSetting]; ok {
+		info.TokenServer = withPort(server, file.port)
+	}
+	// A license is valid during the day it expires.
+	if !info.Expires.IsZero() && time.Now().After(info.Expires.AddDate(0, 0, 1)) {
+		return info, fmt.Errorf(
+			"%w: %s license %s expired on %s, renew it or point to a valid license with %s",
+			ErrExpired,
+			provider,
+			path,
+			info.Expires.Format(time.DateOnly),
+			strings.Join(file.environment, " or "),
+		)
+	}
+	return info, nil
+}
+
+// locate returns the path of the license file.
+func (f licenseFile) locate(provider mip.SolverProvider, path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	var candidates []string
+	for _, variable := range f.environment {
+		value := os.Getenv(variable)
+		if value == "" {
+			continue
+		}
+		if stat, err := os.Stat(value); err == nil && stat.IsDir() {
+			value = filepath.Join(value, f.names[variable])
+		}
+		candidates = append(candidates, value)
+	}
+	home, _ := os.UserHomeDir()
+	for _, location := range f.defaults {
+		if !filepath.IsAbs(location) {
+			if home == "" {
+				continue
+			}
+			location = filepath.Join(home, location)
+		}
+		candidates = append(candidates, location)
+	}
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf(
+		"%w: no %s license at %s, set mip.SolverConfig.LicensePath or %s",
+		ErrNotFound,
+		provider,
+		strings.Join(candidates, ", "),
+		strings.Join(f.environment, " or "),
+	)
+}
+
+func checkLibrary(provider mip.SolverProvider, path string) error {
+	if path == "" {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf(
+			"%w: %s library %s, set mip.SolverConfig.LibraryPath to the installed library: %v",
+			ErrNotFound,
+			provider,
+			path,
+			err,
+		)
+	}
+	return nil
+}
+
+// parseGurobi reads a Gurobi license file, lines of KEY=VALUE pairs with #
+// comments. EXPIRATION is a date or 0 for licenses which do not expire.
+// TOKENSERVER is a host or a comma-separated list of hosts, served at PORT.
+func parseGurobi(r io.Reader, info *Info) error {
+	var server, port string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToUpper(strings.TrimSpace(key)) {
+		case "EXPIRATION":
+			if value == "0" {
+				continue
+			}
+			expires, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				return fmt.Errorf("EXPIRATION: %w", err)
+			}
+			info.Expires = expires
+		case "TOKENSERVER":
+			server = value
+		case "PORT":
+			port = value
+		}
+	}
+	if port == "" {
+		port = gurobiTokenServerPort
+	}
+	info.TokenServer = withPort(server, port)
+	return scanner.Err()
+}
+
+// parseXpress reads a FICO Xpress license file. Only licenses served by a
+// token server are interpreted, they contain a line use_server
+// server="host" or server="host:port". The expiry of other licenses is
+// verified by the library.
+func parseXpress(r io.Reader, info *Info) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "use_server") {
+			continue
+		}
+		_, server, ok := strings.Cut(line, "server=")
+		if !ok {
+			return errors.New("use_server without server")
+		}
+		server = strings.Trim(strings.TrimSpace(server), `"`)
+		info.TokenServer = withPort(server, xpressTokenServerPort)
+	}
+	return scanner.Err()
+}
+
+// withPort returns the comma-separated token server addresses with port
+// added to the ones which do not specify one.
+func withPort(addresses, port string) string {
+	if addresses == "" {
+		return ""
+	}
+	list := strings.Split(addresses, ",")
+	for i, address := range list {
+		address = strings.TrimSpace(address)
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(address, port)
+		}
+		list[i] = address
+	}
+	return strings.Join(list, ",")
+}
+
+// CheckTokenServer verifies a token server at address accepts connections.
+// address is host:port as in Info.TokenServer, or a comma-separated list of
+// them of which one has to accept connections. Hosts without a port use the
+// default port of the Gurobi token server. Returns nil if address is empty
+// and an error wrapping ErrTokenServerUnreachable otherwise.
+func CheckTokenServer(ctx context.Context, address string) error {
+	if address == "" {
+		return nil
+	}
+	var errs []error
+	for _, server := range strings.Split(withPort(address, gurobiTokenServerPort), ",") {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", server)
+		if err == nil {
+			return conn.Close()
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf(
+		"%w: %s, check the server is running and reachable from this host: %v",
+		ErrTokenServerUnreachable,
+		address,
+		errors.Join(errs...),
+	)
+}
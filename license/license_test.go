@@ -0,0 +1,128 @@
+// © 2019-present nextmv.io inc
+
+package license_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+	"github.com/nextmv-io/go-mip/license"
+)
+
+func write(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCheckGurobi(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GRB_LICENSE_FILE", "")
+	_, err := license.Check("gurobi", mip.SolverConfig{})
+	if !errors.Is(err, license.ErrNotFound) || !errors.Is(err, mip.ErrLicense) {
+		t.Errorf("got error %v, want %v", err, license.ErrNotFound)
+	}
+
+	valid := write(t, "gurobi.lic", "# license\nTYPE=TOKEN\nTOKENSERVER=tokens\nPORT=1234\nEXPIRATION=2999-12-31\n")
+	t.Setenv("GRB_LICENSE_FILE", valid)
+	info, err := license.Check("gurobi", mip.SolverConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if info.Path != valid || info.TokenServer != "tokens:1234" || info.Expires.Year() != 2999 {
+		t.Errorf("got %+v", info)
+	}
+
+	info, err = license.Check("gurobi", mip.SolverConfig{
+		Settings: map[string]string{license.TokenServerSetting: "other"},
+	})
+	if err != nil || info.TokenServer != "other:41954" {
+		t.Errorf("got %+v, %v", info, err)
+	}
+
+	servers := write(t, "gurobi.lic", "TOKENSERVER=first, second:1234\n")
+	info, err = license.Check("gurobi", mip.SolverConfig{LicensePath: servers})
+	if err != nil || info.TokenServer != "first:41954,second:1234" {
+		t.Errorf("got %+v, %v", info, err)
+	}
+
+	expired := write(t, "gurobi.lic", "EXPIRATION=2000-01-01\n")
+	_, err = license.Check("gurobi", mip.SolverConfig{LicensePath: expired})
+	if !errors.Is(err, license.ErrExpired) {
+		t.Errorf("got error %v, want %v", err, license.ErrExpired)
+	}
+
+	invalid := write(t, "gurobi.lic", "EXPIRATION=soon\n")
+	_, err = license.Check("gurobi", mip.SolverConfig{LicensePath: invalid})
+	if !errors.Is(err, license.ErrInvalid) {
+		t.Errorf("got error %v, want %v", err, license.ErrInvalid)
+	}
+}
+
+func TestCheckXpress(t *testing.T) {
+	path := write(t, "xpauth.xpr", "use_server server=\"tokens\"\n")
+	t.Setenv("XPAUTH_PATH", filepath.Dir(path))
+	t.Setenv("XPRESSDIR", "")
+	info, err := license.Check("xpress", mip.SolverConfig{})
+	if err != nil || info.Path != path || info.TokenServer != "tokens:27100" {
+		t.Errorf("got %+v, %v", info, err)
+	}
+
+	// XPRESSDIR is the installation directory with the license in bin.
+	root := t.TempDir()
+	path = filepath.Join(root, "bin", "xpauth.xpr")
+	if err := os.Mkdir(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("use_server server=\"tokens\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XPAUTH_PATH", "")
+	t.Setenv("XPRESSDIR", root)
+	if info, err := license.Check("xpress", mip.SolverConfig{}); err != nil || info.Path != path {
+		t.Errorf("got %+v, %v, want license at %s", info, err, path)
+	}
+}
+
+func TestCheckUnsupported(t *testing.T) {
+	if _, err := license.Check("cplex", mip.SolverConfig{}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	_, err := license.Check("cplex", mip.SolverConfig{LibraryPath: filepath.Join(t.TempDir(), "missing.so")})
+	if !errors.Is(err, license.ErrNotFound) {
+		t.Errorf("got error %v, want %v", err, license.ErrNotFound)
+	}
+	if _, err := license.Check("highs", mip.SolverConfig{}); !errors.Is(err, license.ErrUnsupportedProvider) {
+		t.Errorf("got error %v, want %v", err, license.ErrUnsupportedProvider)
+	}
+}
+
+func TestCheckTokenServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("can not listen: %v", err)
+	}
+	address := listener.Addr().String()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := license.CheckTokenServer(ctx, address); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := license.CheckTokenServer(ctx, "127.0.0.1:1,"+address); err != nil {
+		t.Errorf("unexpected error %v for a list with a reachable server", err)
+	}
+	listener.Close()
+	err = license.CheckTokenServer(ctx, address)
+	if !errors.Is(err, license.ErrTokenServerUnreachable) {
+		t.Errorf("got error %v, want %v", err, license.ErrTokenServerUnreachable)
+	}
+}
@@ -0,0 +1,104 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"io"
+	"sync"
+)
+
+// CloseSolver releases the resources held by solver, e.g. the native memory
+// of a cgo back-end, if solver implements io.Closer. Back-ends holding native
+// resources implement io.Closer and release them deterministically in Close,
+// a finalizer is only a safety net for solvers which are never closed.
+// Closing a solver more than once must be a no-op. Returns nil if solver does
+// not implement io.Closer.
+//
+//	solver, err := mip.NewSolver("highs", model)
+//	if err != nil {
+//		return err
+//	}
+//	defer mip.CloseSolver(solver)
+func CloseSolver(solver Solver) error {
+	if closer, ok := UnwrapSolver(solver).(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// SharedEnvironment shares a back-end environment, e.g. a licensed Gurobi or
+// Xpress environment, between solvers. The environment is opened when it is
+// first acquired and closed when the last holder releases it, so a
+// long-running service does not pay for opening an environment per solve and
+// does not keep it once no solver uses it. The methods are safe for
+// concurrent use.
+//
+//	var environments = mip.NewSharedEnvironment(openEnv, closeEnv)
+//
+//	func newSolver(model mip.Model) (mip.Solver, error) {
+//		env, err := environments.Acquire()
+//		if err != nil {
+//			return nil, err
+//		}
+//		return &solver{env: env, release: environments.Release}, nil
+//	}
+type SharedEnvironment[E any] struct {
+	open        func() (E, error)
+	close       func(E) error
+	environment E
+	holders     int
+	mutex       sync.Mutex
+}
+
+// NewSharedEnvironment creates a shared environment which is opened with
+// open and closed with close.
+func NewSharedEnvironment[E any](
+	open func() (E, error),
+	close func(E) error,
+) *SharedEnvironment[E] {
+	return &SharedEnvironment[E]{
+		open:  open,
+		close: close,
+	}
+}
+
+// Acquire returns the environment, opening it if it has no holders. Each
+// successful Acquire must be paired with a Release.
+func (s *SharedEnvironment[E]) Acquire() (E, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.holders == 0 {
+		environment, err := s.open()
+		if err != nil {
+			return environment, err
+		}
+		s.environment = environment
+	}
+	s.holders++
+	return s.environment, nil
+}
+
+// Release releases the environment acquired before, closing it if this was
+// the last holder. Releasing an environment without holders is a no-op.
+func (s *SharedEnvironment[E]) Release() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.holders == 0 {
+		return nil
+	}
+	s.holders--
+	if s.holders > 0 {
+		return nil
+	}
+	var zero E
+	environment := s.environment
+	s.environment = zero
+	return s.close(environment)
+}
+
+// Holders returns the number of holders of the environment.
+func (s *SharedEnvironment[E]) Holders() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.holders
+}
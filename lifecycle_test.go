@@ -0,0 +1,75 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestCloseSolver(t *testing.T) {
+	if err := mip.CloseSolver(&testSolver{}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	solver := &closingSolver{testSolver: testSolver{solution: newTestSolution(1, nil)}}
+	factory := mip.ObserveSolverFactory("test", func(mip.Model) (mip.Solver, error) {
+		return solver, nil
+	}, nil)
+	observed, err := factory(mip.NewModel())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := mip.CloseSolver(observed); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if solver.closed != 1 {
+		t.Errorf("closed solver %d times, want 1", solver.closed)
+	}
+}
+
+func TestSharedEnvironment(t *testing.T) {
+	opened, closed := 0, 0
+	environment := mip.NewSharedEnvironment(
+		func() (int, error) {
+			opened++
+			return opened, nil
+		},
+		func(int) error {
+			closed++
+			return nil
+		},
+	)
+
+	for i := 0; i < 2; i++ {
+		if env, err := environment.Acquire(); err != nil || env != 1 {
+			t.Fatalf("got environment %v, error %v", env, err)
+		}
+	}
+	if err := environment.Release(); err != nil || closed != 0 {
+		t.Errorf("closed %d times with holders, error %v", closed, err)
+	}
+	if err := environment.Release(); err != nil || closed != 1 {
+		t.Errorf("closed %d times without holders, error %v", closed, err)
+	}
+	if err := environment.Release(); err != nil || closed != 1 {
+		t.Errorf("releasing without holders closed %d times, error %v", closed, err)
+	}
+
+	if env, err := environment.Acquire(); err != nil || env != 2 {
+		t.Errorf("got environment %v, error %v, want reopened environment", env, err)
+	}
+	if environment.Holders() != 1 {
+		t.Errorf("got %d holders, want 1", environment.Holders())
+	}
+
+	failing := mip.NewSharedEnvironment(
+		func() (int, error) { return 0, errors.New("no license") },
+		func(int) error { return nil },
+	)
+	if _, err := failing.Acquire(); err == nil || failing.Holders() != 0 {
+		t.Errorf("failed acquire has %d holders, error %v", failing.Holders(), err)
+	}
+}
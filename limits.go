@@ -0,0 +1,66 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+// limitParameters maps the limits to the names of the parameters of the
+// back-ends, in the order nodes, iterations and solutions.
+var limitParameters = map[SolverProvider][3]string{
+	"highs": {
+		"mip_max_nodes",
+		"simplex_iteration_limit",
+		"mip_max_improving_sols",
+	},
+	"xpress": {
+		"MAXNODE",
+		"LPITERLIMIT",
+		"MAXMIPSOL",
+	},
+}
+
+// LimitControlOptions returns the limits which are set, i.e. not 0, as
+// control options of provider. Back-ends apply them the same way as the int
+// control options. Returns false if the parameters of provider are not known.
+func LimitControlOptions(
+	provider SolverProvider,
+	limits LimitOptions,
+) ([]TypedControlOption[int], bool) {
+	names, ok := limitParameters[provider]
+	if !ok {
+		return nil, false
+	}
+	values := [3]int{
+		limits.Nodes,
+		limits.Iterations,
+		limits.Solutions,
+	}
+	options := make([]TypedControlOption[int], 0, len(values))
+	for i, value := range values {
+		if value == 0 {
+			continue
+		}
+		options = append(options, TypedControlOption[int]{
+			Name:  names[i],
+			Value: value,
+		})
+	}
+	return options, true
+}
+
+// FeasibilityOnlyControlOptions returns the control options of provider which
+// stop the solve at the first feasible solution, see
+// SolveOptions.FeasibilityOnly. Back-ends apply them the same way as the int
+// control options. Returns false if the parameters of provider are not known.
+func FeasibilityOnlyControlOptions(
+	provider SolverProvider,
+) ([]TypedControlOption[int], bool) {
+	names, ok := limitParameters[provider]
+	if !ok {
+		return nil, false
+	}
+	return []TypedControlOption[int]{
+		{
+			Name:  names[2],
+			Value: 1,
+		},
+	}, true
+}
@@ -0,0 +1,222 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "fmt"
+
+// Expr is a logical expression over bool vars, built with And, Or, Not,
+// Implies and Iff. Bool vars and literals are expressions. Require adds
+// linear constraints to a model which enforce an expression, Reify a bool
+// var which equals its truth value.
+//
+//	// A driver with a hazmat load needs a hazmat license or a co-driver.
+//	mip.Require(model, mip.Implies(hazmat, mip.Or(license, coDriver)))
+type Expr interface {
+	ensureExpr() bool
+}
+
+// logicOperator is the operator of a logicExpr.
+type logicOperator int
+
+const (
+	and logicOperator = iota
+	or
+	not
+	implies
+	iff
+)
+
+// logicExpr applies an operator to expressions.
+type logicExpr struct {
+	operator logicOperator
+	args     []Expr
+}
+
+func (e *logicExpr) ensureExpr() bool {
+	return true
+}
+
+func (l Literal) ensureExpr() bool {
+	return true
+}
+
+// And is true if all exprs are true, it is true if there are none.
+func And(exprs ...Expr) Expr {
+	return &logicExpr{operator: and, args: exprs}
+}
+
+// Or is true if any of exprs is true, it is false if there are none.
+func Or(exprs ...Expr) Expr {
+	return &logicExpr{operator: or, args: exprs}
+}
+
+// Not is true if e is false.
+func Not(e Expr) Expr {
+	return &logicExpr{operator: not, args: []Expr{e}}
+}
+
+// Implies is true if a is false or b is true.
+func Implies(a, b Expr) Expr {
+	return &logicExpr{operator: implies, args: []Expr{a, b}}
+}
+
+// Iff is true if a and b are both true or both false.
+func Iff(a, b Expr) Expr {
+	return &logicExpr{operator: iff, args: []Expr{a, b}}
+}
+
+// Require adds linear constraints to model which enforce e. Conjunctions are
+// split into their parts, a disjunction becomes a constraint which requires
+// at least one of its literals to hold. Subexpressions which are not
+// literals are reified with new bool vars, see Reify.
+func Require(model Model, e Expr) {
+	switch e := e.(type) {
+	case Bool, Literal:
+		addLiterals(model, GreaterThanOrEqual, 1, literalOf(model, e))
+		return
+	case *logicExpr:
+		switch e.operator {
+		case and:
+			for _, arg := range e.args {
+				Require(model, arg)
+			}
+		case or:
+			literals := make([]Literal, len(e.args))
+			for i, arg := range e.args {
+				literals[i] = literalOf(model, arg)
+			}
+			addLiterals(model, GreaterThanOrEqual, 1, literals...)
+		case not:
+			Require(model, negate(e.args[0]))
+		case implies:
+			Require(model, Or(Not(e.args[0]), e.args[1]))
+		case iff:
+			a := literalOf(model, e.args[0])
+			b := literalOf(model, e.args[1])
+			addLiterals(model, Equal, 1, a, b.complement())
+		}
+		return
+	}
+	panic(fmt.Sprintf("unknown logical expression %T", e))
+}
+
+// Reify returns a bool var of model which is 1 if and only if e is true. A
+// bool var is returned as is, other expressions get a new var and the
+// constraints linking it to the literals of the expression.
+func Reify(model Model, e Expr) Bool {
+	l := literalOf(model, e)
+	if b, ok := l.Var.(Bool); ok && l.Value {
+		return b
+	}
+	z := model.NewBool()
+	addLiterals(model, Equal, 1, Literal{Var: z, Value: true}, l.complement())
+	return z
+}
+
+// literalOf returns a literal which holds if and only if e is true,
+// reifying e with a new bool var if it is not a literal or its negation.
+func literalOf(model Model, e Expr) Literal {
+	switch e := e.(type) {
+	case Bool:
+		return Literal{Var: e, Value: true}
+	case Literal:
+		return e
+	case *logicExpr:
+		switch e.operator {
+		case and, or:
+			literals := make([]Literal, len(e.args))
+			for i, arg := range e.args {
+				literals[i] = literalOf(model, arg)
+			}
+			return reifyJunction(model, e.operator == and, literals)
+		case not:
+			return literalOf(model, e.args[0]).complement()
+		case implies:
+			return literalOf(model, Or(Not(e.args[0]), e.args[1]))
+		case iff:
+			a, b := e.args[0], e.args[1]
+			return literalOf(model, And(Implies(a, b), Implies(b, a)))
+		}
+	}
+	panic(fmt.Sprintf("unknown logical expression %T", e))
+}
+
+// reifyJunction returns the literal of a new bool var z which is the
+// conjunction of literals if conjunction, the disjunction otherwise. The
+// disjunction is the negated conjunction of the negated literals.
+func reifyJunction(model Model, conjunction bool, literals []Literal) Literal {
+	if !conjunction {
+		negated := make([]Literal, len(literals))
+		for i, l := range literals {
+			negated[i] = l.complement()
+		}
+		return reifyJunction(model, true, negated).complement()
+	}
+	z := Literal{Var: model.NewBool(), Value: true}
+	// z implies every literal.
+	for _, l := range literals {
+		addLiterals(model, LessThanOrEqual, 1, z, l.complement())
+	}
+	// All literals imply z.
+	terms := []Literal{z}
+	for _, l := range literals {
+		terms = append(terms, l.complement())
+	}
+	addLiterals(model, GreaterThanOrEqual, 1, terms...)
+	return z
+}
+
+// negate returns the negation of e with the negation pushed into its
+// operands.
+func negate(e Expr) Expr {
+	switch e := e.(type) {
+	case *logicExpr:
+		switch e.operator {
+		case and, or:
+			negated := make([]Expr, len(e.args))
+			for i, arg := range e.args {
+				negated[i] = negate(arg)
+			}
+			if e.operator == and {
+				return Or(negated...)
+			}
+			return And(negated...)
+		case not:
+			return e.args[0]
+		case implies:
+			return And(e.args[0], negate(e.args[1]))
+		case iff:
+			return Iff(e.args[0], negate(e.args[1]))
+		}
+	case Bool:
+		return Literal{Var: e, Value: false}
+	case Literal:
+		return e.complement()
+	}
+	panic(fmt.Sprintf("unknown logical expression %T", e))
+}
+
+// complement returns the literal which holds if and only if l does not.
+func (l Literal) complement() Literal {
+	return Literal{Var: l.Var, Value: !l.Value}
+}
+
+// addLiterals adds the constraint that the number of literals which hold
+// relates to rightHandSide by sense. A literal x = 1 is the term x, a literal
+// x = 0 the term 1 - x.
+func addLiterals(model Model, sense Sense, rightHandSide float64, literals ...Literal) Constraint {
+	for _, l := range literals {
+		if !l.Value {
+			rightHandSide--
+		}
+	}
+	c := model.NewConstraint(sense, rightHandSide)
+	for _, l := range literals {
+		if l.Value {
+			c.NewTerm(1, l.Var)
+			continue
+		}
+		c.NewTerm(-1, l.Var)
+	}
+	return c
+}
@@ -0,0 +1,98 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// satisfiable returns true if the constraints of model, whose vars are all
+// bool vars, are satisfiable with the first vars set to values.
+func satisfiable(model mip.Model, values ...bool) bool {
+	vars := model.Vars()
+	free := len(vars) - len(values)
+	for mask := 0; mask < 1<<free; mask++ {
+		assignment := make(map[mip.Var]float64, len(vars))
+		for i, v := range vars {
+			if i < len(values) {
+				if values[i] {
+					assignment[v] = 1
+				}
+				continue
+			}
+			assignment[v] = float64(mask >> (i - len(values)) & 1)
+		}
+		if mip.Evaluate(model, assignment).TotalViolation == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLogic(t *testing.T) {
+	tests := []struct {
+		name string
+		expr func(x, y, z mip.Bool) mip.Expr
+		want func(x, y, z bool) bool
+	}{
+		{
+			name: "implies or",
+			expr: func(x, y, z mip.Bool) mip.Expr {
+				return mip.Implies(x, mip.Or(y, mip.Not(z)))
+			},
+			want: func(x, y, z bool) bool { return !x || y || !z },
+		},
+		{
+			name: "not and",
+			expr: func(x, y, z mip.Bool) mip.Expr {
+				return mip.Not(mip.And(x, y, z))
+			},
+			want: func(x, y, z bool) bool { return !(x && y && z) },
+		},
+		{
+			name: "iff nested",
+			expr: func(x, y, z mip.Bool) mip.Expr {
+				return mip.Iff(x, mip.And(y, mip.Or(z, mip.Not(x))))
+			},
+			want: func(x, y, z bool) bool { return x == (y && (z || !x)) },
+		},
+		{
+			name: "not implies",
+			expr: func(x, y, z mip.Bool) mip.Expr {
+				return mip.Or(mip.Not(mip.Implies(x, y)), mip.Iff(y, z))
+			},
+			want: func(x, y, z bool) bool { return (x && !y) || y == z },
+		},
+	}
+	for _, test := range tests {
+		model := mip.NewModel()
+		x, y, z := model.NewBool(), model.NewBool(), model.NewBool()
+		mip.Require(model, test.expr(x, y, z))
+		for mask := 0; mask < 8; mask++ {
+			values := []bool{mask&1 == 1, mask&2 == 2, mask&4 == 4}
+			want := test.want(values[0], values[1], values[2])
+			if got := satisfiable(model, values...); got != want {
+				t.Errorf("%s: got %v for %v, want %v", test.name, got, values, want)
+			}
+		}
+	}
+}
+
+func TestReify(t *testing.T) {
+	model := mip.NewModel()
+	x, y := model.NewBool(), model.NewBool()
+	if mip.Reify(model, x) != x {
+		t.Errorf("reifying a bool var returned another var")
+	}
+	either := mip.Reify(model, mip.Or(x, mip.Not(y)))
+	mip.Require(model, mip.Not(either))
+	for mask := 0; mask < 4; mask++ {
+		values := []bool{mask&1 == 1, mask&2 == 2}
+		want := !values[0] && values[1]
+		if got := satisfiable(model, values...); got != want {
+			t.Errorf("got %v for %v, want %v", got, values, want)
+		}
+	}
+}
@@ -0,0 +1,989 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// lpTermsPerLine is the number of terms written per line by WriteLP. The LP
+// format limits the length of a line.
+const lpTermsPerLine = 8
+
+// WriteLP writes model to w in the CPLEX LP format. Vars and constraints are
+// written using their names, characters which are not allowed in LP names
+// are replaced by an underscore. Vars and constraints without a name are
+// written using their auto-generated name. A name which is already used by
+// an earlier var, or constraint respectively, gets a "_%d" suffix, so names
+// stay unique after replacing characters. Constraints without terms are
+// ignored by solvers and are not written.
+func WriteLP(w io.Writer, model Model) error {
+	writer := bufio.NewWriter(w)
+	vars := model.Vars()
+	varNames := make(lpNames)
+	names := make([]string, len(vars))
+	for i, v := range vars {
+		names[i] = varNames.unique(fmt.Sprint(v))
+	}
+
+	fmt.Fprintln(writer, "\\ written by go-mip")
+	if model.Objective().IsMaximize() {
+		fmt.Fprintln(writer, "Maximize")
+	} else {
+		fmt.Fprintln(writer, "Minimize")
+	}
+	writeLPObjective(writer, model.Objective(), names)
+
+	fmt.Fprintln(writer, "Subject To")
+	// The objective row is named obj.
+	constraintNames := lpNames{"obj": true}
+	for i, c := range model.Constraints() {
+		terms := sortedTerms(c.Terms())
+		if len(terms) == 0 {
+			continue
+		}
+		name := c.Name()
+		if name == "" {
+			name = fmt.Sprintf("c%d", i)
+		}
+		fmt.Fprintf(writer, " %s:", constraintNames.unique(name))
+		writeLPTerms(writer, terms, names)
+		switch c.Sense() {
+		case LessThanOrEqual:
+			fmt.Fprint(writer, " <=")
+		case GreaterThanOrEqual:
+			fmt.Fprint(writer, " >=")
+		default:
+			fmt.Fprint(writer, " =")
+		}
+		fmt.Fprintf(writer, " %s\n", formatLPNumber(c.RightHandSide()))
+	}
+
+	writeLPBounds(writer, vars, names)
+
+	writeLPVarSection(writer, "Generals", vars, names, func(v Var) bool {
+		return v.IsInt() && !v.IsBool()
+	})
+	writeLPVarSection(writer, "Binaries", vars, names, func(v Var) bool {
+		return v.IsBool()
+	})
+
+	fmt.Fprintln(writer, "End")
+
+	return writer.Flush()
+}
+
+func writeLPObjective(w io.Writer, objective Objective, names []string) {
+	fmt.Fprint(w, " obj:")
+	terms := sortedTerms(objective.Terms())
+	writeLPTerms(w, terms, names)
+	qTerms := sortedQuadraticTerms(objective.QuadraticTerms())
+	if len(qTerms) == 0 {
+		if len(terms) == 0 {
+			fmt.Fprint(w, " 0")
+		}
+		fmt.Fprintln(w)
+		return
+	}
+	if len(terms) > 0 {
+		fmt.Fprint(w, " +")
+	}
+	fmt.Fprint(w, " [")
+	for i, t := range qTerms {
+		if i > 0 && i%lpTermsPerLine == 0 {
+			fmt.Fprint(w, "\n  ")
+		}
+		// Quadratic terms are halved by the trailing "/ 2".
+		writeLPCoefficient(w, i, 2*t.Coefficient())
+		if t.Var1().Index() == t.Var2().Index() {
+			fmt.Fprintf(w, " %s ^ 2", names[t.Var1().Index()])
+		} else {
+			fmt.Fprintf(w, " %s * %s", names[t.Var1().Index()], names[t.Var2().Index()])
+		}
+	}
+	fmt.Fprintln(w, " ] / 2")
+}
+
+func writeLPBounds(w io.Writer, vars Vars, names []string) {
+	fmt.Fprintln(w, "Bounds")
+	for _, v := range vars {
+		if _, fixed := v.FixedValue(); v.IsBool() && !fixed {
+			continue
+		}
+		lower, upper := bounds(v)
+		switch {
+		case math.IsInf(lower, -1) && math.IsInf(upper, 1):
+			fmt.Fprintf(w, " %s free\n", names[v.Index()])
+		case lower == 0 && math.IsInf(upper, 1):
+		default:
+			fmt.Fprintf(w, " %s <= %s <= %s\n",
+				formatLPNumber(lower),
+				names[v.Index()],
+				formatLPNumber(upper),
+			)
+		}
+	}
+}
+
+func writeLPTerms(w io.Writer, terms Terms, names []string) {
+	for i, t := range terms {
+		if i > 0 && i%lpTermsPerLine == 0 {
+			fmt.Fprint(w, "\n  ")
+		}
+		writeLPCoefficient(w, i, t.Coefficient())
+		fmt.Fprintf(w, " %s", names[t.Var().Index()])
+	}
+}
+
+func writeLPCoefficient(w io.Writer, position int, coefficient float64) {
+	switch {
+	case coefficient < 0:
+		fmt.Fprintf(w, " - %s", formatLPNumber(-coefficient))
+	case position > 0:
+		fmt.Fprintf(w, " + %s", formatLPNumber(coefficient))
+	default:
+		fmt.Fprintf(w, " %s", formatLPNumber(coefficient))
+	}
+}
+
+func writeLPVarSection(
+	w io.Writer,
+	section string,
+	vars Vars,
+	names []string,
+	include func(Var) bool,
+) {
+	written := 0
+	for _, v := range vars {
+		if !include(v) {
+			continue
+		}
+		if written == 0 {
+			fmt.Fprintln(w, section)
+		}
+		fmt.Fprintf(w, " %s\n", names[v.Index()])
+		written++
+	}
+}
+
+func formatLPNumber(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+inf"
+	case math.IsInf(value, -1):
+		return "-inf"
+	}
+	return formatFloat(value)
+}
+
+// lpNames is the set of LP names written so far, of vars or constraints.
+type lpNames map[string]bool
+
+// unique returns name sanitized, see sanitizeLPName, which is not in the set
+// yet and adds it. A "_%d" suffix is added if needed.
+func (n lpNames) unique(name string) string {
+	sanitized := sanitizeLPName(name)
+	unique := sanitized
+	for i := 1; n[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", sanitized, i)
+	}
+	n[unique] = true
+	return unique
+}
+
+// sanitizeLPName replaces characters which are not allowed in LP names by an
+// underscore. Names may not start with a digit or a period.
+func sanitizeLPName(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if !isLPNameRune(r) || (i == 0 && (unicode.IsDigit(r) || r == '.')) {
+			sb.WriteRune('_')
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func isLPNameRune(r rune) bool {
+	if r > unicode.MaxASCII {
+		return false
+	}
+	return unicode.IsLetter(r) ||
+		unicode.IsDigit(r) ||
+		strings.ContainsRune("!\"#$%&()/,.;?@_`'{}|~", r)
+}
+
+// ReadLP reads a model in the CPLEX LP format from r. The objective,
+// constraints, bounds, general and binary sections are supported, including
+// a quadratic objective in square brackets. Comments start with a backslash.
+// Vars are created in the order of their first appearance and named after
+// their LP name, constraints are named after their LP name if they have one.
+// Vars in the generals section become Int vars and vars in the binaries
+// section Bool vars. An error is returned for unsupported sections such as
+// semi-continuous vars or SOS constraints, for constants in the objective
+// and for malformed input.
+func ReadLP(r io.Reader) (Model, error) {
+	tokens, err := tokenizeLP(r)
+	if err != nil {
+		return nil, err
+	}
+	parser := &lpParser{
+		tokens:   tokens,
+		bounds:   make(map[string]*lpBound),
+		varTypes: make(map[string]string),
+	}
+	if err := parser.parse(); err != nil {
+		return nil, err
+	}
+	return parser.build(), nil
+}
+
+type lpTokenKind int
+
+const (
+	lpNumber lpTokenKind = iota
+	lpIdentifier
+	lpOperator
+	lpSection
+)
+
+type lpToken struct {
+	kind  lpTokenKind
+	text  string
+	value float64
+	line  int
+}
+
+var lpSections = map[string]string{
+	"maximize":        "maximize",
+	"maximise":        "maximize",
+	"maximum":         "maximize",
+	"max":             "maximize",
+	"minimize":        "minimize",
+	"minimise":        "minimize",
+	"minimum":         "minimize",
+	"min":             "minimize",
+	"subject to":      "constraints",
+	"such that":       "constraints",
+	"st":              "constraints",
+	"s.t.":            "constraints",
+	"st.":             "constraints",
+	"bounds":          "bounds",
+	"bound":           "bounds",
+	"generals":        "generals",
+	"general":         "generals",
+	"gen":             "generals",
+	"integers":        "generals",
+	"binaries":        "binaries",
+	"binary":          "binaries",
+	"bin":             "binaries",
+	"semi-continuous": "unsupported",
+	"semis":           "unsupported",
+	"semi":            "unsupported",
+	"sos":             "unsupported",
+	"end":             "end",
+}
+
+// tokenizeLP splits the LP input into tokens. Section keywords are only
+// recognized at the start of a line.
+func tokenizeLP(r io.Reader) ([]lpToken, error) {
+	tokens := make([]lpToken, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if i := strings.IndexRune(text, '\\'); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		if section, rest, ok := lpSectionPrefix(text); ok {
+			tokens = append(tokens, lpToken{
+				kind: lpSection,
+				text: section,
+				line: line,
+			})
+			text = rest
+		}
+		lineTokens, err := tokenizeLPLine(text, line)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, lineTokens...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func lpSectionPrefix(text string) (section string, rest string, ok bool) {
+	lower := strings.ToLower(text)
+	for keyword, section := range lpSections {
+		if !strings.HasPrefix(lower, keyword) {
+			continue
+		}
+		rest := text[len(keyword):]
+		if rest != "" && !unicode.IsSpace(rune(rest[0])) {
+			continue
+		}
+		rest = strings.TrimSpace(rest)
+		// A constraint or bound may start with a name looking like a
+		// keyword, e.g. "bin <= 1" or "min: x >= 1".
+		if rest != "" && !lpSectionContinues(section, rest[0]) {
+			continue
+		}
+		return section, rest, true
+	}
+	return "", "", false
+}
+
+// lpSectionContinues returns true if c may start the content following the
+// keyword of section on the same line.
+func lpSectionContinues(section string, c byte) bool {
+	if strings.IndexByte("<>=:*^/]", c) >= 0 {
+		return false
+	}
+	if section == "maximize" || section == "minimize" {
+		return true
+	}
+	return strings.IndexByte("+-[0123456789.", c) < 0
+}
+
+func tokenizeLPLine(text string, line int) ([]lpToken, error) {
+	tokens := make([]lpToken, 0)
+	for i := 0; i < len(text); {
+		c := text[i]
+		var (
+			token lpToken
+			j     int
+			err   error
+		)
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+			continue
+		case strings.IndexByte("<>=", c) >= 0:
+			j = scanLP(text, i, func(c byte) bool {
+				return strings.IndexByte("<>=", c) >= 0
+			})
+			token.kind = lpOperator
+			token.text, err = normalizeLPComparison(text[i:j], line)
+		case strings.IndexByte("+-*^:[]/", c) >= 0:
+			j = i + 1
+			token.kind = lpOperator
+			token.text = string(c)
+		case c >= '0' && c <= '9' || c == '.':
+			j = scanLPNumber(text, i)
+			token.kind = lpNumber
+			token.text = text[i:j]
+			token.value, err = strconv.ParseFloat(token.text, 64)
+			if err != nil {
+				err = fmt.Errorf("lp: line %d: invalid number %q", line, token.text)
+			}
+		case isLPNameRune(rune(c)):
+			j = scanLP(text, i, func(c byte) bool {
+				return isLPNameRune(rune(c))
+			})
+			token.kind = lpIdentifier
+			token.text = text[i:j]
+			switch strings.ToLower(token.text) {
+			case "inf", "infinity":
+				token.kind = lpNumber
+				token.value = math.Inf(1)
+			}
+		default:
+			err = fmt.Errorf("lp: line %d: unexpected character %q", line, c)
+		}
+		if err != nil {
+			return nil, err
+		}
+		token.line = line
+		tokens = append(tokens, token)
+		i = j
+	}
+	return tokens, nil
+}
+
+// scanLP returns the position of the first byte after i in text for which
+// accept returns false.
+func scanLP(text string, i int, accept func(byte) bool) int {
+	j := i + 1
+	for j < len(text) && accept(text[j]) {
+		j++
+	}
+	return j
+}
+
+// scanLPNumber returns the position of the first byte after the number
+// starting at position i in text. Numbers may have an exponent.
+func scanLPNumber(text string, i int) int {
+	isDigit := func(c byte) bool {
+		return c >= '0' && c <= '9'
+	}
+	j := scanLP(text, i, func(c byte) bool {
+		return isDigit(c) || c == '.'
+	})
+	if j >= len(text) || (text[j] != 'e' && text[j] != 'E') {
+		return j
+	}
+	k := j + 1
+	if k < len(text) && (text[k] == '+' || text[k] == '-') {
+		k++
+	}
+	if k >= len(text) || !isDigit(text[k]) {
+		return j
+	}
+	return scanLP(text, k, isDigit)
+}
+
+func normalizeLPComparison(operator string, line int) (string, error) {
+	switch operator {
+	case "<", "<=", "=<":
+		return "<=", nil
+	case ">", ">=", "=>":
+		return ">=", nil
+	case "=":
+		return "=", nil
+	}
+	return "", fmt.Errorf("lp: line %d: invalid operator %q", line, operator)
+}
+
+type lpBound struct {
+	lower float64
+	upper float64
+}
+
+type lpTerm struct {
+	coefficient float64
+	name1       string
+	name2       string
+}
+
+type lpConstraint struct {
+	name  string
+	terms []lpTerm
+	sense Sense
+	rhs   float64
+}
+
+type lpParser struct {
+	tokens      []lpToken
+	position    int
+	maximize    bool
+	objective   []lpTerm
+	quadratic   []lpTerm
+	constraints []lpConstraint
+	bounds      map[string]*lpBound
+	varTypes    map[string]string
+	varOrder    []string
+	varSeen     map[string]bool
+}
+
+func (p *lpParser) peek() (lpToken, bool) {
+	if p.position >= len(p.tokens) {
+		return lpToken{}, false
+	}
+	return p.tokens[p.position], true
+}
+
+func (p *lpParser) next() (lpToken, bool) {
+	token, ok := p.peek()
+	if ok {
+		p.position++
+	}
+	return token, ok
+}
+
+func (p *lpParser) atSectionEnd() bool {
+	token, ok := p.peek()
+	return !ok || token.kind == lpSection
+}
+
+func (p *lpParser) errorf(token lpToken, format string, args ...any) error {
+	return fmt.Errorf("lp: line %d: %s", token.line, fmt.Sprintf(format, args...))
+}
+
+func (p *lpParser) addVar(name string) {
+	if p.varSeen == nil {
+		p.varSeen = make(map[string]bool)
+	}
+	if !p.varSeen[name] {
+		p.varSeen[name] = true
+		p.varOrder = append(p.varOrder, name)
+	}
+}
+
+func (p *lpParser) parse() error {
+	objectiveSeen := false
+	for {
+		token, ok := p.next()
+		if !ok {
+			return nil
+		}
+		if token.kind != lpSection {
+			return p.errorf(token, "unexpected %q outside of a section", token.text)
+		}
+		var err error
+		switch token.text {
+		case "maximize", "minimize":
+			if objectiveSeen {
+				return p.errorf(token, "multiple objectives")
+			}
+			objectiveSeen = true
+			p.maximize = token.text == "maximize"
+			err = p.parseObjective()
+		case "constraints":
+			err = p.parseConstraints()
+		case "bounds":
+			err = p.parseBounds()
+		case "generals", "binaries":
+			err = p.parseVarSection(token.text)
+		case "end":
+			return nil
+		default:
+			return p.errorf(token, "unsupported section")
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (p *lpParser) parseObjective() error {
+	p.skipLabel()
+	terms, constant, err := p.parseExpression(true)
+	if err != nil {
+		return err
+	}
+	if constant != 0 {
+		return fmt.Errorf("lp: constants in the objective are not supported")
+	}
+	for _, t := range terms {
+		if t.name2 != "" {
+			p.quadratic = append(p.quadratic, t)
+		} else {
+			p.objective = append(p.objective, t)
+		}
+	}
+	if !p.atSectionEnd() {
+		token, _ := p.peek()
+		return p.errorf(token, "unexpected %q in objective", token.text)
+	}
+	return nil
+}
+
+// skipLabel consumes a "name:" label and returns the name, or returns an
+// empty string if there is no label.
+func (p *lpParser) skipLabel() string {
+	if p.position+1 >= len(p.tokens) {
+		return ""
+	}
+	token, colon := p.tokens[p.position], p.tokens[p.position+1]
+	if token.kind == lpIdentifier && colon.kind == lpOperator && colon.text == ":" {
+		p.position += 2
+		return token.text
+	}
+	return ""
+}
+
+// parseExpression parses a sum of terms until a comparison operator or the
+// end of the section. Quadratic terms in square brackets are only allowed if
+// quadratic is true.
+func (p *lpParser) parseExpression(quadratic bool) ([]lpTerm, float64, error) {
+	terms := make([]lpTerm, 0)
+	constant := 0.0
+	for !p.atSectionEnd() {
+		token, _ := p.peek()
+		if token.kind == lpOperator &&
+			(token.text == "<=" || token.text == ">=" || token.text == "=") {
+			break
+		}
+		if token.kind == lpOperator && token.text == ":" {
+			return nil, 0, p.errorf(token, "unexpected %q", token.text)
+		}
+		sign, err := p.parseSign(len(terms) == 0 && constant == 0)
+		if err != nil {
+			return nil, 0, err
+		}
+		token, ok := p.peek()
+		if !ok {
+			return nil, 0, fmt.Errorf("lp: unexpected end of input")
+		}
+		if token.kind == lpOperator && token.text == "[" {
+			if !quadratic {
+				return nil, 0, p.errorf(token, "quadratic terms are only supported in the objective")
+			}
+			p.position++
+			quadraticTerms, err := p.parseQuadratic(sign)
+			if err != nil {
+				return nil, 0, err
+			}
+			terms = append(terms, quadraticTerms...)
+			continue
+		}
+		coefficient := 1.0
+		if token.kind == lpNumber {
+			coefficient = token.value
+			p.position++
+			token, ok = p.peek()
+			if !ok || token.kind != lpIdentifier {
+				constant += sign * coefficient
+				continue
+			}
+		}
+		if token.kind != lpIdentifier {
+			return nil, 0, p.errorf(token, "unexpected %q in expression", token.text)
+		}
+		p.position++
+		p.addVar(token.text)
+		terms = append(terms, lpTerm{coefficient: sign * coefficient, name1: token.text})
+	}
+	return terms, constant, nil
+}
+
+// parseSign parses an optional sequence of signs. A sign is required between
+// terms, unless first is true.
+func (p *lpParser) parseSign(first bool) (float64, error) {
+	sign := 1.0
+	seen := false
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind != lpOperator || (token.text != "+" && token.text != "-") {
+			break
+		}
+		if token.text == "-" {
+			sign = -sign
+		}
+		seen = true
+		p.position++
+	}
+	if !seen && !first {
+		token, _ := p.peek()
+		return 0, p.errorf(token, "expected + or - before %q", token.text)
+	}
+	return sign, nil
+}
+
+func (p *lpParser) parseQuadratic(sign float64) ([]lpTerm, error) {
+	terms := make([]lpTerm, 0)
+	for {
+		token, ok := p.peek()
+		if !ok {
+			return nil, fmt.Errorf("lp: unterminated quadratic expression")
+		}
+		if token.kind == lpOperator && token.text == "]" {
+			p.position++
+			break
+		}
+		term, err := p.parseQuadraticTerm(len(terms) == 0)
+		if err != nil {
+			return nil, err
+		}
+		term.coefficient *= sign
+		terms = append(terms, term)
+	}
+	if token, ok := p.peek(); ok && token.kind == lpOperator && token.text == "/" {
+		p.position++
+		divisor, ok := p.next()
+		if !ok || divisor.kind != lpNumber || divisor.value == 0 {
+			return nil, p.errorf(token, "expected divisor after /")
+		}
+		for i := range terms {
+			terms[i].coefficient /= divisor.value
+		}
+	}
+	return terms, nil
+}
+
+// parseQuadraticTerm parses a term of the form "c x ^ 2" or "c x * y".
+func (p *lpParser) parseQuadraticTerm(first bool) (lpTerm, error) {
+	sign, err := p.parseSign(first)
+	if err != nil {
+		return lpTerm{}, err
+	}
+	term := lpTerm{coefficient: sign}
+	if token, ok := p.peek(); ok && token.kind == lpNumber {
+		term.coefficient *= token.value
+		p.position++
+	}
+	term.name1, err = p.expectIdentifier()
+	if err != nil {
+		return lpTerm{}, err
+	}
+	operator, ok := p.next()
+	if !ok {
+		return lpTerm{}, fmt.Errorf("lp: unterminated quadratic expression")
+	}
+	switch operator.text {
+	case "^":
+		exponent, ok := p.next()
+		if !ok || exponent.kind != lpNumber || exponent.value != 2 {
+			return lpTerm{}, p.errorf(operator, "only squares are supported")
+		}
+		term.name2 = term.name1
+	case "*":
+		term.name2, err = p.expectIdentifier()
+		if err != nil {
+			return lpTerm{}, err
+		}
+	default:
+		return lpTerm{}, p.errorf(operator, "expected ^ or * in quadratic term")
+	}
+	return term, nil
+}
+
+func (p *lpParser) expectIdentifier() (string, error) {
+	token, ok := p.next()
+	if !ok {
+		return "", fmt.Errorf("lp: unexpected end of input")
+	}
+	if token.kind != lpIdentifier {
+		return "", p.errorf(token, "expected a name, got %q", token.text)
+	}
+	p.addVar(token.text)
+	return token.text, nil
+}
+
+func (p *lpParser) parseConstraints() error {
+	for !p.atSectionEnd() {
+		name := p.skipLabel()
+		terms, constant, err := p.parseExpression(false)
+		if err != nil {
+			return err
+		}
+		operator, ok := p.next()
+		if !ok || operator.kind != lpOperator {
+			return fmt.Errorf("lp: constraint %q without comparison operator", name)
+		}
+		var sense Sense
+		switch operator.text {
+		case "<=":
+			sense = LessThanOrEqual
+		case ">=":
+			sense = GreaterThanOrEqual
+		case "=":
+			sense = Equal
+		default:
+			return p.errorf(operator, "expected comparison operator, got %q", operator.text)
+		}
+		rhs, err := p.parseSignedNumber()
+		if err != nil {
+			return err
+		}
+		p.constraints = append(p.constraints, lpConstraint{
+			name:  name,
+			terms: terms,
+			sense: sense,
+			rhs:   rhs - constant,
+		})
+	}
+	return nil
+}
+
+func (p *lpParser) parseSignedNumber() (float64, error) {
+	sign, err := p.parseSign(true)
+	if err != nil {
+		return 0, err
+	}
+	token, ok := p.next()
+	if !ok {
+		return 0, fmt.Errorf("lp: unexpected end of input")
+	}
+	if token.kind != lpNumber {
+		return 0, p.errorf(token, "expected a number, got %q", token.text)
+	}
+	return sign * token.value, nil
+}
+
+func (p *lpParser) bound(name string) *lpBound {
+	p.addVar(name)
+	bound, ok := p.bounds[name]
+	if !ok {
+		bound = &lpBound{lower: 0, upper: math.Inf(1)}
+		p.bounds[name] = bound
+	}
+	return bound
+}
+
+func (p *lpParser) parseBounds() error {
+	for !p.atSectionEnd() {
+		token, _ := p.peek()
+		var err error
+		if token.kind == lpIdentifier {
+			err = p.parseNameFirstBound()
+		} else {
+			err = p.parseValueFirstBound()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseNameFirstBound parses a bound of the form "x free" or "x <= 10".
+func (p *lpParser) parseNameFirstBound() error {
+	token, _ := p.next()
+	bound := p.bound(token.text)
+	next, ok := p.peek()
+	if ok && next.kind == lpIdentifier && strings.EqualFold(next.text, "free") {
+		p.position++
+		bound.lower, bound.upper = math.Inf(-1), math.Inf(1)
+		return nil
+	}
+	operator, ok := p.next()
+	if !ok || operator.kind != lpOperator {
+		return p.errorf(token, "incomplete bound for %q", token.text)
+	}
+	value, err := p.parseSignedNumber()
+	if err != nil {
+		return err
+	}
+	return applyLPBound(bound, operator, value, false)
+}
+
+// parseValueFirstBound parses a bound of the form "0 <= x" or "0 <= x <= 10".
+func (p *lpParser) parseValueFirstBound() error {
+	token, _ := p.peek()
+	value, err := p.parseSignedNumber()
+	if err != nil {
+		return err
+	}
+	operator, ok := p.next()
+	if !ok || operator.kind != lpOperator {
+		return p.errorf(token, "incomplete bound")
+	}
+	name, err := p.expectIdentifier()
+	if err != nil {
+		return err
+	}
+	bound := p.bound(name)
+	if err := applyLPBound(bound, operator, value, true); err != nil {
+		return err
+	}
+	next, ok := p.peek()
+	if !ok || next.kind != lpOperator || (next.text != "<=" && next.text != ">=") {
+		return nil
+	}
+	p.position++
+	value, err = p.parseSignedNumber()
+	if err != nil {
+		return err
+	}
+	return applyLPBound(bound, next, value, false)
+}
+
+// applyLPBound applies "name operator value" to bound, or "value operator
+// name" if reversed is true.
+func applyLPBound(bound *lpBound, operator lpToken, value float64, reversed bool) error {
+	text := operator.text
+	if reversed {
+		switch text {
+		case "<=":
+			text = ">="
+		case ">=":
+			text = "<="
+		}
+	}
+	switch text {
+	case "<=":
+		bound.upper = value
+	case ">=":
+		bound.lower = value
+	case "=":
+		bound.lower, bound.upper = value, value
+	default:
+		return fmt.Errorf("lp: line %d: invalid bound operator %q", operator.line, operator.text)
+	}
+	return nil
+}
+
+func (p *lpParser) parseVarSection(section string) error {
+	for !p.atSectionEnd() {
+		name, err := p.expectIdentifier()
+		if err != nil {
+			return err
+		}
+		p.varTypes[name] = section
+	}
+	return nil
+}
+
+func (p *lpParser) build() Model {
+	model := NewModel()
+	vars := make(map[string]Var, len(p.varOrder))
+	for _, name := range p.varOrder {
+		bound, ok := p.bounds[name]
+		if !ok {
+			bound = &lpBound{lower: 0, upper: math.Inf(1)}
+		}
+		var v Var
+		switch p.varTypes[name] {
+		case "binaries":
+			v = model.NewBool()
+		case "generals":
+			v = model.NewInt(intBound(math.Ceil(bound.lower)), intBound(math.Floor(bound.upper)))
+		default:
+			v = model.NewFloat(bound.lower, bound.upper)
+		}
+		v.SetName(name)
+		vars[name] = v
+	}
+
+	if p.maximize {
+		model.Objective().SetMaximize()
+	}
+	for _, t := range p.objective {
+		model.Objective().NewTerm(t.coefficient, vars[t.name1])
+	}
+	for _, t := range p.quadratic {
+		model.Objective().NewQuadraticTerm(t.coefficient, vars[t.name1], vars[t.name2])
+	}
+	for _, lc := range p.constraints {
+		c := model.NewConstraint(lc.sense, lc.rhs)
+		for _, t := range lc.terms {
+			c.NewTerm(t.coefficient, vars[t.name1])
+		}
+		if lc.name != "" {
+			c.SetName(lc.name)
+		}
+	}
+	return model
+}
+
+// intBound converts a bound of an integer var to an int64, mapping
+// infinity and values exceeding MaxIntBound in magnitude to the extremes of
+// int64, which leave the var unbounded.
+func intBound(value float64) int64 {
+	switch {
+	case value > MaxIntBound:
+		return math.MaxInt64
+	case value < -MaxIntBound:
+		return math.MinInt64
+	}
+	return int64(value)
+}
+
+// intLowerBound returns the lower bound of an int var with the float lower
+// bound value, rounded up.
+func intLowerBound(value float64) int64 {
+	return intBound(math.Ceil(value))
+}
+
+// intUpperBound returns the upper bound of an int var with the float upper
+// bound value, rounded down.
+func intUpperBound(value float64) int64 {
+	return intBound(math.Floor(value))
+}
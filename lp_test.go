@@ -0,0 +1,181 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+const testLP = `\ example from the CPLEX documentation
+Maximize
+ obj: x1 + 2 x2 + 3 x3 + x4
+Subject To
+ c1: - x1 + x2 + x3 + 10 x4 <= 20
+ c2: x1 - 3 x2 + x3 <= 30
+ c3: x2 - 3.5 x4 = 0
+Bounds
+ 0 <= x1 <= 40
+ 2 <= x4 <= 3
+ x5 free
+Generals
+ x4
+Binaries
+ x6
+End
+`
+
+func TestReadLP(t *testing.T) {
+	model, err := mip.ReadLP(strings.NewReader(testLP))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vars := model.Vars()
+	if len(vars) != 6 {
+		t.Fatalf("got %d vars, want 6", len(vars))
+	}
+	names := []string{"x1", "x2", "x3", "x4", "x5", "x6"}
+	for i, name := range names {
+		if vars[i].Name() != name {
+			t.Errorf("var %d name = %v, want %v", i, vars[i].Name(), name)
+		}
+	}
+	if vars[0].UpperBound() != 40 || vars[1].UpperBound() != math.Inf(1) {
+		t.Errorf("unexpected bounds %v, %v", vars[0], vars[1])
+	}
+	if !vars[3].IsInt() || vars[3].LowerBound() != 2 || vars[3].UpperBound() != 3 {
+		t.Errorf("expected x4 to be int in [2, 3]")
+	}
+	if !math.IsInf(vars[4].LowerBound(), -1) {
+		t.Errorf("expected x5 to be free")
+	}
+	if !vars[5].IsBool() {
+		t.Errorf("expected x6 to be bool")
+	}
+	if !model.Objective().IsMaximize() {
+		t.Errorf("expected maximization")
+	}
+
+	constraints := model.Constraints()
+	if len(constraints) != 3 {
+		t.Fatalf("got %d constraints, want 3", len(constraints))
+	}
+	if constraints[2].Name() != "c3" || constraints[2].Sense() != mip.Equal {
+		t.Errorf("unexpected constraint %v", constraints[2])
+	}
+	if term, _ := constraints[0].Term(vars[3]); term.Coefficient() != 10 {
+		t.Errorf("coefficient = %v, want 10", term.Coefficient())
+	}
+}
+
+func TestReadLPErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		lp   string
+	}{
+		{name: "objective constant", lp: "min\n obj: x + 3\nEnd\n"},
+		{name: "missing rhs", lp: "min\n obj: x\nst\n c1: x <=\nEnd\n"},
+		{name: "missing sign", lp: "min\n obj: x y\nEnd\n"},
+		{name: "semi-continuous", lp: "min\n obj: x\nsemi-continuous\n x\nEnd\n"},
+		{name: "unexpected character", lp: "min\n obj: x ?? y\nEnd\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := mip.ReadLP(strings.NewReader(tt.lp)); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestWriteLPRoundTrip(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(-1.0, 10.0)
+	x.SetName("x")
+	y := model.NewInt(0, 5)
+	y.SetName("y")
+	z := model.NewBool()
+	z.SetName("z")
+	f := model.NewFloat(math.Inf(-1), math.Inf(1))
+	f.SetName("f")
+	model.Objective().NewTerm(1.0, x)
+	model.Objective().NewTerm(-2.5, y)
+	model.Objective().NewQuadraticTerm(3.0, x, z)
+	model.Objective().NewQuadraticTerm(1.5, f, f)
+	c := model.NewConstraint(mip.GreaterThanOrEqual, -3.0)
+	c.NewTerm(1.0, x)
+	c.NewTerm(-1.0, z)
+	c.SetName("cover")
+
+	var buffer bytes.Buffer
+	if err := mip.WriteLP(&buffer, model); err != nil {
+		t.Fatal(err)
+	}
+	written := buffer.String()
+
+	readModel, err := mip.ReadLP(strings.NewReader(written))
+	if err != nil {
+		t.Fatalf("%v\n%s", err, written)
+	}
+	if got, want := mip.ModelHash(readModel), mip.ModelHash(model); got != want {
+		t.Errorf("round trip changed model:\n%v\nwant\n%v\nlp:\n%s",
+			readModel,
+			model,
+			written,
+		)
+	}
+}
+
+func TestWriteLPFixed(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0.0, 10.0)
+	x.SetName("x")
+	x.Fix(2.5)
+	b := model.NewBool()
+	b.SetName("b")
+	b.Fix(1)
+
+	var buffer bytes.Buffer
+	if err := mip.WriteLP(&buffer, model); err != nil {
+		t.Fatal(err)
+	}
+	for _, bound := range []string{" 2.5 <= x <= 2.5\n", " 1 <= b <= 1\n"} {
+		if !strings.Contains(buffer.String(), bound) {
+			t.Errorf("missing bound %q in\n%s", bound, buffer.String())
+		}
+	}
+}
+
+func TestWriteLPDuplicateNames(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 5)
+	x.SetName("x y")
+	y := model.NewFloat(1, 7)
+	y.SetName("x_y")
+	named := model.NewConstraint(mip.LessThanOrEqual, 10)
+	named.SetName("c1")
+	named.NewTerm(1, x)
+	model.NewConstraint(mip.GreaterThanOrEqual, 2).NewTerm(1, y)
+
+	var buffer bytes.Buffer
+	if err := mip.WriteLP(&buffer, model); err != nil {
+		t.Fatal(err)
+	}
+	read, err := mip.ReadLP(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vars := read.Vars()
+	if len(vars) != 2 || vars[0].UpperBound() != 5 || vars[1].LowerBound() != 1 || vars[1].UpperBound() != 7 {
+		t.Fatalf("got vars %v, want two vars with their bounds", vars)
+	}
+	constraints := read.Constraints()
+	if len(constraints) != 2 || constraints[0].Name() == constraints[1].Name() {
+		t.Errorf("got constraints %v, want two with distinct names", constraints)
+	}
+}
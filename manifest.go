@@ -0,0 +1,175 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"runtime/debug"
+	"sort"
+	"strconv"
+)
+
+// modulePath is the import path of this module, used to look up the version
+// of the package in the build information.
+const modulePath = "github.com/nextmv-io/go-mip"
+
+// ErrNoManifest is returned by SolveWithManifest for solvers which have not
+// been created by NewSolver, their provider and model are not known.
+var ErrNoManifest = errors.New("no manifest for solver")
+
+// Manifest records everything needed to reproduce a Solver.Solve invocation.
+// A manifest is emitted alongside a solution by SolveWithManifest and stored
+// with the results, so the run can be repeated later with the exact same
+// inputs.
+type Manifest struct {
+	// ModelHash is the hash of the model, see ModelHash.
+	ModelHash string `json:"model_hash"`
+	// Options used to solve the model.
+	Options SolveOptions `json:"options"`
+	// PackageVersion is the version of this package as recorded in the build
+	// information of the binary, empty if it can not be determined.
+	PackageVersion string `json:"package_version,omitempty"`
+	// Provider of the solver that solved the model.
+	Provider SolverProvider `json:"provider"`
+	// ProviderVersion is the version of the back-end solver as registered
+	// with RegisterSolverProviderVersion, empty if none has been registered.
+	ProviderVersion string `json:"provider_version,omitempty"`
+	// Seed is the random seed handed to the solver.
+	Seed int `json:"seed"`
+}
+
+// NewManifest creates a manifest for solving model with the given provider
+// and options.
+func NewManifest(
+	model Model,
+	provider SolverProvider,
+	options SolveOptions,
+) Manifest {
+	return Manifest{
+		ModelHash:       ModelHash(model),
+		Options:         options,
+		PackageVersion:  packageVersion(),
+		Provider:        provider,
+		ProviderVersion: SolverProviderVersion(provider),
+		Seed:            options.Seed,
+	}
+}
+
+// SolveWithManifest solves with solver like Solver.Solve and returns the
+// manifest of the solve alongside the solution. The manifest is created
+// before solving and records the options with the defaults of the provider
+// applied, see SetDefaultSolveOptions, so it is returned even if the solve
+// fails. Returns ErrNoManifest if solver has not been created by NewSolver.
+//
+//	solution, manifest, err := mip.SolveWithManifest(solver, options)
+func SolveWithManifest(solver Solver, options SolveOptions) (Solution, Manifest, error) {
+	s, ok := solver.(*defaultsSolver)
+	if !ok {
+		return nil, Manifest{}, ErrNoManifest
+	}
+	options = withProviderDefaults(s, options)
+	manifest := NewManifest(s.model, s.provider, options)
+	solution, err := s.solver.Solve(options)
+	return solution, manifest, err
+}
+
+// ModelHash returns a hex encoded SHA-256 hash of the model. The hash covers
+// the variables (type, bounds, fixed values and names), the objective (sense,
+// linear and quadratic terms) and the constraints (sense, right-hand side,
+// terms and names). Two models that are structurally identical have the same
+// hash, independent of the order in which terms have been added. A copy of a
+// model has the same hash as the model itself.
+func ModelHash(model Model) string {
+	h := sha256.New()
+
+	vars := model.Vars()
+	for _, v := range vars {
+		writeHash(h, "v", varTypeCode(v), v.LowerBound(), v.UpperBound(), v.Name())
+		if value, ok := v.FixedValue(); ok {
+			writeHash(h, "x", value)
+		}
+	}
+
+	objective := model.Objective()
+	writeHash(h, "o", objective.IsMaximize())
+	for _, t := range sortedTerms(objective.Terms()) {
+		writeHash(h, "t", t.Var().Index(), t.Coefficient())
+	}
+	for _, t := range sortedQuadraticTerms(objective.QuadraticTerms()) {
+		writeHash(h, "q", t.Var1().Index(), t.Var2().Index(), t.Coefficient())
+	}
+
+	for _, c := range model.Constraints() {
+		writeHash(h, "c", int64(c.Sense()), c.RightHandSide(), c.Name())
+		for _, t := range sortedTerms(c.Terms()) {
+			writeHash(h, "t", t.Var().Index(), t.Coefficient())
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeHash(h hash.Hash, values ...any) {
+	for _, value := range values {
+		switch v := value.(type) {
+		case float64:
+			fmt.Fprint(h, strconv.FormatFloat(v, 'g', -1, 64))
+		case string:
+			fmt.Fprint(h, strconv.Quote(v))
+		default:
+			fmt.Fprint(h, v)
+		}
+		fmt.Fprint(h, ";")
+	}
+	fmt.Fprint(h, "\n")
+}
+
+func varTypeCode(v Var) string {
+	switch {
+	case v.IsBool():
+		return "B"
+	case v.IsInt():
+		return "I"
+	default:
+		return "F"
+	}
+}
+
+func sortedTerms(terms Terms) Terms {
+	sort.SliceStable(terms, func(i, j int) bool {
+		return terms[i].Var().Index() < terms[j].Var().Index()
+	})
+	return terms
+}
+
+func sortedQuadraticTerms(terms QuadraticTerms) QuadraticTerms {
+	sort.SliceStable(terms, func(i, j int) bool {
+		return terms[i].Var1().Index() < terms[j].Var1().Index() ||
+			(terms[i].Var1().Index() == terms[j].Var1().Index() &&
+				terms[i].Var2().Index() < terms[j].Var2().Index())
+	})
+	return terms
+}
+
+func packageVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return ""
+}
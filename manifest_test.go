@@ -0,0 +1,92 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestModelHash(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0.0, 10.0)
+	y := model.NewInt(0, 5)
+	model.Objective().SetMaximize()
+	model.Objective().NewTerm(1.0, x)
+	model.Objective().NewTerm(2.0, y)
+	c := model.NewConstraint(mip.LessThanOrEqual, 8.0)
+	c.NewTerm(1.0, x)
+	c.NewTerm(1.0, y)
+	c.SetName("capacity")
+
+	hash := mip.ModelHash(model)
+
+	if got := mip.ModelHash(model.Copy()); got != hash {
+		t.Errorf("hash of copy = %v, want %v", got, hash)
+	}
+
+	c.NewTerm(1.0, y)
+	if got := mip.ModelHash(model); got == hash {
+		t.Errorf("hash did not change after adding a term")
+	}
+}
+
+func TestNewManifest(t *testing.T) {
+	model := mip.NewModel()
+	model.NewBool()
+
+	options := mip.SolveOptions{Seed: 42}
+	manifest := mip.NewManifest(model, "highs", options)
+
+	if manifest.ModelHash != mip.ModelHash(model) {
+		t.Errorf("manifest model hash = %v, want %v",
+			manifest.ModelHash,
+			mip.ModelHash(model),
+		)
+	}
+	if manifest.Seed != 42 {
+		t.Errorf("manifest seed = %v, want 42", manifest.Seed)
+	}
+	if manifest.Provider != "highs" {
+		t.Errorf("manifest provider = %v, want highs", manifest.Provider)
+	}
+}
+
+func TestSolveWithManifest(t *testing.T) {
+	provider := mip.SolverProvider("test-manifest")
+	backend := &optionsSolver{}
+	mip.RegisterSolverProvider(provider, func(mip.Model) (mip.Solver, error) {
+		return backend, nil
+	})
+	mip.RegisterSolverProviderVersion(provider, "1.2.3")
+	mip.SetDefaultSolveOptions(provider, mip.SolveOptions{Duration: time.Minute})
+	model := mip.NewModel()
+	model.NewBool()
+	solver, err := mip.NewSolver(provider, model)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	solution, manifest, err := mip.SolveWithManifest(solver, mip.SolveOptions{Seed: 7})
+	if err != nil || solution == nil {
+		t.Fatalf("got solution %v and error %v", solution, err)
+	}
+	if manifest.Provider != provider || manifest.ProviderVersion != "1.2.3" {
+		t.Errorf("got provider %q version %q, want %q version 1.2.3",
+			manifest.Provider, manifest.ProviderVersion, provider)
+	}
+	if manifest.ModelHash != mip.ModelHash(model) || manifest.Seed != 7 {
+		t.Errorf("got model hash %v and seed %v", manifest.ModelHash, manifest.Seed)
+	}
+	if manifest.Options.Duration != time.Minute || backend.options[0].Duration != time.Minute {
+		t.Errorf("manifest options %+v are not the solve options %+v", manifest.Options, backend.options[0])
+	}
+
+	_, _, err = mip.SolveWithManifest(backend, mip.SolveOptions{})
+	if !errors.Is(err, mip.ErrNoManifest) {
+		t.Errorf("got error %v, want %v", err, mip.ErrNoManifest)
+	}
+}
@@ -0,0 +1,238 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Matrix is a model in the array form most back-ends load their native
+// model from: the constraint matrix in compressed sparse row form, the
+// quadratic objective in coordinate form and dense arrays for the rest.
+// Back-ends build it with BuildMatrix instead of translating the model term
+// by term.
+type Matrix struct {
+	// RowStarts are the positions of the first non-zero of each row in
+	// Columns and Values, followed by the number of non-zeros.
+	RowStarts []int
+	// Columns are the var indices of the non-zeros, ordered within a row.
+	Columns []int32
+	// Values are the coefficients of the non-zeros. Terms of the same var in
+	// a constraint are summed, zero sums are left out.
+	Values []float64
+	// Senses of the constraints.
+	Senses []Sense
+	// RightHandSides of the constraints.
+	RightHandSides []float64
+	// LowerBounds of the vars, the fixed value for fixed vars.
+	LowerBounds []float64
+	// UpperBounds of the vars, the fixed value for fixed vars.
+	UpperBounds []float64
+	// Types of the vars.
+	Types []VarType
+	// Objective are the linear objective coefficients by var index.
+	Objective []float64
+	// Maximize is true if the objective is maximized.
+	Maximize bool
+	// QuadraticRows are the indices of the first vars of the quadratic
+	// objective terms, which are at most the indices of the second vars in
+	// QuadraticColumns. The terms are ordered by both indices.
+	QuadraticRows []int32
+	// QuadraticColumns are the indices of the second vars of the quadratic
+	// objective terms.
+	QuadraticColumns []int32
+	// QuadraticValues are the coefficients of the quadratic objective terms.
+	QuadraticValues []float64
+}
+
+// NonZeros returns the number of non-zeros of the constraint matrix.
+func (m *Matrix) NonZeros() int {
+	return m.RowStarts[len(m.RowStarts)-1]
+}
+
+// BuildMatrix returns model in array form, see Matrix. The rows of the
+// constraint matrix are built in parallel by workers goroutines, each
+// assembling a contiguous range of rows, and then copied into the result in
+// parallel. Translating models with millions of rows single-threaded can
+// take longer than solving them. A non-positive number of workers uses
+// runtime.GOMAXPROCS workers. The result does not depend on the number of
+// workers. The scratch memory of a worker grows with the longest row it
+// assembles. The model must not be changed while the matrix is built.
+// Returns an error wrapping ErrInvalidModel if a constraint or the objective
+// has a var which is not a var of model.
+func BuildMatrix(model Model, workers int) (*Matrix, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	vars := model.Vars()
+	constraints := model.Constraints()
+	matrix := &Matrix{
+		RowStarts:      make([]int, len(constraints)+1),
+		Senses:         make([]Sense, len(constraints)),
+		RightHandSides: make([]float64, len(constraints)),
+		LowerBounds:    make([]float64, len(vars)),
+		UpperBounds:    make([]float64, len(vars)),
+		Types:          make([]VarType, len(vars)),
+		Objective:      make([]float64, len(vars)),
+		Maximize:       model.Objective().IsMaximize(),
+	}
+	for i, v := range vars {
+		matrix.LowerBounds[i], matrix.UpperBounds[i] = bounds(v)
+		matrix.Types[i] = v.Type()
+	}
+	for _, t := range model.Objective().Terms() {
+		if t.Var().Index() >= len(vars) {
+			return nil, fmt.Errorf("%w: objective has var %v of another model", ErrInvalidModel, t.Var())
+		}
+		matrix.Objective[t.Var().Index()] = t.Coefficient()
+	}
+	quadraticTerms := model.Objective().QuadraticTerms()
+	for _, t := range quadraticTerms {
+		if max(t.Var1().Index(), t.Var2().Index()) >= len(vars) {
+			return nil, fmt.Errorf(
+				"%w: objective has quadratic term of %v and %v of another model",
+				ErrInvalidModel, t.Var1(), t.Var2(),
+			)
+		}
+	}
+	addQuadraticObjective(matrix, quadraticTerms)
+
+	workers = max(1, min(workers, len(constraints)))
+	shards := make([]matrixShard, workers)
+	var wg sync.WaitGroup
+	for w := range shards {
+		shards[w].first = w * len(constraints) / workers
+		shards[w].last = (w + 1) * len(constraints) / workers
+		wg.Add(1)
+		go func(shard *matrixShard) {
+			defer wg.Done()
+			shard.build(constraints, len(vars), matrix)
+		}(&shards[w])
+	}
+	wg.Wait()
+	for _, shard := range shards {
+		if shard.err != nil {
+			return nil, shard.err
+		}
+	}
+
+	// The non-zeros of a shard start after the non-zeros of the shards
+	// before it.
+	offsets := make([]int, workers)
+	total := 0
+	for w, shard := range shards {
+		offsets[w] = total
+		total += len(shard.values)
+	}
+	matrix.Columns = make([]int32, total)
+	matrix.Values = make([]float64, total)
+	matrix.RowStarts[len(constraints)] = total
+	for w := range shards {
+		wg.Add(1)
+		go func(shard *matrixShard, offset int) {
+			defer wg.Done()
+			copy(matrix.Columns[offset:], shard.columns)
+			copy(matrix.Values[offset:], shard.values)
+			for row := shard.first; row < shard.last; row++ {
+				matrix.RowStarts[row] += offset
+			}
+		}(&shards[w], offsets[w])
+	}
+	wg.Wait()
+	return matrix, nil
+}
+
+// matrixShard assembles the rows [first, last) of a constraint matrix.
+type matrixShard struct {
+	first   int
+	last    int
+	columns []int32
+	values  []float64
+	// err is the error of the first row which could not be assembled.
+	err error
+}
+
+// build assembles the rows of the invoking shard, setting their row starts
+// relative to the shard, senses and right-hand sides in matrix.
+func (s *matrixShard) build(constraints Constraints, vars int, matrix *Matrix) {
+	// Terms of the current row, merged by sorting them by var index. The
+	// sort is stable, so terms of the same var are summed in the order they
+	// were added.
+	var terms []matrixTerm
+	for row := s.first; row < s.last; row++ {
+		c := constraints[row]
+		matrix.Senses[row] = c.Sense()
+		matrix.RightHandSides[row] = c.RightHandSide()
+		matrix.RowStarts[row] = len(s.values)
+
+		terms = terms[:0]
+		eachTerm(c, func(index int, coefficient float64) {
+			terms = append(terms, matrixTerm{index: index, coefficient: coefficient})
+		})
+		sort.SliceStable(terms, func(i, j int) bool {
+			return terms[i].index < terms[j].index
+		})
+		if len(terms) > 0 && (terms[0].index < 0 || terms[len(terms)-1].index >= vars) {
+			s.err = fmt.Errorf(
+				"%w: constraint %s has a var of another model",
+				ErrInvalidModel, constraintLabel(c, row),
+			)
+			return
+		}
+		for i := 0; i < len(terms); {
+			index, coefficient := terms[i].index, 0.0
+			for ; i < len(terms) && terms[i].index == index; i++ {
+				coefficient += terms[i].coefficient
+			}
+			if coefficient != 0 {
+				s.columns = append(s.columns, int32(index))
+				s.values = append(s.values, coefficient)
+			}
+		}
+	}
+}
+
+// matrixTerm is a term of a row of a constraint matrix.
+type matrixTerm struct {
+	index       int
+	coefficient float64
+}
+
+// eachTerm calls f for each term added to c, including repeated terms of the
+// same var, without allocating the terms.
+func eachTerm(c Constraint, f func(index int, coefficient float64)) {
+	impl, ok := c.(*constraint)
+	if !ok {
+		for _, t := range c.Terms() {
+			f(t.Var().Index(), t.Coefficient())
+		}
+		return
+	}
+	for _, t := range impl.terms {
+		f(t.Var().Index(), t.Coefficient())
+	}
+	for _, t := range impl.compact {
+		f(int(t.index), float64(t.coefficient))
+	}
+}
+
+// addQuadraticObjective sets the quadratic objective of matrix to terms.
+func addQuadraticObjective(matrix *Matrix, terms QuadraticTerms) {
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Var1().Index() != terms[j].Var1().Index() {
+			return terms[i].Var1().Index() < terms[j].Var1().Index()
+		}
+		return terms[i].Var2().Index() < terms[j].Var2().Index()
+	})
+	matrix.QuadraticRows = make([]int32, len(terms))
+	matrix.QuadraticColumns = make([]int32, len(terms))
+	matrix.QuadraticValues = make([]float64, len(terms))
+	for i, t := range terms {
+		matrix.QuadraticRows[i] = int32(t.Var1().Index())
+		matrix.QuadraticColumns[i] = int32(t.Var2().Index())
+		matrix.QuadraticValues[i] = t.Coefficient()
+	}
+}
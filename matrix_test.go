@@ -0,0 +1,128 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestBuildMatrix(t *testing.T) {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	x := model.NewFloat(0, 10)
+	y := model.NewInt(-2, 5)
+	z := model.NewBool()
+	z.Fix(1)
+
+	c0 := model.NewConstraint(mip.LessThanOrEqual, 8)
+	c0.NewTerm(2, z)
+	c0.NewTerm(1, x)
+	c0.NewTerm(3, z)
+	c0.NewTerm(1, y)
+	c0.NewTerm(-1, y)
+	model.NewConstraint(mip.Equal, 0)
+	c2 := model.NewConstraint(mip.GreaterThanOrEqual, -1)
+	c2.NewTerm(4, y)
+	model.Objective().NewTerm(3, x)
+	model.Objective().NewQuadraticTerm(2, z, x)
+	model.Objective().NewQuadraticTerm(1, x, x)
+
+	want := &mip.Matrix{
+		RowStarts:        []int{0, 2, 2, 3},
+		Columns:          []int32{0, 2, 1},
+		Values:           []float64{1, 5, 4},
+		Senses:           []mip.Sense{mip.LessThanOrEqual, mip.Equal, mip.GreaterThanOrEqual},
+		RightHandSides:   []float64{8, 0, -1},
+		LowerBounds:      []float64{0, -2, 1},
+		UpperBounds:      []float64{10, 5, 1},
+		Types:            []mip.VarType{mip.Continuous, mip.Integer, mip.Binary},
+		Objective:        []float64{3, 0, 0},
+		Maximize:         true,
+		QuadraticRows:    []int32{0, 0},
+		QuadraticColumns: []int32{0, 2},
+		QuadraticValues:  []float64{1, 2},
+	}
+	for _, workers := range []int{0, 1, 2, 8} {
+		if got := buildMatrix(t, model, workers); !reflect.DeepEqual(got, want) {
+			t.Errorf("got matrix %+v with %d workers, want %+v", got, workers, want)
+		}
+	}
+	if got := buildMatrix(t, model, 2).NonZeros(); got != 3 {
+		t.Errorf("got %d non-zeros, want 3", got)
+	}
+}
+
+func TestBuildMatrixWorkers(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	model := mip.NewModel()
+	vars := make(mip.Vars, 50)
+	for i := range vars {
+		vars[i] = model.NewFloat(0, 1)
+	}
+	for i := 0; i < 1000; i++ {
+		c := model.NewConstraint(mip.LessThanOrEqual, float64(i))
+		for j := 0; j < 10; j++ {
+			c.NewTerm(float64(r.Intn(5)-2), vars[r.Intn(len(vars))])
+		}
+	}
+
+	sequential := buildMatrix(t, model, 1)
+	if parallel := buildMatrix(t, model, 7); !reflect.DeepEqual(parallel, sequential) {
+		t.Error("got different matrices with 1 and 7 workers")
+	}
+	for i, c := range model.Constraints() {
+		if got := sequential.RowStarts[i+1] - sequential.RowStarts[i]; got != len(c.Terms()) {
+			t.Errorf("got %d non-zeros in row %d, want %d", got, i, len(c.Terms()))
+		}
+	}
+}
+
+func TestBuildMatrixForeignVar(t *testing.T) {
+	model := mip.NewModel()
+	other := mip.NewModel()
+	other.NewFloat(0, 1)
+	foreign := other.NewFloat(0, 1)
+	model.NewConstraint(mip.LessThanOrEqual, 1).NewTerm(1, foreign)
+	if _, err := mip.BuildMatrix(model, 2); !errors.Is(err, mip.ErrInvalidModel) {
+		t.Errorf("got error %v, want %v", err, mip.ErrInvalidModel)
+	}
+}
+
+func buildMatrix(t *testing.T, model mip.Model, workers int) *mip.Matrix {
+	t.Helper()
+	matrix, err := mip.BuildMatrix(model, workers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matrix
+}
+
+func BenchmarkBuildMatrix(b *testing.B) {
+	r := rand.New(rand.NewSource(7))
+	model := mip.NewModel()
+	vars := make(mip.Vars, 10_000)
+	for i := range vars {
+		vars[i] = model.NewFloat(0, 1)
+	}
+	for i := 0; i < 100_000; i++ {
+		c := model.NewConstraint(mip.LessThanOrEqual, 1)
+		for j := 0; j < 10; j++ {
+			c.NewTerm(1, vars[r.Intn(len(vars))])
+		}
+	}
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("Workers%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := mip.BuildMatrix(model, workers); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// ByteSize is an amount of memory in bytes.
+type ByteSize int64
+
+// Units of a ByteSize.
+const (
+	Byte     ByteSize = 1
+	KibiByte          = 1024 * Byte
+	MebiByte          = 1024 * KibiByte
+	GibiByte          = 1024 * MebiByte
+)
+
+// String returns the invoking size in the largest binary unit in which it is
+// at least 1, e.g. 1.5 GiB.
+func (b ByteSize) String() string {
+	switch {
+	case b >= GibiByte || b <= -GibiByte:
+		return fmt.Sprintf("%.1f GiB", float64(b)/float64(GibiByte))
+	case b >= MebiByte || b <= -MebiByte:
+		return fmt.Sprintf("%.1f MiB", float64(b)/float64(MebiByte))
+	case b >= KibiByte || b <= -KibiByte:
+		return fmt.Sprintf("%.1f KiB", float64(b)/float64(KibiByte))
+	}
+	return fmt.Sprintf("%d B", int64(b))
+}
+
+// MemoryProfile describes the memory a back-end needs to solve a model as a
+// linear function of its size, see EstimateMemory.
+type MemoryProfile struct {
+	// Base is the memory independent of the model, e.g. of the environment
+	// of the back-end.
+	Base ByteSize `json:"base"`
+	// PerRow is the memory per constraint.
+	PerRow ByteSize `json:"per_row"`
+	// PerColumn is the memory per var.
+	PerColumn ByteSize `json:"per_column"`
+	// PerNonZero is the memory per non-zero coefficient of the constraints.
+	PerNonZero ByteSize `json:"per_non_zero"`
+}
+
+// DefaultMemoryProfile is used by EstimateMemory for providers which have
+// not registered a profile. It is a conservative rule of thumb for a
+// branch-and-bound back-end which holds the model in row and column major
+// form, a presolved copy and the LP factorization.
+var DefaultMemoryProfile = MemoryProfile{
+	Base:       64 * MebiByte,
+	PerRow:     256 * Byte,
+	PerColumn:  256 * Byte,
+	PerNonZero: 64 * Byte,
+}
+
+// memoryProfiles are the profiles registered with RegisterMemoryProfile.
+var memoryProfiles = struct {
+	sync.RWMutex
+	profiles map[SolverProvider]MemoryProfile
+}{
+	profiles: map[SolverProvider]MemoryProfile{},
+}
+
+// RegisterMemoryProfile sets the memory profile of provider used by
+// EstimateMemory. Back-ends register measured profiles from an init function
+// next to RegisterSolverProvider. Registering a profile again replaces it.
+func RegisterMemoryProfile(provider SolverProvider, profile MemoryProfile) {
+	memoryProfiles.Lock()
+	defer memoryProfiles.Unlock()
+	memoryProfiles.profiles[provider] = profile
+}
+
+// EstimateMemory returns an estimate of the memory provider needs to solve
+// a model with rows constraints, cols vars and nnz non-zeros, using the
+// profile registered with RegisterMemoryProfile or DefaultMemoryProfile. It
+// is meant for capacity planning, e.g. to choose the machine to solve on:
+// the memory of a solve also grows with the search tree, which depends on
+// the model and the solve options.
+//
+//	need := mip.EstimateMemory(len(model.Constraints()), len(model.Vars()), nonZeros, "highs")
+//	need += model.MemoryFootprint()
+func EstimateMemory(rows, cols, nnz int, provider SolverProvider) ByteSize {
+	memoryProfiles.RLock()
+	profile, ok := memoryProfiles.profiles[provider]
+	memoryProfiles.RUnlock()
+	if !ok {
+		profile = DefaultMemoryProfile
+	}
+	return profile.Base +
+		ByteSize(rows)*profile.PerRow +
+		ByteSize(cols)*profile.PerColumn +
+		ByteSize(nnz)*profile.PerNonZero
+}
+
+// Sizes of the parts of a model used by MemoryFootprint. An interface value
+// in a slice or map takes two words, the value it points to is allocated
+// separately.
+const (
+	interfaceSize = ByteSize(unsafe.Sizeof(Var(nil)))
+	stringSize    = ByteSize(unsafe.Sizeof(""))
+	// mapEntryOverhead approximates the memory of a map entry besides its
+	// key and value, e.g. buckets and their load factor.
+	mapEntryOverhead = 16 * Byte
+)
+
+func (m *model) MemoryFootprint() ByteSize {
+	size := ByteSize(unsafe.Sizeof(*m))
+	for _, v := range m.vars {
+		size += interfaceSize + varSize(v)
+	}
+	termSize := interfaceSize + ByteSize(unsafe.Sizeof(term{}))
+	for _, c := range m.constraints {
+		size += interfaceSize + ByteSize(unsafe.Sizeof(constraint{}))
+		if !c.(*constraint).shared {
+			size += ByteSize(cap(c.(*constraint).terms)) * termSize
+		}
+		size += ByteSize(cap(c.(*constraint).compact)) * ByteSize(unsafe.Sizeof(compactTerm{}))
+	}
+	o := m.objective.(*objective)
+	size += ByteSize(unsafe.Sizeof(*o)) +
+		ByteSize(len(o.terms))*termSize +
+		ByteSize(len(o.quadraticTerms))*(interfaceSize+ByteSize(unsafe.Sizeof(quadraticTerm{})))
+
+	entry := 2*interfaceSize + mapEntryOverhead
+	size += ByteSize(cap(m.varNames.values)+cap(m.varGroups.values)) * stringSize
+	for _, name := range m.varNames.values {
+		size += ByteSize(len(name))
+	}
+	for _, c := range m.constraints {
+		size += ByteSize(len(c.(*constraint).name))
+	}
+	for group := range m.varGroups.interned {
+		size += entry + ByteSize(len(group))
+	}
+	size += ByteSize(len(m.fixed)+len(m.varTypes)+len(m.soft)) * entry
+	for _, values := range m.attributes {
+		size += entry + ByteSize(len(values))*(stringSize+interfaceSize+mapEntryOverhead)
+	}
+	for _, p := range m.params {
+		size += entry + ByteSize(unsafe.Sizeof(*p)) +
+			ByteSize(len(p.rightHandSides))*interfaceSize +
+			ByteSize(len(p.terms))*ByteSize(unsafe.Sizeof(paramTerm{}))
+	}
+	return size
+}
+
+// varSize returns the size of the value v points to.
+func varSize(v Var) ByteSize {
+	switch v.(type) {
+	case *boolVariable:
+		return ByteSize(unsafe.Sizeof(boolVariable{}))
+	case *intVariable:
+		return ByteSize(unsafe.Sizeof(intVariable{}))
+	}
+	return ByteSize(unsafe.Sizeof(floatVariable{}))
+}
@@ -0,0 +1,80 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestByteSizeString(t *testing.T) {
+	tests := []struct {
+		size mip.ByteSize
+		want string
+	}{
+		{512, "512 B"},
+		{1536, "1.5 KiB"},
+		{3 * mip.MebiByte, "3.0 MiB"},
+		{5*mip.GibiByte + 512*mip.MebiByte, "5.5 GiB"},
+	}
+	for _, test := range tests {
+		if got := test.size.String(); got != test.want {
+			t.Errorf("got %q for %d, want %q", got, int64(test.size), test.want)
+		}
+	}
+}
+
+func TestEstimateMemory(t *testing.T) {
+	got := mip.EstimateMemory(10, 20, 100, "unregistered")
+	want := mip.DefaultMemoryProfile.Base +
+		10*mip.DefaultMemoryProfile.PerRow +
+		20*mip.DefaultMemoryProfile.PerColumn +
+		100*mip.DefaultMemoryProfile.PerNonZero
+	if got != want {
+		t.Errorf("got %v with default profile, want %v", got, want)
+	}
+
+	mip.RegisterMemoryProfile("test-memory", mip.MemoryProfile{
+		Base:       mip.MebiByte,
+		PerRow:     100,
+		PerColumn:  10,
+		PerNonZero: 1,
+	})
+	if got := mip.EstimateMemory(10, 20, 100, "test-memory"); got != mip.MebiByte+1000+200+100 {
+		t.Errorf("got %v with registered profile, want %v", got, mip.MebiByte+1300)
+	}
+}
+
+func TestMemoryFootprint(t *testing.T) {
+	model := mip.NewModel()
+	empty := model.MemoryFootprint()
+	if empty <= 0 {
+		t.Fatalf("got footprint %v of empty model, want positive", empty)
+	}
+
+	vars := make(mip.Vars, 100)
+	for i := range vars {
+		vars[i] = model.NewFloat(0, 1)
+	}
+	withVars := model.MemoryFootprint()
+	if withVars <= empty {
+		t.Errorf("got footprint %v with vars, want more than %v", withVars, empty)
+	}
+
+	for i := 0; i < 10; i++ {
+		c := model.NewConstraint(mip.LessThanOrEqual, 1)
+		c.SetNamef("c%d", i)
+		for _, v := range vars {
+			c.NewTerm(1, v)
+		}
+	}
+	withTerms := model.MemoryFootprint()
+	// At least a coefficient and an interface value per term.
+	if withTerms-withVars < 1000*24 {
+		t.Errorf("got footprint %v with 1000 terms, want at least 24 B per term more than %v", withTerms, withVars)
+	}
+	if copied := model.Copy().MemoryFootprint(); copied < withTerms/2 || copied > 2*withTerms {
+		t.Errorf("got footprint %v of copy, want about %v", copied, withTerms)
+	}
+}
@@ -0,0 +1,209 @@
+// © 2019-present nextmv.io inc
+
+// Package metrics exposes live metrics of in-flight solves in the Prometheus
+// text exposition format, so it can be scraped without depending on a
+// Prometheus client library.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// gauge is a metric reported per in-flight solve.
+type gauge struct {
+	name  string
+	help  string
+	value func(progress mip.Progress) float64
+}
+
+var gauges = []gauge{
+	{
+		name:  "mip_solve_gap",
+		help:  "Relative gap between incumbent and best bound of in-flight solves.",
+		value: mip.Progress.Gap,
+	},
+	{
+		name: "mip_solve_incumbent",
+		help: "Objective value of the incumbent of in-flight solves.",
+		value: func(progress mip.Progress) float64 {
+			if !progress.HasIncumbent {
+				return math.NaN()
+			}
+			return progress.Incumbent
+		},
+	},
+	{
+		name: "mip_solve_best_bound",
+		help: "Best bound on the objective value of in-flight solves.",
+		value: func(progress mip.Progress) float64 {
+			return progress.BestBound
+		},
+	},
+	{
+		name: "mip_solve_nodes",
+		help: "Branch-and-bound nodes processed by in-flight solves.",
+		value: func(progress mip.Progress) float64 {
+			return float64(progress.Nodes)
+		},
+	},
+	{
+		name: "mip_solve_elapsed_seconds",
+		help: "Seconds since in-flight solves started.",
+		value: func(progress mip.Progress) float64 {
+			return progress.Elapsed.Seconds()
+		},
+	},
+}
+
+// Collector collects the progress of in-flight solves. It implements
+// http.Handler serving the metrics in the Prometheus text exposition format.
+// It is safe for concurrent use by multiple goroutines.
+type Collector struct {
+	solves map[*Solve]struct{}
+	mutex  sync.Mutex
+}
+
+// NewCollector creates a collector without in-flight solves.
+func NewCollector() *Collector {
+	return &Collector{solves: make(map[*Solve]struct{})}
+}
+
+// Solve is an in-flight solve tracked by a Collector.
+type Solve struct {
+	collector *Collector
+	id        string
+	provider  mip.SolverProvider
+	progress  mip.Progress
+}
+
+// Track starts tracking a solve identified by id. The id and the provider
+// are reported as labels. Invoke Solve.Done once the solve finished to stop
+// reporting it.
+func (c *Collector) Track(id string, provider mip.SolverProvider) *Solve {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	solve := &Solve{
+		collector: c,
+		id:        id,
+		provider:  provider,
+		progress:  mip.Progress{BestBound: math.NaN()},
+	}
+	c.solves[solve] = struct{}{}
+	return solve
+}
+
+// Observe records progress of the solve. It can be registered with
+// mip.ProgressNotifier.OnProgress.
+func (s *Solve) Observe(progress mip.Progress) {
+	s.collector.mutex.Lock()
+	defer s.collector.mutex.Unlock()
+	s.progress = progress
+}
+
+// ObserveSolver registers the solve with solver if it is a
+// mip.ProgressNotifier. Returns false if solver does not report progress.
+func (s *Solve) ObserveSolver(solver mip.Solver) bool {
+	notifier, ok := mip.UnwrapSolver(solver).(mip.ProgressNotifier)
+	if ok {
+		notifier.OnProgress(s.Observe)
+	}
+	return ok
+}
+
+// ObserveLogRecord records the progress of LogProgress and LogIncumbent
+// records and ignores all other records. It can be used with
+// mip.NewLogRecordWriter for solvers which only report progress in their
+// log.
+func (s *Solve) ObserveLogRecord(record mip.LogRecord) {
+	if record.Progress != nil {
+		s.Observe(record.Progress.Progress())
+	}
+}
+
+// Done stops tracking the solve.
+func (s *Solve) Done() {
+	s.collector.mutex.Lock()
+	defer s.collector.mutex.Unlock()
+	delete(s.collector.solves, s)
+}
+
+// ServeHTTP implements http.Handler.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	_, _ = c.WriteTo(w)
+}
+
+// WriteTo writes the metrics of all in-flight solves to w in the Prometheus
+// text exposition format.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mutex.Lock()
+	solves := make([]Solve, 0, len(c.solves))
+	for solve := range c.solves {
+		solves = append(solves, *solve)
+	}
+	c.mutex.Unlock()
+
+	sort.Slice(solves, func(i, j int) bool {
+		return solves[i].id < solves[j].id
+	})
+
+	counter := &countingWriter{w: w}
+	b := bufio.NewWriter(counter)
+	for _, g := range gauges {
+		fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name)
+		for _, solve := range solves {
+			fmt.Fprintf(
+				b,
+				"%s{solve=%s,provider=%s} %s\n",
+				g.name,
+				quote(solve.id),
+				quote(string(solve.provider)),
+				formatValue(g.value(solve.progress)),
+			)
+		}
+	}
+	err := b.Flush()
+	return counter.n, err
+}
+
+// quote returns value as a quoted label value.
+func quote(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+	return `"` + value + `"`
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
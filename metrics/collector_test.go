@@ -0,0 +1,61 @@
+// © 2019-present nextmv.io inc
+
+package metrics_test
+
+import (
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+	"github.com/nextmv-io/go-mip/metrics"
+)
+
+func TestCollector(t *testing.T) {
+	collector := metrics.NewCollector()
+
+	a := collector.Track("a", "highs")
+	a.Observe(mip.Progress{
+		BestBound:    15,
+		Elapsed:      2 * time.Second,
+		HasIncumbent: true,
+		Incumbent:    20,
+		Nodes:        7,
+	})
+	b := collector.Track(`b"1`, "highs")
+	b.ObserveLogRecord(mip.ParseHiGHSLogLine(
+		"   0   0   0   0.00%   -inf   inf   inf   0   0   0   0   0.5s",
+	))
+	done := collector.Track("c", "highs")
+	done.Done()
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if recorder.Header().Get("Content-Type") != metrics.ContentType {
+		t.Errorf("unexpected content type %q", recorder.Header().Get("Content-Type"))
+	}
+
+	body := recorder.Body.String()
+	for _, line := range []string{
+		"# TYPE mip_solve_gap gauge",
+		`mip_solve_gap{solve="a",provider="highs"} 0.25`,
+		`mip_solve_gap{solve="b\"1",provider="highs"} +Inf`,
+		`mip_solve_incumbent{solve="a",provider="highs"} 20`,
+		`mip_solve_incumbent{solve="b\"1",provider="highs"} NaN`,
+		`mip_solve_best_bound{solve="b\"1",provider="highs"} -Inf`,
+		`mip_solve_nodes{solve="a",provider="highs"} 7`,
+		`mip_solve_elapsed_seconds{solve="b\"1",provider="highs"} 0.5`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("missing line %q in\n%s", line, body)
+		}
+	}
+	if strings.Contains(body, `solve="c"`) {
+		t.Errorf("finished solve must not be reported")
+	}
+	if gap := (mip.Progress{}).Gap(); !math.IsInf(gap, 1) {
+		t.Errorf("got gap %v without incumbent, want +Inf", gap)
+	}
+}
@@ -0,0 +1,383 @@
+// © 2019-present nextmv.io inc
+
+package miptest
+
+import (
+	"errors"
+	"io"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// ConformanceOptions configure RunSolverConformance.
+type ConformanceOptions struct {
+	// SkipQuadratic skips the cases with a quadratic objective, for
+	// back-ends which only solve linear models.
+	SkipQuadratic bool
+	// Tolerance is the absolute tolerance on objective values and values of
+	// vars. Defaults to 1e-6 if 0.
+	Tolerance float64
+	// TimeLimitGrace is how long a solve may exceed its duration limit or
+	// take to return after it is interrupted. Defaults to 5s if 0.
+	TimeLimitGrace time.Duration
+}
+
+// conformanceCase is a model with its expected outcome.
+type conformanceCase struct {
+	name      string
+	quadratic bool
+	model     func() mip.Model
+	check     func(t *testing.T, model mip.Model, solution mip.Solution, tolerance float64)
+}
+
+// RunSolverConformance runs a suite of subtests verifying that the solvers
+// created by factory behave as package mip expects: LP, MIP and QP models are
+// solved to optimality, infeasible and unbounded models are detected, options
+// are accepted, copies of a model are solved concurrently and the duration
+// limit stops the solve. Solvers implementing mip.Interrupter must return
+// promptly when interrupted, with an error wrapping mip.ErrInterrupted or the
+// best solution found so far. Authors of back-ends run it from their tests,
+// with the race detector to check that solvers of different models and
+// solutions can be used concurrently.
+//
+//	func TestConformance(t *testing.T) {
+//		miptest.RunSolverConformance(t, mybackend.NewSolver, miptest.ConformanceOptions{})
+//	}
+func RunSolverConformance(
+	t *testing.T,
+	factory mip.SolverFactory,
+	options ConformanceOptions,
+) {
+	t.Helper()
+	if options.Tolerance == 0 {
+		options.Tolerance = 1e-6
+	}
+	if options.TimeLimitGrace == 0 {
+		options.TimeLimitGrace = 5 * time.Second
+	}
+
+	for _, c := range conformanceCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			if c.quadratic && options.SkipQuadratic {
+				t.Skip("quadratic cases are skipped")
+			}
+			model := c.model()
+			solution := solve(t, factory, model, mip.SolveOptions{Duration: time.Minute})
+			c.check(t, model, solution, options.Tolerance)
+		})
+	}
+
+	t.Run("options", func(t *testing.T) {
+		model := lpModel()
+		solveOptions := mip.SolveOptions{
+			Duration:      time.Minute,
+			Verbosity:     mip.High,
+			Seed:          42,
+			Deterministic: true,
+			LogWriter:     io.Discard,
+		}
+		solveOptions.MIP.Gap.Relative = 1e-6
+		solveOptions.Tolerances.PrimalFeasibility = 1e-7
+		solution := solve(t, factory, model, solveOptions)
+		assertOptimal(t, model, solution, 2.8, options.Tolerance)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		model, _ := knapsack()
+		clones := mip.CloneForSolve(model, 4)
+		var wg sync.WaitGroup
+		for _, clone := range clones {
+			wg.Add(1)
+			go func(clone mip.Model) {
+				defer wg.Done()
+				// solve can not be used, it stops the test from another
+				// goroutine.
+				solver, err := factory(clone)
+				if err != nil {
+					t.Errorf("creating solver: %v", err)
+					return
+				}
+				solution, err := solver.Solve(mip.SolveOptions{Duration: time.Minute})
+				if err != nil || solution == nil {
+					t.Errorf("solving: %v, solution %v", err, solution)
+					return
+				}
+				// Solutions must be safe for concurrent use.
+				var readers sync.WaitGroup
+				for _, v := range clone.Vars() {
+					readers.Add(1)
+					go func(v mip.Var) {
+						defer readers.Done()
+						_ = solution.Value(v)
+						_ = solution.ObjectiveValue()
+					}(v)
+				}
+				readers.Wait()
+				assertOptimal(t, clone, solution, 13, options.Tolerance)
+			}(clone)
+		}
+		wg.Wait()
+	})
+
+	t.Run("duration limit", func(t *testing.T) {
+		model := hardModel()
+		duration := 200 * time.Millisecond
+		start := time.Now()
+		solution := solve(t, factory, model, mip.SolveOptions{Duration: duration})
+		if elapsed := time.Since(start); elapsed > duration+options.TimeLimitGrace {
+			t.Errorf("solve took %v with a duration limit of %v", elapsed, duration)
+		}
+		if !solution.IsOptimal() && !solution.IsTimeOut() {
+			t.Errorf("solution is neither optimal nor timed out")
+		}
+		if solution.HasValues() {
+			assertValid(t, model, solution)
+		}
+	})
+
+	t.Run("interrupt", func(t *testing.T) {
+		runInterrupt(t, factory, options.TimeLimitGrace)
+	})
+}
+
+// runInterrupt interrupts a long solve and verifies it returns within grace.
+func runInterrupt(t *testing.T, factory mip.SolverFactory, grace time.Duration) {
+	t.Helper()
+	model := hardModel()
+	solver, err := factory(model)
+	if err != nil {
+		t.Fatalf("creating solver: %v", err)
+	}
+	interrupter, ok := mip.UnwrapSolver(solver).(mip.Interrupter)
+	if !ok {
+		t.Skip("solver does not implement mip.Interrupter")
+	}
+
+	type result struct {
+		solution mip.Solution
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		solution, err := solver.Solve(mip.SolveOptions{Duration: time.Hour})
+		done <- result{solution, err}
+	}()
+
+	// Interrupt repeatedly, the first interrupt may precede the solve.
+	time.Sleep(100 * time.Millisecond)
+	interrupted := time.Now()
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	deadline := time.NewTimer(grace)
+	defer deadline.Stop()
+	for {
+		interrupter.Interrupt()
+		select {
+		case r := <-done:
+			checkInterrupted(t, model, r.solution, r.err)
+			return
+		case <-deadline.C:
+			t.Fatalf("solve did not return %v after it was interrupted", time.Since(interrupted))
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkInterrupted verifies the outcome of an interrupted solve.
+func checkInterrupted(t *testing.T, model mip.Model, solution mip.Solution, err error) {
+	t.Helper()
+	if err != nil {
+		if !errors.Is(err, mip.ErrInterrupted) {
+			t.Errorf("got error %v, want %v", err, mip.ErrInterrupted)
+		}
+		return
+	}
+	if solution == nil {
+		t.Fatalf("interrupted solve returned no solution and no error")
+	}
+	if !solution.IsOptimal() && !solution.IsTimeOut() {
+		t.Errorf("interrupted solution is neither optimal nor timed out")
+	}
+	if solution.HasValues() {
+		assertValid(t, model, solution)
+	}
+}
+
+var conformanceCases = []conformanceCase{
+	{
+		name:  "lp",
+		model: lpModel,
+		check: func(t *testing.T, model mip.Model, solution mip.Solution, tolerance float64) {
+			assertOptimal(t, model, solution, 2.8, tolerance)
+			AssertValues(t, model, solution, map[string]float64{"x": 1.6, "y": 1.2}, tolerance)
+		},
+	},
+	{
+		name: "mip",
+		model: func() mip.Model {
+			model, _ := knapsack()
+			return model
+		},
+		check: func(t *testing.T, model mip.Model, solution mip.Solution, tolerance float64) {
+			assertOptimal(t, model, solution, 13, tolerance)
+			AssertValues(t, model, solution, map[string]float64{"a": 1, "b": 0, "c": 1}, tolerance)
+		},
+	},
+	{
+		name:      "qp",
+		quadratic: true,
+		model: func() mip.Model {
+			// minimize x^2 - 2x, optimal at x = 1.
+			model := mip.NewModel()
+			x := model.NewFloat(-10, 10)
+			x.SetName("x")
+			model.Objective().NewQuadraticTerm(1, x, x)
+			model.Objective().NewTerm(-2, x)
+			return model
+		},
+		check: func(t *testing.T, model mip.Model, solution mip.Solution, tolerance float64) {
+			assertOptimal(t, model, solution, -1, tolerance)
+			AssertValues(t, model, solution, map[string]float64{"x": 1}, math.Sqrt(tolerance))
+		},
+	},
+	{
+		name: "infeasible",
+		model: func() mip.Model {
+			model, weight := knapsack()
+			model.NewConstraint(mip.GreaterThanOrEqual, 11).NewTerm(1, weight)
+			return model
+		},
+		check: func(t *testing.T, _ mip.Model, solution mip.Solution, _ float64) {
+			if !solution.IsInfeasible() {
+				t.Errorf("infeasible model not detected")
+			}
+			if solution.HasValues() {
+				t.Errorf("infeasible model has values")
+			}
+		},
+	},
+	{
+		name: "unbounded",
+		model: func() mip.Model {
+			model := mip.NewModel()
+			x := model.NewFreeFloat()
+			y := model.NewInt(0, 10)
+			model.Objective().SetMaximize()
+			model.Objective().NewTerm(1, x)
+			model.Objective().NewTerm(1, y)
+			c := model.NewConstraint(mip.GreaterThanOrEqual, 1)
+			c.NewTerm(1, x)
+			c.NewTerm(-1, y)
+			return model
+		},
+		check: func(t *testing.T, _ mip.Model, solution mip.Solution, _ float64) {
+			if !solution.IsUnbounded() {
+				t.Errorf("unbounded model not detected")
+			}
+		},
+	},
+}
+
+// hardModel returns a model which takes back-ends a while to solve.
+func hardModel() mip.Model {
+	model, _ := mip.RandomModel(1, mip.RandomModelSpec{
+		Vars:        400,
+		Constraints: 300,
+		Density:     0.2,
+		IntFraction: 1,
+		MaxBound:    1000,
+	})
+	return model
+}
+
+// lpModel returns maximize x + y subject to x + 2y <= 4 and 3x + y <= 6,
+// optimal at x = 1.6 and y = 1.2.
+func lpModel() mip.Model {
+	model := mip.NewModel()
+	x := model.NewFloat(0, math.Inf(1))
+	x.SetName("x")
+	y := model.NewFloat(0, math.Inf(1))
+	y.SetName("y")
+	model.Objective().SetMaximize()
+	model.Objective().NewTerm(1, x)
+	model.Objective().NewTerm(1, y)
+	c1 := model.NewConstraint(mip.LessThanOrEqual, 4)
+	c1.NewTerm(1, x)
+	c1.NewTerm(2, y)
+	c2 := model.NewConstraint(mip.LessThanOrEqual, 6)
+	c2.NewTerm(3, x)
+	c2.NewTerm(1, y)
+	return model
+}
+
+// knapsack returns maximize 10a + 7b + 3c subject to 5a + 4b + c <= 6,
+// optimal at a = c = 1. The second return value is a float var holding the
+// weight.
+func knapsack() (mip.Model, mip.Var) {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	weight := model.NewFloat(0, 6)
+	weight.SetName("weight")
+	c := model.NewConstraint(mip.Equal, 0)
+	c.NewTerm(-1, weight)
+	for i, name := range []string{"a", "b", "c"} {
+		item := model.NewBool()
+		item.SetName(name)
+		model.Objective().NewTerm([]float64{10, 7, 3}[i], item)
+		c.NewTerm([]float64{5, 4, 1}[i], item)
+	}
+	return model, weight
+}
+
+func solve(
+	t *testing.T,
+	factory mip.SolverFactory,
+	model mip.Model,
+	options mip.SolveOptions,
+) mip.Solution {
+	t.Helper()
+	solver, err := factory(model)
+	if err != nil {
+		t.Fatalf("creating solver: %v", err)
+	}
+	solution, err := solver.Solve(options)
+	if err != nil {
+		t.Fatalf("solving: %v", err)
+	}
+	if solution == nil {
+		t.Fatalf("solving: no solution")
+	}
+	if solution.RunTime() < 0 {
+		t.Errorf("negative run time %v", solution.RunTime())
+	}
+	return solution
+}
+
+func assertOptimal(
+	t *testing.T,
+	model mip.Model,
+	solution mip.Solution,
+	objectiveValue float64,
+	tolerance float64,
+) {
+	t.Helper()
+	if !solution.IsOptimal() {
+		t.Errorf("solution is not optimal")
+	}
+	AssertObjectiveValue(t, solution, objectiveValue, tolerance)
+	if solution.HasValues() {
+		assertValid(t, model, solution)
+	}
+}
+
+func assertValid(t *testing.T, model mip.Model, solution mip.Solution) {
+	t.Helper()
+	if report := mip.Verify(model, solution, mip.DefaultTolerances()); !report.IsValid() {
+		t.Errorf("solution does not pass verification: %+v", report)
+	}
+}
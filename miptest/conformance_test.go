@@ -0,0 +1,66 @@
+// © 2019-present nextmv.io inc
+
+package miptest_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+	"github.com/nextmv-io/go-mip/miptest"
+)
+
+// outcome is a Solution with a configurable status.
+type outcome struct {
+	solution
+	infeasible bool
+	timeOut    bool
+	unbounded  bool
+}
+
+func (o outcome) IsInfeasible() bool { return o.infeasible }
+func (o outcome) IsOptimal() bool    { return !o.infeasible && !o.timeOut && !o.unbounded }
+func (o outcome) IsTimeOut() bool    { return o.timeOut }
+func (o outcome) IsUnbounded() bool  { return o.unbounded }
+
+// oracle is a Solver which knows the outcomes of the conformance models,
+// recognized by their number of vars and constraints. Other models run
+// until the duration limit or an interrupt.
+type oracle struct {
+	model       mip.Model
+	interrupted chan struct{}
+	once        sync.Once
+}
+
+func (o *oracle) Interrupt() {
+	o.once.Do(func() { close(o.interrupted) })
+}
+
+func (o *oracle) Solve(options mip.SolveOptions) (mip.Solution, error) {
+	shape := fmt.Sprintf("%d/%d", len(o.model.Vars()), len(o.model.Constraints()))
+	switch shape {
+	case "2/2":
+		return outcome{solution: solution{2.8, map[int]float64{0: 1.6, 1: 1.2}}}, nil
+	case "4/1":
+		return outcome{solution: solution{13, map[int]float64{0: 6, 1: 1, 2: 0, 3: 1}}}, nil
+	case "1/0":
+		return outcome{solution: solution{-1, map[int]float64{0: 1}}}, nil
+	case "4/2":
+		return outcome{infeasible: true}, nil
+	case "2/1":
+		return outcome{unbounded: true}, nil
+	}
+	select {
+	case <-time.After(options.Duration):
+	case <-o.interrupted:
+	}
+	return outcome{timeOut: true}, nil
+}
+
+func TestRunSolverConformance(t *testing.T) {
+	miptest.RunSolverConformance(t, func(model mip.Model) (mip.Solver, error) {
+		return &oracle{model: model, interrupted: make(chan struct{})}, nil
+	}, miptest.ConformanceOptions{})
+}
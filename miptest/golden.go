@@ -0,0 +1,214 @@
+// © 2019-present nextmv.io inc
+
+// Package miptest provides helpers to test applications building models with
+// package mip and back-ends implementing it.
+package miptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// UpdateEnv is the environment variable which, if set to a non-empty value,
+// makes the golden assertions write the golden files instead of comparing
+// against them.
+//
+//	MIPTEST_UPDATE=1 go test ./...
+const UpdateEnv = "MIPTEST_UPDATE"
+
+// AssertGoldenLP asserts that model written in the LP format, see mip.WriteLP,
+// matches the golden file at path.
+func AssertGoldenLP(t testing.TB, model mip.Model, path string) {
+	t.Helper()
+	var buffer bytes.Buffer
+	if err := mip.WriteLP(&buffer, model); err != nil {
+		t.Fatalf("miptest: writing LP: %v", err)
+	}
+	assertGolden(t, buffer.Bytes(), path)
+}
+
+// AssertGoldenJSON asserts that the canonical JSON representation of model,
+// see CanonicalJSON, matches the golden file at path.
+func AssertGoldenJSON(t testing.TB, model mip.Model, path string) {
+	t.Helper()
+	b, err := CanonicalJSON(model)
+	if err != nil {
+		t.Fatalf("miptest: marshaling model: %v", err)
+	}
+	assertGolden(t, b, path)
+}
+
+func assertGolden(t testing.TB, got []byte, path string) {
+	t.Helper()
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("miptest: updating golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("miptest: reading golden file, set %s=1 to create it: %v", UpdateEnv, err)
+	}
+	if bytes.Equal(got, want) {
+		return
+	}
+	gotLines := strings.Split(string(got), "\n")
+	wantLines := strings.Split(string(want), "\n")
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		g, w := line(gotLines, i), line(wantLines, i)
+		if g != w {
+			t.Errorf(
+				"miptest: model does not match golden file %s at line %d:\n got: %s\nwant: %s",
+				path,
+				i+1,
+				g,
+				w,
+			)
+			return
+		}
+	}
+}
+
+func line(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return "<end of file>"
+}
+
+type canonicalModel struct {
+	Maximize       bool                     `json:"maximize"`
+	Objective      []canonicalTerm          `json:"objective"`
+	QuadraticTerms []canonicalQuadraticTerm `json:"quadratic_objective,omitempty"`
+	Constraints    []canonicalConstraint    `json:"constraints"`
+	Vars           []canonicalVar           `json:"vars"`
+}
+
+type canonicalVar struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	Lower *float64 `json:"lower"`
+	Upper *float64 `json:"upper"`
+}
+
+type canonicalTerm struct {
+	Var         string  `json:"var"`
+	Coefficient float64 `json:"coefficient"`
+}
+
+type canonicalQuadraticTerm struct {
+	Var1        string  `json:"var1"`
+	Var2        string  `json:"var2"`
+	Coefficient float64 `json:"coefficient"`
+}
+
+type canonicalConstraint struct {
+	Name          string          `json:"name"`
+	Sense         string          `json:"sense"`
+	RightHandSide float64         `json:"rhs"`
+	Terms         []canonicalTerm `json:"terms"`
+}
+
+// CanonicalJSON returns an indented JSON representation of model which does
+// not depend on the order in which terms have been added. Vars and
+// constraints are listed in the order of their creation, terms in the order
+// of their vars. Vars are referenced by name, infinite bounds are null.
+func CanonicalJSON(model mip.Model) ([]byte, error) {
+	c := canonicalModel{
+		Maximize:    model.Objective().IsMaximize(),
+		Objective:   canonicalTerms(model.Objective().Terms()),
+		Constraints: []canonicalConstraint{},
+		Vars:        []canonicalVar{},
+	}
+
+	quadratic := model.Objective().QuadraticTerms()
+	sort.SliceStable(quadratic, func(i, j int) bool {
+		a, b := quadratic[i], quadratic[j]
+		if a.Var1().Index() != b.Var1().Index() {
+			return a.Var1().Index() < b.Var1().Index()
+		}
+		return a.Var2().Index() < b.Var2().Index()
+	})
+	for _, t := range quadratic {
+		c.QuadraticTerms = append(c.QuadraticTerms, canonicalQuadraticTerm{
+			Var1:        fmt.Sprint(t.Var1()),
+			Var2:        fmt.Sprint(t.Var2()),
+			Coefficient: t.Coefficient(),
+		})
+	}
+
+	for _, v := range model.Vars() {
+		c.Vars = append(c.Vars, canonicalVar{
+			Name:  fmt.Sprint(v),
+			Type:  varType(v),
+			Lower: finite(v.LowerBound()),
+			Upper: finite(v.UpperBound()),
+		})
+	}
+
+	for _, constraint := range model.Constraints() {
+		c.Constraints = append(c.Constraints, canonicalConstraint{
+			Name:          constraint.Name(),
+			Sense:         senses[constraint.Sense()],
+			RightHandSide: constraint.RightHandSide(),
+			Terms:         canonicalTerms(constraint.Terms()),
+		})
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(c); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+var senses = map[mip.Sense]string{
+	mip.LessThanOrEqual:    "<=",
+	mip.Equal:              "=",
+	mip.GreaterThanOrEqual: ">=",
+}
+
+func canonicalTerms(terms mip.Terms) []canonicalTerm {
+	sort.SliceStable(terms, func(i, j int) bool {
+		return terms[i].Var().Index() < terms[j].Var().Index()
+	})
+	result := make([]canonicalTerm, len(terms))
+	for i, t := range terms {
+		result[i] = canonicalTerm{
+			Var:         fmt.Sprint(t.Var()),
+			Coefficient: t.Coefficient(),
+		}
+	}
+	return result
+}
+
+func varType(v mip.Var) string {
+	switch {
+	case v.IsBool():
+		return "bool"
+	case v.IsInt():
+		return "int"
+	default:
+		return "float"
+	}
+}
+
+func finite(value float64) *float64 {
+	if math.IsInf(value, 0) {
+		return nil
+	}
+	return &value
+}
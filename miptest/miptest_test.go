@@ -0,0 +1,108 @@
+// © 2019-present nextmv.io inc
+
+package miptest_test
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+	"github.com/nextmv-io/go-mip/miptest"
+)
+
+// recorder records failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	failures int
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(string, ...any) {
+	r.failures++
+}
+
+func (r *recorder) Fatalf(string, ...any) {
+	r.failures++
+}
+
+// solution is a Solution with fixed values.
+type solution struct {
+	objectiveValue float64
+	values         map[int]float64
+}
+
+func (s solution) HasValues() bool                { return s.values != nil }
+func (s solution) IsInfeasible() bool             { return false }
+func (s solution) IsNumericalFailure() bool       { return false }
+func (s solution) IsOptimal() bool                { return true }
+func (s solution) IsSubOptimal() bool             { return false }
+func (s solution) IsTimeOut() bool                { return false }
+func (s solution) IsUnbounded() bool              { return false }
+func (s solution) ObjectiveValue() float64        { return s.objectiveValue }
+func (s solution) Provider() mip.SolverProvider   { return "test" }
+func (s solution) RunTime() time.Duration         { return 0 }
+func (s solution) Value(variable mip.Var) float64 { return s.values[variable.Index()] }
+
+func newModel(reverse bool) mip.Model {
+	model := mip.NewModel()
+	x := model.NewFloat(0, math.Inf(1))
+	x.SetName("x")
+	y := model.NewInt(-5, 5)
+	y.SetName("y")
+	z := model.NewBool()
+	z.SetName("z")
+	model.Objective().SetMaximize()
+	c := model.NewConstraint(mip.LessThanOrEqual, 10)
+	c.SetName("capacity")
+	if reverse {
+		model.Objective().NewTerm(3, z)
+		model.Objective().NewTerm(1, x)
+		c.NewTerm(2, y)
+		c.NewTerm(1, x)
+	} else {
+		model.Objective().NewTerm(1, x)
+		model.Objective().NewTerm(3, z)
+		c.NewTerm(1, x)
+		c.NewTerm(2, y)
+	}
+	model.Objective().NewQuadraticTerm(-1, x, x)
+	return model
+}
+
+func TestGolden(t *testing.T) {
+	for _, reverse := range []bool{false, true} {
+		model := newModel(reverse)
+		miptest.AssertGoldenLP(t, model, "testdata/model.lp")
+		miptest.AssertGoldenJSON(t, model, "testdata/model.json")
+	}
+
+	if os.Getenv(miptest.UpdateEnv) != "" {
+		return
+	}
+	model := newModel(false)
+	model.NewConstraint(mip.Equal, 1)
+	r := &recorder{TB: t}
+	miptest.AssertGoldenJSON(r, model, "testdata/model.json")
+	if r.failures != 1 {
+		t.Errorf("expected a mismatch for a changed model")
+	}
+}
+
+func TestAssertSolution(t *testing.T) {
+	model := newModel(false)
+	s := solution{objectiveValue: 2, values: map[int]float64{0: 1, 1: 2}}
+
+	miptest.AssertObjectiveValue(t, s, 2.0000001, 1e-6)
+	miptest.AssertValues(t, model, s, map[string]float64{"x": 1, "y": 2}, 1e-6)
+
+	r := &recorder{TB: t}
+	miptest.AssertObjectiveValue(r, s, 3, 1e-6)
+	miptest.AssertValues(r, model, s, map[string]float64{"x": 2, "w": 0}, 1e-6)
+	miptest.AssertValues(r, model, solution{}, nil, 1e-6)
+	if r.failures != 4 {
+		t.Errorf("got %d failures, want 4", r.failures)
+	}
+}
@@ -0,0 +1,58 @@
+// © 2019-present nextmv.io inc
+
+package miptest
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// AssertObjectiveValue asserts that solution has values and an objective
+// value within tolerance of want.
+func AssertObjectiveValue(t testing.TB, solution mip.Solution, want, tolerance float64) {
+	t.Helper()
+	if solution == nil || !solution.HasValues() {
+		t.Errorf("miptest: solution has no values")
+		return
+	}
+	if got := solution.ObjectiveValue(); math.Abs(got-want) > tolerance {
+		t.Errorf("miptest: objective value = %v, want %v ± %v", got, want, tolerance)
+	}
+}
+
+// AssertValues asserts that solution has values and that the value of each
+// var named in want, see Var.String, is within tolerance of the wanted
+// value. Vars of model not named in want are not checked.
+func AssertValues(
+	t testing.TB,
+	model mip.Model,
+	solution mip.Solution,
+	want map[string]float64,
+	tolerance float64,
+) {
+	t.Helper()
+	if solution == nil || !solution.HasValues() {
+		t.Errorf("miptest: solution has no values")
+		return
+	}
+	found := make(map[string]bool, len(want))
+	for _, v := range model.Vars() {
+		name := fmt.Sprint(v)
+		wanted, ok := want[name]
+		if !ok {
+			continue
+		}
+		found[name] = true
+		if got := solution.Value(v); math.Abs(got-wanted) > tolerance {
+			t.Errorf("miptest: value of %s = %v, want %v ± %v", name, got, wanted, tolerance)
+		}
+	}
+	for name := range want {
+		if !found[name] {
+			t.Errorf("miptest: model has no var %s", name)
+		}
+	}
+}
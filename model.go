@@ -4,20 +4,75 @@
 package mip
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"strings"
 )
 
 // Model manages the variables, constraints and objective.
 type Model interface {
+	// AddTermByID adds a term to the constraint with handle c like
+	// Constraint.NewTerm, without the dispatch of interface methods, for
+	// building models with many terms:
+	//
+	//	c := model.NewConstraintID(mip.LessThanOrEqual, capacity)
+	//	for i, weight := range weights {
+	//		model.AddTermByID(c, items[i], weight)
+	//	}
+	//
+	// Panics if a handle is out of range and like NewTerm.
+	AddTermByID(c ConstraintID, v VarID, coefficient float64)
+	// ConstraintByID returns the constraint with handle id. Panics if id is
+	// out of range.
+	ConstraintByID(id ConstraintID) Constraint
 	// Constraints returns a copy slice of all constraints.
 	Constraints() Constraints
-	// Copy returns a copy of the model.
+	// Copy returns a copy of the model. A copy shares no state with the
+	// model, so the model and its copies can be changed and solved
+	// concurrently by different solvers, see CloneForSolve.
 	Copy() Model
+	// Fprint writes the invoking model to w in a human readable form,
+	// formatted according to options. Unlike String it is meant to inspect
+	// large models, e.g. by wrapping long expressions or only printing a
+	// group of vars.
+	Fprint(w io.Writer, options PrintOptions) error
+	// Fork returns a copy of the invoking model which shares the terms of
+	// the constraints with it until they are changed in either model. Forks
+	// are much cheaper than Copy for algorithms which derive many models
+	// changing few constraints, e.g. branching or scenario sweeps that fix
+	// vars.
+	Fork() Model
+	// Free releases the vars, constraints and terms of a model created by
+	// NewModelWithArena for reuse by other models and leaves the model
+	// empty. The vars, constraints and terms of the model, and solutions
+	// referencing them, must not be used afterwards. Free does nothing for
+	// other models.
+	Free()
+	// Freeze validates the invoking model and marks it immutable. Changing
+	// a frozen model, its vars, constraints or objective returns an error
+	// wrapping ErrFrozen from methods returning an error and panics with it
+	// otherwise. A frozen model can be read, copied, forked and solved from
+	// many goroutines at once without defensive copies. Copies and forks are
+	// not frozen. Returns an error wrapping ErrInvalidModel, leaving the
+	// model unfrozen, if a var has crossed bounds, a term has a var of
+	// another model or an infinite coefficient, or an equality constraint
+	// has an infinite right-hand side. Freezing a frozen model does nothing.
+	Freeze() error
+	// Frozen returns true if the invoking model is frozen, see Freeze.
+	Frozen() bool
+	// MemoryFootprint returns an estimate of the memory held by the invoking
+	// model, its vars, constraints, terms, objective, names and other data,
+	// see EstimateMemory for the memory of a back-end solving it.
+	MemoryFootprint() ByteSize
 	// NewBool adds a bool variable to the invoking model,
 	// returns the newly constructed variable.
 	NewBool() Bool
+	// NewBoolChecked adds a bool var like NewBool but returns a
+	// *ModelLimitError instead of panicking if the model would exceed its
+	// limits.
+	NewBoolChecked() (Bool, error)
 	// NewFloat adds a float var with bounds [lowerBound,
 	// upperBound] to the invoking model, returns the newly constructed
 	// var.
@@ -25,132 +80,292 @@ type Model interface {
 		lowerBound float64,
 		upperBound float64,
 	) Float
+	// NewFloatChecked adds a float var like NewFloat but returns an error
+	// instead of panicking if a bound is NaN or the model would exceed its
+	// limits.
+	NewFloatChecked(
+		lowerBound float64,
+		upperBound float64,
+	) (Float, error)
+	// NewFreeFloat adds a float var without bounds, i.e. with bounds
+	// [-Infinity(), Infinity()], to the invoking model, returns the newly
+	// constructed var.
+	NewFreeFloat() Float
 	// NewInt adds an integer var with bounds [loweBound,
 	// upperBound] to the invoking model, returns the newly constructed
-	// var.
+	// var. Bounds must not exceed MaxIntBound in magnitude, except for
+	// math.MinInt64 and math.MaxInt64 which leave the var unbounded. Panics
+	// if a bound is out of range, use NewIntChecked for bounds derived from
+	// data.
 	NewInt(
 		lowerBound int64,
 		upperBound int64,
 	) Int
+	// NewIntChecked adds an integer var like NewInt but returns an error
+	// wrapping ErrIntBoundOutOfRange instead of panicking if a bound is out of
+	// range.
+	NewIntChecked(
+		lowerBound int64,
+		upperBound int64,
+	) (Int, error)
 	// NewConstraint adds a constraint with sense and right-hand-side value rhs
 	// to the invoking model. All terms for existing and future variables
 	// are initially zero. Returns the newly constructed constraint.
 	// A constraint where all terms remain zero is ignored by the solver.
 	NewConstraint(sense Sense, rhs float64) Constraint
+	// NewConstraintChecked adds a constraint like NewConstraint but returns an
+	// error instead of panicking if rhs is NaN or the model would exceed its
+	// limits.
+	NewConstraintChecked(sense Sense, rhs float64) (Constraint, error)
+	// NewConstraintID adds a constraint like NewConstraint and returns its
+	// handle, see AddTermByID.
+	NewConstraintID(sense Sense, rhs float64) ConstraintID
+	// NewParam adds a param named name with value to the invoking model, see
+	// Param. If the model has a param of the name, its value is changed and
+	// it is returned.
+	NewParam(name string, value float64) Param
 	// Objective returns the objective of the model.
 	Objective() Objective
+	// SetConstraintNames sets the names of the first len(names) constraints
+	// of the invoking model at once, e.g. names read with the model from a
+	// file. Panics if there are more names than constraints.
+	SetConstraintNames(names []string)
+	// SetParam changes the value of the param named name and updates the
+	// right-hand sides and coefficients using it. Returns an error wrapping
+	// ErrUnknownParam if the model has no param of the name.
+	SetParam(name string, value float64) error
+	// SetVarNames sets the names of the first len(names) vars of the
+	// invoking model at once, the name of a var being the name at its
+	// index. Panics if there are more names than vars. Names are stored by
+	// var index and only allocated once a name is set, groups are interned
+	// since many vars share them.
+	SetVarNames(names []string)
+	// SetVarType changes the type of v in place, e.g. to relax integer vars
+	// to continuous ones for a relaxation-based heuristic and to restore
+	// them afterwards. Unlike a copy of the model, v, its terms and the
+	// handles referencing it stay valid. The bounds of v are not changed,
+	// back-ends restrict binary vars to [0, 1] and round the bounds of
+	// integer vars. Copy keeps the changed type. Panics if t is not
+	// Continuous, Integer or Binary.
+	//
+	//	for _, v := range ints {
+	//		model.SetVarType(v, mip.Continuous)
+	//	}
+	//	relaxation, err := solver.Solve(options)
+	//	for _, v := range ints {
+	//		model.SetVarType(v, mip.Integer)
+	//	}
+	SetVarType(v Var, t VarType)
+	// Summary returns the number of vars by type, constraints and non-zeros
+	// of the invoking model followed by its first constraints and vars. It
+	// is cheap for models of any size and meant for logging. String returns
+	// the summary for models with more than StringLimit vars, constraints
+	// and non-zeros, use Fprint to write such models in full.
+	Summary() string
+	// VarByID returns the var with handle id. Panics if id is out of range.
+	VarByID(id VarID) Var
 	// Vars returns a copy slice of all vars.
 	Vars() Vars
 }
 
 // NewModel SDK implementation.
 func NewModel() Model {
+	return NewModelWithLimits(ModelLimits{})
+}
+
+// NewModelWithLimits creates a new model which refuses to grow beyond the
+// given limits. Adding a var, constraint or constraint term beyond a limit
+// panics with a *ModelLimitError.
+func NewModelWithLimits(limits ModelLimits) Model {
 	return &model{
-		constraints:     make(Constraints, 0),
-		constraintNames: make(map[Constraint]string),
+		attributes:  make(attributes),
+		constraints: make(Constraints, 0),
+		limits:      limits,
+		params:      make(map[string]*param),
+		soft:        make(map[Constraint]softConstraint),
+		varTypes:    make(map[Var]VarType),
 		objective: &objective{
 			maximize: false,
 			terms:    make(Terms, 0),
 		},
-		vars:     make(Vars, 0),
-		varNames: make(map[Var]string),
+		fixed:     make(map[Var]float64),
+		vars:      make(Vars, 0),
+		varGroups: newInternedNames(),
 	}
 }
 
+// ModelLimits caps the size of a model. Limits protect services from
+// malformed input which would otherwise build models that exhaust memory. A
+// limit of 0 is treated as infinity.
+type ModelLimits struct {
+	// Constraints is the maximum number of constraints.
+	Constraints int `json:"constraints"`
+	// NonZeros is the maximum number of terms added to constraints. Terms
+	// added multiple times for the same variable count multiple times.
+	NonZeros int `json:"non_zeros"`
+	// Vars is the maximum number of vars.
+	Vars int `json:"vars"`
+}
+
+// ModelLimitError is the error raised when a model grows beyond one of its
+// ModelLimits.
+type ModelLimitError struct {
+	// Entity is the kind of entity which exceeded the limit, one of "vars",
+	// "constraints" or "non-zeros".
+	Entity string
+	// Limit is the configured limit.
+	Limit int
+}
+
+// Error implements the error interface.
+func (e *ModelLimitError) Error() string {
+	return fmt.Sprintf(
+		"model size limit exceeded: more than %d %s",
+		e.Limit,
+		e.Entity,
+	)
+}
+
+// ErrNaN is returned by the checked constructors for coefficients, bounds
+// and right-hand sides which are NaN. The unchecked constructors panic with
+// the same error.
+var ErrNaN = errors.New("NaN")
+
+// MaxIntBound is the largest magnitude of a finite bound of an int var.
+// Back-ends represent bounds as float64, which can not represent all integers
+// of larger magnitude exactly.
+const MaxIntBound = 1 << 53
+
+// ErrIntBoundOutOfRange is returned for bounds of int vars exceeding
+// MaxIntBound in magnitude.
+var ErrIntBoundOutOfRange = errors.New("int bound out of range")
+
+// checkIntBound returns an error if bound is out of range. The int64 extremes
+// are accepted as they denote an unbounded var.
+func checkIntBound(bound int64) error {
+	if bound == math.MinInt64 || bound == math.MaxInt64 ||
+		(bound >= -MaxIntBound && bound <= MaxIntBound) {
+		return nil
+	}
+	return fmt.Errorf(
+		"%w: %d exceeds %d in magnitude",
+		ErrIntBoundOutOfRange,
+		bound,
+		MaxIntBound,
+	)
+}
+
 type model struct {
-	objective       Objective
-	constraintNames map[Constraint]string
-	varNames        map[Var]string
-	constraints     Constraints
-	vars            Vars
+	attributes  attributes
+	objective   Objective
+	fixed       map[Var]float64
+	varGroups   names
+	varNames    names
+	constraints Constraints
+	vars        Vars
+	limits      ModelLimits
+	nonZeros    int
+	soft        map[Constraint]softConstraint
+	varTypes    map[Var]VarType
+	arena       *arena
+	params      map[string]*param
+	// float32 is true if constraints store their terms as compact terms,
+	// see NewModelWithFloat32Coefficients.
+	float32 bool
+	// frozen is true if the model can not be changed, see Model.Freeze.
+	frozen bool
+	// streamed is the number of vars passed to the sink of a ModelStream,
+	// their types, bounds and names can not be changed.
+	streamed int
 }
 
-func (m *model) setConstraintName(constraint Constraint, name string) {
-	m.constraintNames[constraint] = name
+// checkLimit returns a *ModelLimitError if count exceeds limit.
+func checkLimit(entity string, count int, limit int) error {
+	if limit > 0 && count > limit {
+		return &ModelLimitError{
+			Entity: entity,
+			Limit:  limit,
+		}
+	}
+	return nil
 }
 
-func (m *model) getConstraintName(constraint Constraint) string {
-	if name, ok := m.constraintNames[constraint]; ok {
-		return name
+// checkNaN returns an error wrapping ErrNaN if value is NaN.
+func checkNaN(what string, value float64) error {
+	if math.IsNaN(value) {
+		return fmt.Errorf("%s is %w", what, ErrNaN)
 	}
-	return ""
+	return nil
 }
 
 func (m *model) setVarName(variable Var, name string) {
-	m.varNames[variable] = name
+	m.mustBeMutableVar(variable)
+	m.varNames.set(variable.Index(), name, len(m.vars))
 }
 
 func (m *model) getVarName(variable Var) string {
-	if name, ok := m.varNames[variable]; ok {
-		return name
-	}
-	return ""
+	return m.varNames.get(variable.Index())
 }
 
-func (m *model) Constraints() Constraints {
-	constraints := make(Constraints, len(m.constraints))
+func (m *model) fixVar(variable Var, value float64) {
+	m.mustBeMutableVar(variable)
+	if err := checkNaN("fixed value", value); err != nil {
+		panic(err)
+	}
+	m.fixed[variable] = value
+}
 
-	copy(constraints, m.constraints)
+func (m *model) unfixVar(variable Var) {
+	m.mustBeMutableVar(variable)
+	delete(m.fixed, variable)
+}
 
-	return constraints
+func (m *model) getFixedValue(variable Var) (float64, bool) {
+	value, ok := m.fixed[variable]
+	return value, ok
 }
 
-func (m *model) Copy() Model {
-	copyModel := NewModel()
-
-	for _, v := range m.Vars() {
-		switch {
-		case v.IsFloat():
-			{
-				copyVar := copyModel.NewFloat(
-					v.LowerBound(),
-					v.UpperBound(),
-				)
-				copyVar.SetName(v.Name())
-			}
-		case v.IsBool():
-			{
-				copyVar := copyModel.NewBool()
-				copyVar.SetName(v.Name())
-			}
-		case v.IsInt():
-			copyVar := copyModel.NewInt(
-				int64(v.LowerBound()),
-				int64(v.UpperBound()),
-			)
-			copyVar.SetName(v.Name())
-		}
+func (m *model) SetVarType(v Var, t VarType) {
+	m.mustBeMutableVar(v)
+	if t < Continuous || t > Binary {
+		panic(fmt.Sprintf("mip: SetVarType with unknown type %d", t))
 	}
+	native := Continuous
+	switch v.(type) {
+	case *intVariable:
+		native = Integer
+	case *boolVariable:
+		native = Binary
+	}
+	if t == native {
+		delete(m.varTypes, v)
+		return
+	}
+	m.varTypes[v] = t
+}
 
-	if m.Objective().IsMaximize() {
-		copyModel.Objective().SetMaximize()
-	} else {
-		copyModel.Objective().SetMinimize()
+func (m *model) getVarType(variable Var, native VarType) VarType {
+	if t, ok := m.varTypes[variable]; ok {
+		return t
 	}
+	return native
+}
 
-	vars := copyModel.Vars()
+func (m *model) setVarGroup(variable Var, group string) {
+	m.mustBeMutable()
+	m.varGroups.set(variable.Index(), group, len(m.vars))
+}
 
-	for _, t := range m.Objective().Terms() {
-		copyModel.Objective().NewTerm(
-			t.Coefficient(),
-			vars[t.Var().Index()],
-		)
-	}
-	for _, c := range m.Constraints() {
-		copyConstraint := copyModel.NewConstraint(
-			c.Sense(),
-			c.RightHandSide(),
-		)
-		for _, t := range c.Terms() {
-			copyConstraint.NewTerm(
-				t.Coefficient(),
-				vars[t.Var().Index()],
-			)
-		}
-		copyConstraint.SetName(c.Name())
-	}
+func (m *model) getVarGroup(variable Var) string {
+	return m.varGroups.get(variable.Index())
+}
 
-	return copyModel
+func (m *model) Constraints() Constraints {
+	constraints := make(Constraints, len(m.constraints))
+
+	copy(constraints, m.constraints)
+
+	return constraints
 }
 
 func (m *model) Objective() Objective {
@@ -166,7 +381,23 @@ func (m *model) Vars() Vars {
 }
 
 func (m *model) NewBool() Bool {
-	b := &boolVariable{
+	b, err := m.NewBoolChecked()
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (m *model) NewBoolChecked() (Bool, error) {
+	if err := m.checkMutable(); err != nil {
+		return nil, err
+	}
+	if err := checkLimit("vars", len(m.vars)+1, m.limits.Vars); err != nil {
+		return nil, err
+	}
+
+	b := m.arena.bool()
+	*b = boolVariable{
 		variable: variable{
 			index: len(m.vars),
 			model: m,
@@ -175,21 +406,39 @@ func (m *model) NewBool() Bool {
 
 	m.vars = append(m.vars, b)
 
-	return b
+	return b, nil
 }
 
 func (m *model) NewFloat(
 	lowerBound float64,
 	upperBound float64,
 ) Float {
-	if math.IsNaN(lowerBound) {
-		panic("lower bound is NaN")
+	f, err := m.NewFloatChecked(lowerBound, upperBound)
+	if err != nil {
+		panic(err)
 	}
-	if math.IsNaN(upperBound) {
-		panic("upper bound is NaN")
+	return f
+}
+
+func (m *model) NewFloatChecked(
+	lowerBound float64,
+	upperBound float64,
+) (Float, error) {
+	if err := m.checkMutable(); err != nil {
+		return nil, err
+	}
+	if err := checkNaN("lower bound", lowerBound); err != nil {
+		return nil, err
+	}
+	if err := checkNaN("upper bound", upperBound); err != nil {
+		return nil, err
+	}
+	if err := checkLimit("vars", len(m.vars)+1, m.limits.Vars); err != nil {
+		return nil, err
 	}
 
-	f := &floatVariable{
+	f := m.arena.float()
+	*f = floatVariable{
 		variable: variable{
 			index: len(m.vars),
 			model: m,
@@ -200,14 +449,43 @@ func (m *model) NewFloat(
 
 	m.vars = append(m.vars, f)
 
-	return f
+	return f, nil
+}
+
+func (m *model) NewFreeFloat() Float {
+	return m.NewFloat(-Infinity(), Infinity())
 }
 
 func (m *model) NewInt(
 	lowerBound int64,
 	upperBound int64,
 ) Int {
-	i := &intVariable{
+	i, err := m.NewIntChecked(lowerBound, upperBound)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func (m *model) NewIntChecked(
+	lowerBound int64,
+	upperBound int64,
+) (Int, error) {
+	if err := m.checkMutable(); err != nil {
+		return nil, err
+	}
+	if err := checkIntBound(lowerBound); err != nil {
+		return nil, err
+	}
+	if err := checkIntBound(upperBound); err != nil {
+		return nil, err
+	}
+	if err := checkLimit("vars", len(m.vars)+1, m.limits.Vars); err != nil {
+		return nil, err
+	}
+
+	i := m.arena.int()
+	*i = intVariable{
 		variable: variable{
 			index: len(m.vars),
 			model: m,
@@ -218,29 +496,51 @@ func (m *model) NewInt(
 
 	m.vars = append(m.vars, i)
 
-	return i
+	return i, nil
 }
 
 func (m *model) NewConstraint(
 	sense Sense,
 	rightHandSide float64,
 ) Constraint {
-	if math.IsNaN(rightHandSide) {
-		panic("right hand side is NaN")
+	c, err := m.NewConstraintChecked(sense, rightHandSide)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (m *model) NewConstraintChecked(
+	sense Sense,
+	rightHandSide float64,
+) (Constraint, error) {
+	if err := m.checkMutable(); err != nil {
+		return nil, err
+	}
+	if err := checkNaN("right hand side", rightHandSide); err != nil {
+		return nil, err
 	}
-	constraint := &constraint{
+	err := checkLimit("constraints", len(m.constraints)+1, m.limits.Constraints)
+	if err != nil {
+		return nil, err
+	}
+	c := m.arena.constraint()
+	*c = constraint{
 		model:         m,
 		rightHandSide: rightHandSide,
 		sense:         sense,
 		terms:         make([]Term, 0),
 	}
 
-	m.constraints = append(m.constraints, constraint)
+	m.constraints = append(m.constraints, c)
 
-	return constraint
+	return c, nil
 }
 
 func (m *model) String() string {
+	if len(m.vars)+len(m.constraints)+m.nonZeros > StringLimit {
+		return m.Summary()
+	}
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "%v\n", m.objective)
 	for i, c := range m.constraints {
@@ -0,0 +1,207 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+// Copy returns a deep copy of the invoking model. The internal slices and
+// maps are copied directly instead of rebuilding the model through its API,
+// terms and constraints are allocated in bulk. Everything attached to the
+// model is copied, including quadratic objective terms, names, groups,
+// fixes, attributes, type changes, params and soft constraints.
+func (m *model) Copy() Model {
+	return m.copyModel(false)
+}
+
+// Fork returns a copy-on-write copy of the invoking model. The terms of the
+// constraints, the bulk of a model, are shared with the fork until either
+// model changes the terms of a constraint, which copies them. Vars,
+// constraints, the objective, names and other attributes are copied as by
+// Copy. Models using an arena are copied, since their terms are released
+// by Free.
+func (m *model) Fork() Model {
+	if m.arena != nil {
+		return m.copyModel(false)
+	}
+	return m.copyModel(true)
+}
+
+// copyModel returns a copy of the invoking model which shares the terms of
+// its constraints if share.
+func (m *model) copyModel(share bool) *model {
+	c := &model{
+		limits:   m.limits,
+		nonZeros: m.nonZeros,
+		float32:  m.float32,
+	}
+
+	c.vars = make(Vars, len(m.vars))
+	for i, v := range m.vars {
+		c.vars[i] = copyVar(v, c)
+	}
+	vars := func(v Var) Var {
+		return c.vars[v.Index()]
+	}
+
+	c.constraints = make(Constraints, len(m.constraints))
+	constraintMap := make(map[Constraint]Constraint, len(m.constraints))
+	total := 0
+	if !share {
+		for _, original := range m.constraints {
+			total += len(original.(*constraint).terms)
+		}
+	}
+	termSlab := make([]term, total)
+	termPointers := make(Terms, total)
+	constraintSlab := make([]constraint, len(m.constraints))
+	for i, original := range m.constraints {
+		o := original.(*constraint)
+		if m.float32 {
+			// Compact terms refer to vars by index and are copied as they are.
+			constraintSlab[i] = constraint{
+				model:         c,
+				compact:       append([]compactTerm(nil), o.compact...),
+				rightHandSide: o.rightHandSide,
+				sense:         o.sense,
+				name:          o.name,
+			}
+			c.constraints[i] = &constraintSlab[i]
+			constraintMap[original] = c.constraints[i]
+			continue
+		}
+		if share {
+			// The terms of a frozen model never change, so it does not need
+			// to know they are shared, and concurrent forks do not write it.
+			if !m.frozen {
+				o.shared = true
+			}
+			constraintSlab[i] = constraint{
+				model:         c,
+				terms:         o.terms[:len(o.terms):len(o.terms)],
+				rightHandSide: o.rightHandSide,
+				sense:         o.sense,
+				name:          o.name,
+				shared:        true,
+			}
+			c.constraints[i] = &constraintSlab[i]
+			constraintMap[original] = c.constraints[i]
+			continue
+		}
+		terms := termPointers[:len(o.terms):len(o.terms)]
+		termPointers = termPointers[len(o.terms):]
+		for j, t := range o.terms {
+			termSlab[j] = term{
+				coefficient: t.Coefficient(),
+				variable:    vars(t.Var()),
+			}
+			terms[j] = &termSlab[j]
+		}
+		termSlab = termSlab[len(o.terms):]
+		constraintSlab[i] = constraint{
+			model:         c,
+			terms:         terms,
+			rightHandSide: o.rightHandSide,
+			sense:         o.sense,
+			name:          o.name,
+		}
+		c.constraints[i] = &constraintSlab[i]
+		constraintMap[original] = c.constraints[i]
+	}
+
+	c.objective = copyObjective(m.objective.(*objective), vars)
+	copyModelMaps(m, c, vars, constraintMap)
+	copyParams(m, c, vars, constraintMap)
+	return c
+}
+
+// copyVar returns a copy of v belonging to model.
+func copyVar(v Var, model *model) Var {
+	switch v := v.(type) {
+	case *intVariable:
+		copied := *v
+		copied.model = model
+		return &copied
+	case *boolVariable:
+		copied := *v
+		copied.model = model
+		return &copied
+	}
+	copied := *v.(*floatVariable)
+	copied.model = model
+	return &copied
+}
+
+// copyObjective returns a copy of o with its vars replaced by vars.
+func copyObjective(o *objective, vars func(Var) Var) *objective {
+	c := &objective{
+		terms:    make(Terms, len(o.terms)),
+		maximize: o.maximize,
+	}
+	terms := make([]term, len(o.terms))
+	for i, t := range o.terms {
+		terms[i] = term{
+			coefficient: t.Coefficient(),
+			variable:    vars(t.Var()),
+		}
+		c.terms[i] = &terms[i]
+	}
+	for _, v := range o.penalties {
+		c.penalties = append(c.penalties, vars(v))
+	}
+	if len(o.quadraticTerms) > 0 {
+		c.quadraticTerms = make(QuadraticTerms, len(o.quadraticTerms))
+		quadraticTerms := make([]quadraticTerm, len(o.quadraticTerms))
+		for i, t := range o.quadraticTerms {
+			quadraticTerms[i] = quadraticTerm{
+				coefficient: t.Coefficient(),
+				variable1:   vars(t.Var1()),
+				variable2:   vars(t.Var2()),
+			}
+			c.quadraticTerms[i] = &quadraticTerms[i]
+		}
+	}
+	return c
+}
+
+// copyModelMaps copies the maps of m keyed by vars and constraints to c.
+func copyModelMaps(
+	m *model,
+	c *model,
+	vars func(Var) Var,
+	constraints map[Constraint]Constraint,
+) {
+	c.varNames = m.varNames.copy()
+	c.varGroups = m.varGroups.copy()
+	c.fixed = make(map[Var]float64, len(m.fixed))
+	for v, value := range m.fixed {
+		c.fixed[vars(v)] = value
+	}
+	c.varTypes = make(map[Var]VarType, len(m.varTypes))
+	for v, t := range m.varTypes {
+		c.varTypes[vars(v)] = t
+	}
+
+	c.soft = make(map[Constraint]softConstraint, len(m.soft))
+	for original, soft := range m.soft {
+		copied := softConstraint{}
+		if soft.under != nil {
+			copied.under = vars(soft.under)
+		}
+		if soft.over != nil {
+			copied.over = vars(soft.over)
+		}
+		c.soft[constraints[original]] = copied
+	}
+
+	c.attributes = make(attributes, len(m.attributes))
+	for entity, values := range m.attributes {
+		copied := make(map[string]any, len(values))
+		for key, value := range values {
+			copied[key] = value
+		}
+		switch entity := entity.(type) {
+		case Var:
+			c.attributes[vars(entity)] = copied
+		case Constraint:
+			c.attributes[constraints[entity]] = copied
+		}
+	}
+}
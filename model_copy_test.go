@@ -0,0 +1,135 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestCopy(t *testing.T) {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	x := model.NewFloat(0, 10)
+	y := model.NewInt(-3, 3)
+	b := model.NewBool()
+	x.SetName("x")
+	y.SetGroup("ints")
+	b.Fix(1)
+	b.SetAttr("customer", 7)
+	model.Objective().NewTerm(2, x)
+	model.Objective().NewQuadraticTerm(3, x, y)
+	c := model.NewConstraint(mip.LessThanOrEqual, 4)
+	c.SetName("capacity")
+	c.SetAttr("shift", "early")
+	c.NewTerm(1, x)
+	c.NewTerm(1, y)
+
+	copied := model.Copy()
+	vars := copied.Vars()
+	constraints := copied.Constraints()
+	if !copied.Objective().IsMaximize() || len(copied.Objective().QuadraticTerms()) != 1 {
+		t.Errorf("objective was not copied: %v", copied.Objective())
+	}
+	if term, _ := copied.Objective().QuadraticTerm(vars[0], vars[1]); term.Coefficient() != 3 {
+		t.Errorf("got quadratic coefficient %v, want 3", term.Coefficient())
+	}
+	if vars[0].Name() != "x" || vars[1].Group() != "ints" || vars[1].LowerBound() != -3 {
+		t.Errorf("vars were not copied")
+	}
+	if value, ok := vars[2].FixedValue(); !ok || value != 1 {
+		t.Errorf("fix was not copied")
+	}
+	if value, _ := vars[2].Attr("customer"); value != 7 {
+		t.Errorf("var attribute was not copied")
+	}
+	if value, _ := constraints[0].Attr("shift"); value != "early" || constraints[0].Name() != "capacity" {
+		t.Errorf("constraint was not copied")
+	}
+	if terms := constraints[0].Terms(); len(terms) != 2 || terms[0].Var() == x || terms[1].Var() == x {
+		t.Errorf("got terms %v, want terms of the copied vars", terms)
+	}
+
+	// Changing the copy does not change the model.
+	constraints[0].SetTerm(vars[0], 5)
+	constraints[0].NewTerm(1, vars[2])
+	vars[0].SetName("copy")
+	copied.Objective().Clear()
+	if term, _ := c.Term(x); term.Coefficient() != 1 || len(c.Terms()) != 2 || x.Name() != "x" {
+		t.Errorf("changing the copy changed the model")
+	}
+	if len(model.Objective().Terms()) != 1 || !model.Objective().IsQuadratic() {
+		t.Errorf("clearing the copied objective changed the model")
+	}
+}
+
+func BenchmarkCopy(b *testing.B) {
+	model, _ := mip.RandomModel(0, mip.RandomModelSpec{
+		Vars:        1000,
+		Constraints: 1000,
+		Density:     0.01,
+	})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		model.Copy()
+	}
+}
+
+func TestFork(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewFloat(0, 10)
+	shared := model.NewConstraint(mip.LessThanOrEqual, 4)
+	shared.NewTerm(1, x)
+	shared.NewTerm(2, y)
+	changed := model.NewConstraint(mip.GreaterThanOrEqual, 1)
+	changed.NewTerm(1, x)
+
+	fork := model.Fork()
+	vars := fork.Vars()
+	constraints := fork.Constraints()
+	for _, term := range constraints[0].Terms() {
+		if term.Var() != vars[term.Var().Index()] {
+			t.Errorf("term of the fork has var %v of the model", term.Var())
+		}
+	}
+
+	constraints[1].SetTerm(vars[0], 3)
+	constraints[1].NewTerm(1, vars[1])
+	vars[0].Fix(2)
+	if term, _ := changed.Term(x); term.Coefficient() != 1 || len(changed.Terms()) != 1 {
+		t.Errorf("changing the fork changed the model: %v", changed)
+	}
+	if _, ok := x.FixedValue(); ok {
+		t.Errorf("fixing a var of the fork fixed the var of the model")
+	}
+
+	shared.RemoveTerm(y)
+	if terms := constraints[0].Terms(); len(terms) != 2 {
+		t.Errorf("changing the model changed the fork: %v", constraints[0])
+	}
+	if term, _ := constraints[1].Term(vars[0]); term.Coefficient() != 3 {
+		t.Errorf("got coefficient %v, want 3", term.Coefficient())
+	}
+
+	// A fork of a fork maps the vars of shared terms to its own.
+	second := fork.Fork()
+	if term := second.Constraints()[0].Terms()[0]; term.Var() != second.Vars()[term.Var().Index()] {
+		t.Errorf("term of the second fork has a var of another model")
+	}
+}
+
+func BenchmarkFork(b *testing.B) {
+	model, _ := mip.RandomModel(0, mip.RandomModelSpec{
+		Vars:        1000,
+		Constraints: 1000,
+		Density:     0.01,
+	})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		model.Fork()
+	}
+}
@@ -0,0 +1,179 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Compression defines how a model or solution file is compressed.
+type Compression int64
+
+// Compression of a file.
+const (
+	// NoCompression is used for plain text files.
+	NoCompression Compression = iota
+	// Gzip is used for files compressed with gzip, extension ".gz".
+	Gzip
+	// Zstd is used for files compressed with Zstandard, extension ".zst".
+	// Zstandard is not part of the standard library and is not supported,
+	// it is recognized to return a meaningful error.
+	Zstd
+)
+
+// ErrUnsupportedCompression is returned for files using a compression which
+// is not supported.
+var ErrUnsupportedCompression = errors.New("unsupported compression")
+
+// CompressionFromPath returns the compression of a file based on the
+// extension of path.
+func CompressionFromPath(path string) Compression {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gz":
+		return Gzip
+	case ".zst":
+		return Zstd
+	default:
+		return NoCompression
+	}
+}
+
+// NewCompressedWriter returns a writer compressing everything written to it
+// with compression before writing it to w. The returned writer must be closed
+// to flush the compressed data, closing it does not close w.
+func NewCompressedWriter(
+	w io.Writer,
+	compression Compression,
+) (io.WriteCloser, error) {
+	switch compression {
+	case NoCompression:
+		return nopWriteCloser{w}, nil
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	default:
+		return nil, ErrUnsupportedCompression
+	}
+}
+
+// NewDecompressingReader returns a reader decompressing everything read from
+// r with compression. Closing the returned reader does not close r.
+func NewDecompressingReader(
+	r io.Reader,
+	compression Compression,
+) (io.ReadCloser, error) {
+	switch compression {
+	case NoCompression:
+		return io.NopCloser(r), nil
+	case Gzip:
+		return gzip.NewReader(r)
+	default:
+		return nil, ErrUnsupportedCompression
+	}
+}
+
+// WriteModelFile writes model to the file at path. The format is derived
+// from the extension of path: ".lp" for WriteLP and ".osil" or ".xml" for
+// WriteOSiL. An additional ".gz" extension compresses the file with gzip,
+// e.g. "instance.lp.gz".
+func WriteModelFile(path string, model Model) (err error) {
+	compression := CompressionFromPath(path)
+	if compression == Zstd {
+		return ErrUnsupportedCompression
+	}
+	write, err := modelWriter(modelFileFormat(path, compression))
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	w, err := NewCompressedWriter(file, compression)
+	if err != nil {
+		return err
+	}
+	if err := write(w, model); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// ReadModelFile reads a model from the file at path. The format is derived
+// from the extension of path: ".lp" for ReadLP, ".osil" or ".xml" for
+// ReadOSiL, ".nl" for ReadNL and ".fzn" for ReadFlatZinc. An additional
+// ".gz" extension decompresses the file with gzip, e.g. "instance.lp.gz".
+func ReadModelFile(path string) (Model, error) {
+	compression := CompressionFromPath(path)
+	read, err := modelReader(modelFileFormat(path, compression))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r, err := NewDecompressingReader(file, compression)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return read(r)
+}
+
+// modelFileFormat returns the lower case extension of path identifying the
+// format of the file, ignoring the extension of the compression.
+func modelFileFormat(path string, compression Compression) string {
+	if compression != NoCompression {
+		path = strings.TrimSuffix(path, filepath.Ext(path))
+	}
+	return strings.ToLower(filepath.Ext(path))
+}
+
+func modelWriter(format string) (func(io.Writer, Model) error, error) {
+	switch format {
+	case ".lp":
+		return WriteLP, nil
+	case ".osil", ".xml":
+		return WriteOSiL, nil
+	default:
+		return nil, fmt.Errorf("unsupported model file format %q for writing", format)
+	}
+}
+
+func modelReader(format string) (func(io.Reader) (Model, error), error) {
+	switch format {
+	case ".lp":
+		return ReadLP, nil
+	case ".osil", ".xml":
+		return ReadOSiL, nil
+	case ".nl":
+		return ReadNL, nil
+	case ".fzn":
+		return ReadFlatZinc, nil
+	default:
+		return nil, fmt.Errorf("unsupported model file format %q for reading", format)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,53 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestModelFile(t *testing.T) {
+	model, err := mip.ReadLP(strings.NewReader(testLP))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for _, name := range []string{"model.lp", "model.lp.gz", "model.osil.gz"} {
+		path := filepath.Join(dir, name)
+		if err := mip.WriteModelFile(path, model); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gzipped := len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b
+		if gzipped != strings.HasSuffix(name, ".gz") {
+			t.Errorf("%s: gzip compressed = %v", name, gzipped)
+		}
+
+		read, err := mip.ReadModelFile(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if mip.ModelHash(read) != mip.ModelHash(model) {
+			t.Errorf("%s: model changed in round trip", name)
+		}
+	}
+
+	err = mip.WriteModelFile(filepath.Join(dir, "model.lp.zst"), model)
+	if !errors.Is(err, mip.ErrUnsupportedCompression) {
+		t.Errorf("got error %v, want %v", err, mip.ErrUnsupportedCompression)
+	}
+	if err := mip.WriteModelFile(filepath.Join(dir, "model.mps"), model); err == nil {
+		t.Errorf("expected error for unsupported format")
+	}
+}
@@ -0,0 +1,94 @@
+// © 2019-present nextmv.io inc
+
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// Assignment is a model assigning tasks to agents at minimum cost.
+type Assignment struct {
+	// Model to solve.
+	Model mip.Model
+	// X is 1 if the agent (row) is assigned to the task (column).
+	X mip.VarMatrix[int, int, mip.Bool]
+}
+
+// NewAssignment creates an assignment model for costs, where costs[i][j] is
+// the cost of assigning agent i to task j. Each task is assigned to exactly
+// one agent and each agent to at most one task, so there must be at least as
+// many agents as tasks for the model to be feasible.
+func NewAssignment(costs [][]float64) (*Assignment, error) {
+	agents := len(costs)
+	if agents == 0 {
+		return nil, errors.New("assignment: no agents")
+	}
+	tasks := len(costs[0])
+	for i, row := range costs {
+		if len(row) != tasks {
+			return nil, fmt.Errorf(
+				"assignment: agent %d has %d costs, want %d",
+				i,
+				len(row),
+				tasks,
+			)
+		}
+	}
+
+	model := mip.NewModel()
+	x := mip.NewVarMatrix(
+		indices(agents),
+		indices(tasks),
+		func(i, j int) mip.Bool {
+			v := model.NewBool()
+			v.SetName(fmt.Sprintf("x[%d][%d]", i, j))
+			model.Objective().NewTerm(costs[i][j], v)
+			return v
+		},
+	)
+
+	mip.ForEach(model, "agent", x.Rows(), func(i int) mip.ConstraintSpec {
+		return sumSpec(mip.LessThanOrEqual, 1, x.Row(i))
+	})
+	mip.ForEach(model, "task", x.Cols(), func(j int) mip.ConstraintSpec {
+		return sumSpec(mip.Equal, 1, x.Col(j))
+	})
+
+	return &Assignment{Model: model, X: x}, nil
+}
+
+// indices returns 0, 1, ..., n-1.
+func indices(n int) []int {
+	result := make([]int, n)
+	for i := range result {
+		result[i] = i
+	}
+	return result
+}
+
+// sumSpec specifies a constraint on the sum of vars.
+func sumSpec(sense mip.Sense, rhs float64, vars mip.Vars) mip.ConstraintSpec {
+	return weightedSumSpec(sense, rhs, vars, func(int) float64 { return 1 })
+}
+
+// weightedSumSpec specifies a constraint on the sum of vars weighted by the
+// coefficient of their position.
+func weightedSumSpec(
+	sense mip.Sense,
+	rhs float64,
+	vars mip.Vars,
+	coefficient func(i int) float64,
+) mip.ConstraintSpec {
+	spec := mip.ConstraintSpec{
+		Sense:         sense,
+		RightHandSide: rhs,
+		Terms:         make([]mip.TermSpec, len(vars)),
+	}
+	for i, v := range vars {
+		spec.Terms[i] = mip.TermSpec{Coefficient: coefficient(i), Var: v}
+	}
+	return spec
+}
@@ -0,0 +1,57 @@
+// © 2019-present nextmv.io inc
+
+package models
+
+import (
+	"fmt"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// BinPacking is a model packing items into a minimum number of bins.
+type BinPacking struct {
+	// Model to solve.
+	Model mip.Model
+	// X is 1 if the item (row) is packed into the bin (column).
+	X mip.VarMatrix[int, int, mip.Bool]
+	// Y is 1 if the bin is used.
+	Y mip.VarMap[int, mip.Bool]
+}
+
+// NewBinPacking creates a bin packing model for items with sizes and bins of
+// capacity. There is one bin per item. Returns an error if an item does not
+// fit into a bin.
+func NewBinPacking(sizes []float64, capacity float64) (*BinPacking, error) {
+	for i, size := range sizes {
+		if size > capacity {
+			return nil, fmt.Errorf("bin packing: item %d exceeds the capacity", i)
+		}
+	}
+	items := indices(len(sizes))
+
+	model := mip.NewModel()
+	y := mip.NewVarMap(items, func(b int) mip.Bool {
+		v := model.NewBool()
+		v.SetName(fmt.Sprintf("y[%d]", b))
+		model.Objective().NewTerm(1, v)
+		return v
+	})
+	x := mip.NewVarMatrix(items, items, func(i, b int) mip.Bool {
+		v := model.NewBool()
+		v.SetName(fmt.Sprintf("x[%d][%d]", i, b))
+		return v
+	})
+
+	mip.ForEach(model, "item", items, func(i int) mip.ConstraintSpec {
+		return sumSpec(mip.Equal, 1, x.Row(i))
+	})
+	mip.ForEach(model, "bin", items, func(b int) mip.ConstraintSpec {
+		spec := weightedSumSpec(mip.LessThanOrEqual, 0, x.Col(b), func(i int) float64 {
+			return sizes[i]
+		})
+		spec.Terms = append(spec.Terms, mip.TermSpec{Coefficient: -capacity, Var: y.Get(b)})
+		return spec
+	})
+
+	return &BinPacking{Model: model, X: x, Y: y}, nil
+}
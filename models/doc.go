@@ -0,0 +1,8 @@
+// © 2019-present nextmv.io inc
+
+// Package models generates models of standard optimization problems, such as
+// assignment, knapsack, set covering and partitioning, bin packing and lot
+// sizing. Each generator returns the model together with its decision vars.
+// The models are useful to test back-ends and as starting points for models
+// with additional side constraints.
+package models
@@ -0,0 +1,49 @@
+// © 2019-present nextmv.io inc
+
+package models
+
+import (
+	"fmt"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// Knapsack is a model selecting items of maximum value whose weight does
+// not exceed a capacity.
+type Knapsack struct {
+	// Model to solve.
+	Model mip.Model
+	// X is 1 if the item is selected.
+	X mip.VarMap[int, mip.Bool]
+	// Capacity is the knapsack constraint.
+	Capacity mip.Constraint
+}
+
+// NewKnapsack creates a 0-1 knapsack model for items with values and weights
+// and a knapsack of capacity.
+func NewKnapsack(values, weights []float64, capacity float64) (*Knapsack, error) {
+	if len(values) != len(weights) {
+		return nil, fmt.Errorf(
+			"knapsack: %d values and %d weights",
+			len(values),
+			len(weights),
+		)
+	}
+
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	x := mip.NewVarMap(indices(len(values)), func(i int) mip.Bool {
+		v := model.NewBool()
+		v.SetName(fmt.Sprintf("x[%d]", i))
+		model.Objective().NewTerm(values[i], v)
+		return v
+	})
+
+	c := model.NewConstraint(mip.LessThanOrEqual, capacity)
+	c.SetName("capacity")
+	x.Each(func(i int, v mip.Bool) {
+		c.NewTerm(weights[i], v)
+	})
+
+	return &Knapsack{Model: model, X: x, Capacity: c}, nil
+}
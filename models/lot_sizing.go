@@ -0,0 +1,110 @@
+// © 2019-present nextmv.io inc
+
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// LotSizing is a single item lot sizing model, deciding in which periods to
+// produce to satisfy demand at minimum setup and holding costs.
+type LotSizing struct {
+	// Model to solve.
+	Model mip.Model
+	// Produce is the quantity produced in the period.
+	Produce mip.VarMap[int, mip.Float]
+	// Inventory is the stock at the end of the period.
+	Inventory mip.VarMap[int, mip.Float]
+	// Setup is 1 if the item is produced in the period.
+	Setup mip.VarMap[int, mip.Bool]
+}
+
+// LotSizingInput is the input of NewLotSizing, with one entry per period.
+type LotSizingInput struct {
+	// Demand per period.
+	Demand []float64
+	// SetupCosts per period, incurred when producing.
+	SetupCosts []float64
+	// HoldingCosts per unit in stock at the end of a period.
+	HoldingCosts []float64
+	// Capacity of production per period, 0 for uncapacitated lot sizing.
+	Capacity float64
+}
+
+// NewLotSizing creates a lot sizing model for input. The inventory is empty
+// at the start.
+func NewLotSizing(input LotSizingInput) (*LotSizing, error) {
+	periods := len(input.Demand)
+	if periods == 0 {
+		return nil, errors.New("lot sizing: no periods")
+	}
+	if len(input.SetupCosts) != periods || len(input.HoldingCosts) != periods {
+		return nil, fmt.Errorf("lot sizing: costs must be given for %d periods", periods)
+	}
+
+	// Without a capacity production is bounded by the remaining demand.
+	remaining := make([]float64, periods+1)
+	for t := periods - 1; t >= 0; t-- {
+		remaining[t] = remaining[t+1] + input.Demand[t]
+	}
+	bigM := func(t int) float64 {
+		if input.Capacity > 0 {
+			return input.Capacity
+		}
+		return remaining[t]
+	}
+
+	model := mip.NewModel()
+	ts := indices(periods)
+	produce := mip.NewVarMap(ts, func(t int) mip.Float {
+		v := model.NewFloat(0, bigM(t))
+		v.SetName(fmt.Sprintf("produce[%d]", t))
+		return v
+	})
+	inventory := mip.NewVarMap(ts, func(t int) mip.Float {
+		v := model.NewFloat(0, mip.Infinity())
+		v.SetName(fmt.Sprintf("inventory[%d]", t))
+		model.Objective().NewTerm(input.HoldingCosts[t], v)
+		return v
+	})
+	setup := mip.NewVarMap(ts, func(t int) mip.Bool {
+		v := model.NewBool()
+		v.SetName(fmt.Sprintf("setup[%d]", t))
+		model.Objective().NewTerm(input.SetupCosts[t], v)
+		return v
+	})
+
+	mip.ForEach(model, "balance", ts, func(t int) mip.ConstraintSpec {
+		spec := mip.ConstraintSpec{
+			Sense:         mip.Equal,
+			RightHandSide: input.Demand[t],
+			Terms: []mip.TermSpec{
+				{Coefficient: 1, Var: produce.Get(t)},
+				{Coefficient: -1, Var: inventory.Get(t)},
+			},
+		}
+		if t > 0 {
+			spec.Terms = append(spec.Terms, mip.TermSpec{Coefficient: 1, Var: inventory.Get(t - 1)})
+		}
+		return spec
+	})
+	mip.ForEach(model, "setup", ts, func(t int) mip.ConstraintSpec {
+		return mip.ConstraintSpec{
+			Sense: mip.LessThanOrEqual,
+			Terms: []mip.TermSpec{
+				{Coefficient: 1, Var: produce.Get(t)},
+				{Coefficient: -bigM(t), Var: setup.Get(t)},
+			},
+		}
+	})
+
+	return &LotSizing{
+		Model:     model,
+		Produce:   produce,
+		Inventory: inventory,
+		Setup:     setup,
+	}, nil
+}
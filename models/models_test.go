@@ -0,0 +1,121 @@
+// © 2019-present nextmv.io inc
+
+package models_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+	"github.com/nextmv-io/go-mip/models"
+)
+
+// evaluate checks that assignment is feasible for model and has the wanted
+// objective value.
+func evaluate(t *testing.T, model mip.Model, assignment map[mip.Var]float64, want float64) {
+	t.Helper()
+	evaluation := mip.Evaluate(model, assignment)
+	if violated := evaluation.Violated(1e-9); len(violated) > 0 {
+		t.Errorf("violated constraints: %v", violated[0].Constraint.Name())
+	}
+	if evaluation.ObjectiveValue != want {
+		t.Errorf("objective value = %v, want %v", evaluation.ObjectiveValue, want)
+	}
+}
+
+func TestAssignment(t *testing.T) {
+	a, err := models.NewAssignment([][]float64{{4, 1}, {2, 3}, {5, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Model.Vars()) != 6 || len(a.Model.Constraints()) != 5 {
+		t.Fatalf("unexpected model size\n%v", a.Model)
+	}
+	evaluate(t, a.Model, map[mip.Var]float64{a.X.Get(0, 1): 1, a.X.Get(1, 0): 1}, 3)
+
+	if _, err := models.NewAssignment([][]float64{{1, 2}, {3}}); err == nil {
+		t.Error("expected error for ragged costs")
+	}
+}
+
+func TestKnapsack(t *testing.T) {
+	k, err := models.NewKnapsack([]float64{10, 7, 3}, []float64{5, 4, 1}, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !k.Model.Objective().IsMaximize() {
+		t.Error("expected maximization")
+	}
+	evaluate(t, k.Model, map[mip.Var]float64{k.X.Get(0): 1, k.X.Get(2): 1}, 13)
+	evaluation := mip.Evaluate(k.Model, map[mip.Var]float64{k.X.Get(0): 1, k.X.Get(1): 1})
+	if evaluation.TotalViolation != 3 {
+		t.Errorf("total violation = %v, want 3", evaluation.TotalViolation)
+	}
+}
+
+func TestSetCover(t *testing.T) {
+	costs := []float64{1, 1, 3}
+	sets := [][]int{{0, 1}, {1, 2}, {0, 1, 2}}
+
+	covering, err := models.NewSetCovering(costs, sets, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluate(t, covering.Model, map[mip.Var]float64{covering.X.Get(0): 1, covering.X.Get(1): 1}, 2)
+
+	partitioning, err := models.NewSetPartitioning(costs, sets, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluation := mip.Evaluate(
+		partitioning.Model,
+		map[mip.Var]float64{partitioning.X.Get(0): 1, partitioning.X.Get(1): 1},
+	)
+	if len(evaluation.Violated(0)) != 1 {
+		t.Errorf("expected element 1 to be covered twice")
+	}
+	evaluate(t, partitioning.Model, map[mip.Var]float64{partitioning.X.Get(2): 1}, 3)
+
+	if _, err := models.NewSetCovering(costs, [][]int{{0}, {1}, {3}}, 3); err == nil {
+		t.Error("expected error for invalid element")
+	}
+}
+
+func TestBinPacking(t *testing.T) {
+	b, err := models.NewBinPacking([]float64{4, 3, 2}, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluate(t, b.Model, map[mip.Var]float64{
+		b.X.Get(0, 0): 1,
+		b.X.Get(2, 0): 1,
+		b.X.Get(1, 1): 1,
+		b.Y.Get(0):    1,
+		b.Y.Get(1):    1,
+	}, 2)
+
+	if _, err := models.NewBinPacking([]float64{7}, 6); err == nil {
+		t.Error("expected error for oversized item")
+	}
+}
+
+func TestLotSizing(t *testing.T) {
+	l, err := models.NewLotSizing(models.LotSizingInput{
+		Demand:       []float64{3, 2, 4},
+		SetupCosts:   []float64{10, 10, 10},
+		HoldingCosts: []float64{1, 1, 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluate(t, l.Model, map[mip.Var]float64{
+		l.Produce.Get(0):   5,
+		l.Inventory.Get(0): 2,
+		l.Setup.Get(0):     1,
+		l.Produce.Get(2):   4,
+		l.Setup.Get(2):     1,
+	}, 22)
+
+	if _, err := models.NewLotSizing(models.LotSizingInput{Demand: []float64{1}}); err == nil {
+		t.Error("expected error for missing costs")
+	}
+}
@@ -0,0 +1,66 @@
+// © 2019-present nextmv.io inc
+
+package models
+
+import (
+	"fmt"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// SetCover is a model selecting sets of minimum cost covering all elements.
+type SetCover struct {
+	// Model to solve.
+	Model mip.Model
+	// X is 1 if the set is selected.
+	X mip.VarMap[int, mip.Bool]
+}
+
+// NewSetCovering creates a set covering model: select sets of minimum total
+// costs such that each of the elements 0, ..., elements-1 is contained in at
+// least one selected set. sets[i] holds the elements of set i.
+func NewSetCovering(costs []float64, sets [][]int, elements int) (*SetCover, error) {
+	return newSetCover(costs, sets, elements, mip.GreaterThanOrEqual)
+}
+
+// NewSetPartitioning creates a set partitioning model: select sets of
+// minimum total costs such that each of the elements 0, ..., elements-1 is
+// contained in exactly one selected set. sets[i] holds the elements of set
+// i.
+func NewSetPartitioning(costs []float64, sets [][]int, elements int) (*SetCover, error) {
+	return newSetCover(costs, sets, elements, mip.Equal)
+}
+
+func newSetCover(
+	costs []float64,
+	sets [][]int,
+	elements int,
+	sense mip.Sense,
+) (*SetCover, error) {
+	if len(costs) != len(sets) {
+		return nil, fmt.Errorf("set cover: %d costs and %d sets", len(costs), len(sets))
+	}
+	containing := make([]mip.Vars, elements)
+
+	model := mip.NewModel()
+	x := mip.NewVarMap(indices(len(sets)), func(i int) mip.Bool {
+		v := model.NewBool()
+		v.SetName(fmt.Sprintf("x[%d]", i))
+		model.Objective().NewTerm(costs[i], v)
+		return v
+	})
+	for i, set := range sets {
+		for _, element := range set {
+			if element < 0 || element >= elements {
+				return nil, fmt.Errorf("set cover: set %d has invalid element %d", i, element)
+			}
+			containing[element] = append(containing[element], x.Get(i))
+		}
+	}
+
+	mip.ForEach(model, "element", indices(elements), func(e int) mip.ConstraintSpec {
+		return sumSpec(sense, 1, containing[e])
+	})
+
+	return &SetCover{Model: model, X: x}, nil
+}
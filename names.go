@@ -0,0 +1,93 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "fmt"
+
+// names are the names or groups of vars by index. The slice is allocated
+// when the first name is set, so models without names pay nothing, and a
+// name takes a string header instead of a map entry keyed by an interface
+// value. Unset names are empty, String of a var generates its name on
+// demand.
+type names struct {
+	values []string
+	// interned are the distinct values if values are interned, nil
+	// otherwise.
+	interned map[string]string
+}
+
+// newInternedNames returns names which store each distinct value once, for
+// values shared by many vars such as groups.
+func newInternedNames() names {
+	return names{interned: make(map[string]string)}
+}
+
+// get returns the value of index.
+func (n *names) get(index int) string {
+	if index < len(n.values) {
+		return n.values[index]
+	}
+	return ""
+}
+
+// set sets the value of index, growing the values to count, the number of
+// vars, if needed.
+func (n *names) set(index int, value string, count int) {
+	if index >= len(n.values) {
+		if value == "" {
+			return
+		}
+		n.values = append(n.values, make([]string, count-len(n.values))...)
+	}
+	n.values[index] = n.intern(value)
+}
+
+// intern returns the stored copy of value if the invoking names are
+// interned, value otherwise.
+func (n *names) intern(value string) string {
+	if n.interned == nil || value == "" {
+		return value
+	}
+	if interned, ok := n.interned[value]; ok {
+		return interned
+	}
+	n.interned[value] = value
+	return value
+}
+
+// copy returns a copy of the invoking names.
+func (n *names) copy() names {
+	c := names{values: append([]string(nil), n.values...)}
+	if n.interned != nil {
+		c.interned = make(map[string]string, len(n.interned))
+		for value := range n.interned {
+			c.interned[value] = value
+		}
+	}
+	return c
+}
+
+func (m *model) SetVarNames(names []string) {
+	m.mustBeMutable()
+	if len(names) > len(m.vars) {
+		panic(fmt.Sprintf("mip: SetVarNames with %d names for %d vars", len(names), len(m.vars)))
+	}
+	if len(m.varNames.values) < len(m.vars) {
+		m.varNames.values = append(m.varNames.values, make([]string, len(m.vars)-len(m.varNames.values))...)
+	}
+	copy(m.varNames.values, names)
+}
+
+func (m *model) SetConstraintNames(names []string) {
+	m.mustBeMutable()
+	if len(names) > len(m.constraints) {
+		panic(fmt.Sprintf(
+			"mip: SetConstraintNames with %d names for %d constraints",
+			len(names),
+			len(m.constraints),
+		))
+	}
+	for i, name := range names {
+		m.constraints[i].(*constraint).name = name
+	}
+}
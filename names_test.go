@@ -0,0 +1,68 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestSetNames(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 1)
+	y := model.NewInt(0, 1)
+	z := model.NewBool()
+	c0 := model.NewConstraint(mip.LessThanOrEqual, 1)
+	c1 := model.NewConstraint(mip.Equal, 0)
+
+	if x.Name() != "" || fmt.Sprint(x) != "F0" {
+		t.Errorf("got name %q printed as %v, want no name printed as F0", x.Name(), x)
+	}
+
+	model.SetVarNames([]string{"x", "y"})
+	model.SetConstraintNames([]string{"capacity", "balance"})
+	if x.Name() != "x" || y.Name() != "y" || z.Name() != "" {
+		t.Errorf("got names %q, %q and %q, want x, y and none", x.Name(), y.Name(), z.Name())
+	}
+	if c0.Name() != "capacity" || c1.Name() != "balance" {
+		t.Errorf("got constraint names %q and %q", c0.Name(), c1.Name())
+	}
+
+	z.SetName("z")
+	z.SetGroup("flags")
+	y.SetGroup("flags")
+	late := model.NewFloat(0, 1)
+	late.SetGroup("late")
+	copied := model.Copy()
+	x.SetName("changed")
+	if names := fmt.Sprint(copied.Vars()); names != "[x y z F3]" {
+		t.Errorf("got copied vars %v, want [x y z F3]", names)
+	}
+	if copied.Vars()[2].Group() != "flags" || copied.Vars()[3].Group() != "late" || copied.Vars()[0].Group() != "" {
+		t.Errorf("got copied groups %q, %q and %q",
+			copied.Vars()[0].Group(), copied.Vars()[2].Group(), copied.Vars()[3].Group())
+	}
+	if copied.Constraints()[1].Name() != "balance" {
+		t.Errorf("got copied constraint name %q, want balance", copied.Constraints()[1].Name())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("got no panic for more names than vars")
+		}
+	}()
+	model.SetVarNames(make([]string, 5))
+}
+
+func BenchmarkSetName(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		model := mip.NewModel()
+		for j := 0; j < 10_000; j++ {
+			v := model.NewFloat(0, 1)
+			v.SetName("x")
+			v.SetGroup("group")
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidNetwork is returned by AddFlowConservation if the arcs and flow
+// vars do not match, a node ID is not unique or an arc references a node
+// which is not given.
+var ErrInvalidNetwork = errors.New("invalid network")
+
+// FlowNode is a node of a flow network.
+type FlowNode[N comparable] struct {
+	// ID of the node.
+	ID N
+	// Supply of the node, the flow leaving minus the flow entering it.
+	// Negative for demand nodes, 0 for transshipment nodes.
+	Supply float64
+}
+
+// FlowArc is a directed arc of a flow network.
+type FlowArc[N comparable] struct {
+	// From is the ID of the node the flow leaves.
+	From N
+	// To is the ID of the node the flow enters.
+	To N
+}
+
+// AddFlowConservation adds a flow conservation constraint per node to model,
+// the flow vars of the arcs leaving the node minus the ones entering it
+// equal its supply. flows holds the flow var of each arc. Returns the
+// constraints by node ID and an error wrapping ErrInvalidNetwork if the
+// number of arcs and flows differs, a node ID is given twice or an arc
+// references an unknown node. No constraints are added on error.
+//
+//	nodes := []mip.FlowNode[string]{
+//		{ID: "plant", Supply: 10},
+//		{ID: "hub"},
+//		{ID: "store", Supply: -10},
+//	}
+//	arcs := []mip.FlowArc[string]{
+//		{From: "plant", To: "hub"},
+//		{From: "hub", To: "store"},
+//	}
+//	flows := mip.Vars{model.NewFloat(0, 20), model.NewFloat(0, 20)}
+//	balance, err := mip.AddFlowConservation(model, nodes, arcs, flows)
+func AddFlowConservation[N comparable](
+	model Model,
+	nodes []FlowNode[N],
+	arcs []FlowArc[N],
+	flows Vars,
+) (map[N]Constraint, error) {
+	if len(arcs) != len(flows) {
+		return nil, fmt.Errorf(
+			"%w: %d arcs and %d flows",
+			ErrInvalidNetwork,
+			len(arcs),
+			len(flows),
+		)
+	}
+	constraints := make(map[N]Constraint, len(nodes))
+	for _, node := range nodes {
+		if _, ok := constraints[node.ID]; ok {
+			return nil, fmt.Errorf(
+				"%w: duplicate node %v",
+				ErrInvalidNetwork,
+				node.ID,
+			)
+		}
+		constraints[node.ID] = nil
+	}
+	for i, arc := range arcs {
+		_, from := constraints[arc.From]
+		_, to := constraints[arc.To]
+		if !from || !to {
+			return nil, fmt.Errorf(
+				"%w: arc %d from %v to %v references an unknown node",
+				ErrInvalidNetwork,
+				i,
+				arc.From,
+				arc.To,
+			)
+		}
+	}
+
+	for _, node := range nodes {
+		constraints[node.ID] = model.NewConstraint(Equal, node.Supply)
+	}
+	for i, arc := range arcs {
+		constraints[arc.From].NewTerm(1, flows[i])
+		constraints[arc.To].NewTerm(-1, flows[i])
+	}
+	return constraints, nil
+}
+
+// AddCapacity adds a constraint to model limiting the sum of flows to
+// capacity, e.g. the throughput of a node or the flow of parallel arcs. If
+// open is not nil the capacity is only available if open is 1, the
+// constraint is sum(flows) <= capacity * open.
+func AddCapacity(model Model, flows Vars, capacity float64, open Bool) Constraint {
+	rightHandSide := capacity
+	if open != nil {
+		rightHandSide = 0
+	}
+	c := model.NewConstraint(LessThanOrEqual, rightHandSide)
+	for _, flow := range flows {
+		c.NewTerm(1, flow)
+	}
+	if open != nil {
+		c.NewTerm(-capacity, open)
+	}
+	return c
+}
+
+// IsNetwork returns true if the constraints of model form a pure network
+// matrix up to negating rows: all coefficients are 1 or -1, every var has at
+// most two terms and the constraints can be split into two sets such that
+// negating the ones in one set leaves every var with at most one term of
+// each sign. Such models, e.g. min-cost flow, transportation and assignment
+// models, have integral vertices for integral right-hand sides and bounds
+// and can be solved by network simplex algorithms.
+func IsNetwork(model Model) bool {
+	constraints := model.Constraints()
+	sides := newParityUnion(len(constraints))
+	for _, column := range unimodularColumns(constraints, len(model.Vars())) {
+		if !sides.addColumn(column) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+	"github.com/nextmv-io/go-mip/models"
+)
+
+func TestAddFlowConservation(t *testing.T) {
+	model := mip.NewModel()
+	nodes := []mip.FlowNode[string]{
+		{ID: "plant", Supply: 10},
+		{ID: "hub"},
+		{ID: "store", Supply: -10},
+	}
+	arcs := []mip.FlowArc[string]{
+		{From: "plant", To: "hub"},
+		{From: "hub", To: "store"},
+		{From: "plant", To: "store"},
+	}
+	flows := mip.Vars{
+		model.NewFloat(0, 20),
+		model.NewFloat(0, 20),
+		model.NewFloat(0, 5),
+	}
+
+	balance, err := mip.AddFlowConservation(model, nodes, arcs, flows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"plant": "1 F0 + 1 F2 = 10",
+		"hub":   "-1 F0 + 1 F1 = 0",
+		"store": "-1 F1 + -1 F2 = -10",
+	}
+	for id, c := range balance {
+		if got := fmt.Sprint(c); got != want[id] {
+			t.Errorf("node %s: got %q, want %q", id, got, want[id])
+		}
+	}
+	if !mip.IsNetwork(model) {
+		t.Errorf("flow conservation model is not a network")
+	}
+
+	open := model.NewBool()
+	capacity := mip.AddCapacity(model, flows[1:], 12, open)
+	if got, want := fmt.Sprint(capacity), "1 F1 + 1 F2 + -12 B3 <= 0"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if mip.IsNetwork(model) {
+		t.Errorf("model with capacity is a network")
+	}
+
+	_, err = mip.AddFlowConservation(model, nodes, arcs[:2], flows)
+	if !errors.Is(err, mip.ErrInvalidNetwork) {
+		t.Errorf("got error %v, want %v", err, mip.ErrInvalidNetwork)
+	}
+	_, err = mip.AddFlowConservation(model, nodes[:2], arcs, flows)
+	if !errors.Is(err, mip.ErrInvalidNetwork) {
+		t.Errorf("got error %v, want %v", err, mip.ErrInvalidNetwork)
+	}
+
+	constraints := len(model.Constraints())
+	duplicate := append(nodes, mip.FlowNode[string]{ID: "hub", Supply: 3})
+	_, err = mip.AddFlowConservation(model, duplicate, arcs, flows)
+	if !errors.Is(err, mip.ErrInvalidNetwork) {
+		t.Errorf("got error %v, want %v", err, mip.ErrInvalidNetwork)
+	}
+	if got := len(model.Constraints()); got != constraints {
+		t.Errorf("got %d constraints after error, want %d", got, constraints)
+	}
+}
+
+func TestIsNetworkNegatedRows(t *testing.T) {
+	assignment, err := models.NewAssignment([][]float64{{4, 1}, {2, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mip.IsNetwork(assignment.Model) {
+		t.Errorf("assignment model is not a network")
+	}
+
+	// x + y, y + z and x + z form an odd cycle, no negation of rows gives
+	// every var one term of each sign.
+	model := mip.NewModel()
+	x, y, z := model.NewFloat(0, 1), model.NewFloat(0, 1), model.NewFloat(0, 1)
+	for _, pair := range [][2]mip.Var{{x, y}, {y, z}, {x, z}} {
+		c := model.NewConstraint(mip.LessThanOrEqual, 1)
+		c.NewTerm(1, pair[0])
+		c.NewTerm(1, pair[1])
+	}
+	if mip.IsNetwork(model) {
+		t.Errorf("odd cycle is a network")
+	}
+}
@@ -0,0 +1,402 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// nlHeaderLines is the number of lines of the header of an NL file.
+const nlHeaderLines = 10
+
+// ReadNL reads a model in the text variant of the AMPL NL format from r, as
+// written by AMPL or Pyomo. Only the linear parts of a model are supported;
+// an error is returned for nonlinear expressions, imported functions, defined
+// variables, complementarity constraints, multiple objectives, constants in
+// the objective and the binary variant of the format. Vars and constraints
+// are not named, as names are stored in separate files. Ranged constraints
+// with different lower and upper bounds are added as two constraints and
+// free constraints are not added. An error is returned for NaN numbers.
+func ReadNL(r io.Reader) (Model, error) {
+	reader := &nlReader{scanner: bufio.NewScanner(r)}
+	reader.scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024*1024)
+	if err := reader.readHeader(); err != nil {
+		return nil, err
+	}
+	if err := reader.readSegments(); err != nil {
+		return nil, err
+	}
+	return reader.build()
+}
+
+// nlRangeLengths maps the kind of a range to the number of numbers on its
+// line, including the kind. Kind 5 are complementarity constraints.
+var nlRangeLengths = map[int]int{0: 3, 1: 2, 2: 2, 3: 1, 4: 2}
+
+type nlRange struct {
+	kind  int
+	lower float64
+	upper float64
+}
+
+type nlReader struct {
+	scanner     *bufio.Scanner
+	line        int
+	vars        int
+	constraints int
+	binaries    int
+	integers    int
+	maximize    bool
+	constants   []float64
+	ranges      []nlRange
+	bounds      []nlRange
+	jacobian    []map[int]float64
+	gradient    map[int]float64
+}
+
+func (r *nlReader) errorf(format string, args ...any) error {
+	return fmt.Errorf("nl: line %d: %s", r.line, fmt.Sprintf(format, args...))
+}
+
+// next returns the next line without a trailing comment.
+func (r *nlReader) next() (string, bool) {
+	if !r.scanner.Scan() {
+		return "", false
+	}
+	r.line++
+	line := r.scanner.Text()
+	if i := strings.IndexByte(line, '#'); i >= 0 {
+		line = line[:i]
+	}
+	return strings.TrimSpace(line), true
+}
+
+// nextNumbers returns the numbers on the next line.
+func (r *nlReader) nextNumbers() ([]float64, error) {
+	line, ok := r.next()
+	if !ok {
+		return nil, fmt.Errorf("nl: unexpected end of input")
+	}
+	return r.numbers(strings.Fields(line))
+}
+
+// numbers parses fields, infinite numbers are allowed, NaN is not.
+func (r *nlReader) numbers(fields []string) ([]float64, error) {
+	numbers := make([]float64, len(fields))
+	for i, field := range fields {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil || math.IsNaN(value) {
+			return nil, r.errorf("invalid number %q", field)
+		}
+		numbers[i] = value
+	}
+	return numbers, nil
+}
+
+func (r *nlReader) readHeader() error {
+	header := make([][]float64, nlHeaderLines)
+	for i := range header {
+		line, ok := r.next()
+		if !ok {
+			return fmt.Errorf("nl: incomplete header")
+		}
+		if i == 0 {
+			if !strings.HasPrefix(line, "g") {
+				return r.errorf("only the text variant of the NL format is supported")
+			}
+			continue
+		}
+		numbers, err := r.numbers(strings.Fields(line))
+		if err != nil {
+			return err
+		}
+		header[i] = numbers
+	}
+	if len(header[1]) < 3 || len(header[2]) < 2 || len(header[4]) < 3 ||
+		len(header[5]) < 2 || len(header[6]) < 2 {
+		return fmt.Errorf("nl: malformed header")
+	}
+
+	r.vars, r.constraints = int(header[1][0]), int(header[1][1])
+	if header[1][2] > 1 {
+		return fmt.Errorf("nl: multiple objectives are not supported")
+	}
+	if header[2][0] > 0 || header[2][1] > 0 ||
+		header[4][0] > 0 || header[4][1] > 0 || header[4][2] > 0 {
+		return fmt.Errorf("nl: nonlinear models are not supported")
+	}
+	if header[5][1] > 0 {
+		return fmt.Errorf("nl: imported functions are not supported")
+	}
+	r.binaries, r.integers = int(header[6][0]), int(header[6][1])
+
+	r.constants = make([]float64, r.constraints)
+	r.ranges = make([]nlRange, r.constraints)
+	for i := range r.ranges {
+		r.ranges[i] = nlRange{kind: 3, lower: math.Inf(-1), upper: math.Inf(1)}
+	}
+	r.jacobian = make([]map[int]float64, r.constraints)
+	r.bounds = make([]nlRange, r.vars)
+	for i := range r.bounds {
+		r.bounds[i] = nlRange{kind: 0, lower: 0, upper: math.Inf(1)}
+	}
+	r.gradient = make(map[int]float64)
+	return nil
+}
+
+func (r *nlReader) readSegments() error {
+	for {
+		line, ok := r.next()
+		if !ok {
+			return r.scanner.Err()
+		}
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line[1:])
+		if line[0] == 'S' && len(fields) > 2 {
+			// Suffix segments end with the name of the suffix.
+			fields = fields[:2]
+		}
+		args, err := r.numbers(fields)
+		if err != nil {
+			return err
+		}
+		switch line[0] {
+		case 'C':
+			err = r.readConstraintExpression(args)
+		case 'O':
+			err = r.readObjectiveExpression(args)
+		case 'r':
+			err = r.readRanges(r.ranges)
+		case 'b':
+			err = r.readRanges(r.bounds)
+		case 'J':
+			err = r.readLinearPart(args, true)
+		case 'G':
+			err = r.readLinearPart(args, false)
+		case 'x', 'd', 'k':
+			err = r.skipLines(args)
+		case 'S':
+			if len(args) < 2 {
+				return r.errorf("malformed suffix segment")
+			}
+			err = r.skipLines(args[1:])
+		case 'F', 'V', 'L':
+			return r.errorf("segment %q is not supported", line[:1])
+		default:
+			return r.errorf("unknown segment %q", line[:1])
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (r *nlReader) index(args []float64, n int, what string) (int, error) {
+	if len(args) < 1 || args[0] < 0 || int(args[0]) >= n {
+		return 0, r.errorf("invalid %s index", what)
+	}
+	return int(args[0]), nil
+}
+
+// readConstant reads an expression which must be a numeric constant.
+func (r *nlReader) readConstant() (float64, error) {
+	line, ok := r.next()
+	if !ok {
+		return 0, fmt.Errorf("nl: unexpected end of input")
+	}
+	if line == "" || strings.IndexByte("nsl", line[0]) < 0 {
+		return 0, r.errorf("nonlinear expressions are not supported")
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(line[1:]), 64)
+	if err != nil || math.IsNaN(value) {
+		return 0, r.errorf("invalid constant %q", line)
+	}
+	return value, nil
+}
+
+func (r *nlReader) readConstraintExpression(args []float64) error {
+	i, err := r.index(args, r.constraints, "constraint")
+	if err != nil {
+		return err
+	}
+	r.constants[i], err = r.readConstant()
+	return err
+}
+
+func (r *nlReader) readObjectiveExpression(args []float64) error {
+	if _, err := r.index(args, 1, "objective"); err != nil {
+		return err
+	}
+	r.maximize = len(args) > 1 && args[1] == 1
+	constant, err := r.readConstant()
+	if err != nil {
+		return err
+	}
+	if constant != 0 {
+		return r.errorf("constants in the objective are not supported")
+	}
+	return nil
+}
+
+// readRanges reads one range per element of ranges, as found in the "r" and
+// "b" segments.
+func (r *nlReader) readRanges(ranges []nlRange) error {
+	for i := range ranges {
+		numbers, err := r.nextNumbers()
+		if err != nil {
+			return err
+		}
+		if len(numbers) == 0 {
+			return r.errorf("missing range")
+		}
+		rng := nlRange{
+			kind:  int(numbers[0]),
+			lower: math.Inf(-1),
+			upper: math.Inf(1),
+		}
+		expected := nlRangeLengths[rng.kind]
+		if expected == 0 {
+			return r.errorf("complementarity constraints are not supported")
+		}
+		if len(numbers) != expected {
+			return r.errorf("malformed range")
+		}
+		switch rng.kind {
+		case 0:
+			rng.lower, rng.upper = numbers[1], numbers[2]
+		case 1:
+			rng.upper = numbers[1]
+		case 2:
+			rng.lower = numbers[1]
+		case 4:
+			rng.lower, rng.upper = numbers[1], numbers[1]
+		}
+		ranges[i] = rng
+	}
+	return nil
+}
+
+func (r *nlReader) readLinearPart(args []float64, constraint bool) error {
+	n := 1
+	what := "objective"
+	if constraint {
+		n = r.constraints
+		what = "constraint"
+	}
+	i, err := r.index(args, n, what)
+	if err != nil {
+		return err
+	}
+	if len(args) < 2 {
+		return r.errorf("missing number of terms")
+	}
+	coefficients := r.gradient
+	if constraint {
+		coefficients = make(map[int]float64, int(args[1]))
+		r.jacobian[i] = coefficients
+	}
+	for k := 0; k < int(args[1]); k++ {
+		numbers, err := r.nextNumbers()
+		if err != nil {
+			return err
+		}
+		if len(numbers) != 2 || numbers[0] < 0 || int(numbers[0]) >= r.vars {
+			return r.errorf("malformed linear term")
+		}
+		coefficients[int(numbers[0])] += numbers[1]
+	}
+	return nil
+}
+
+// skipLines skips the number of lines given by the first argument.
+func (r *nlReader) skipLines(args []float64) error {
+	if len(args) < 1 {
+		return r.errorf("missing number of lines")
+	}
+	for k := 0; k < int(args[0]); k++ {
+		if _, ok := r.next(); !ok {
+			return fmt.Errorf("nl: unexpected end of input")
+		}
+	}
+	return nil
+}
+
+func (r *nlReader) build() (Model, error) {
+	model := NewModel()
+	vars := make(Vars, r.vars)
+	firstBinary := r.vars - r.binaries - r.integers
+	for j := range vars {
+		bound := r.bounds[j]
+		var err error
+		switch {
+		case j >= firstBinary+r.binaries:
+			vars[j], err = model.NewIntChecked(intLowerBound(bound.lower), intUpperBound(bound.upper))
+		case j >= firstBinary:
+			vars[j], err = model.NewBoolChecked()
+		default:
+			vars[j], err = model.NewFloatChecked(bound.lower, bound.upper)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("nl: var %d: %w", j, err)
+		}
+	}
+
+	if r.maximize {
+		model.Objective().SetMaximize()
+	}
+	for _, j := range sortedKeys(r.gradient) {
+		if _, err := model.Objective().NewTermChecked(r.gradient[j], vars[j]); err != nil {
+			return nil, fmt.Errorf("nl: objective: %w", err)
+		}
+	}
+
+	for i, rng := range r.ranges {
+		constant := r.constants[i]
+		newConstraint := func(sense Sense, rhs float64) error {
+			c, err := model.NewConstraintChecked(sense, rhs-constant)
+			if err != nil {
+				return err
+			}
+			for _, j := range sortedKeys(r.jacobian[i]) {
+				if _, err := c.NewTermChecked(r.jacobian[i][j], vars[j]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		var err error
+		switch {
+		case rng.lower == rng.upper:
+			err = newConstraint(Equal, rng.lower)
+		default:
+			if !math.IsInf(rng.lower, -1) {
+				err = newConstraint(GreaterThanOrEqual, rng.lower)
+			}
+			if err == nil && !math.IsInf(rng.upper, 1) {
+				err = newConstraint(LessThanOrEqual, rng.upper)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("nl: constraint %d: %w", i, err)
+		}
+	}
+	return model, nil
+}
+
+// sortedKeys returns the keys of coefficients in increasing order.
+func sortedKeys(coefficients map[int]float64) []int {
+	keys := make([]int, 0, len(coefficients))
+	for key := range coefficients {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,124 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+const testNL = `g3 1 1 0	# problem test
+ 3 3 1 1 0	# vars, constraints, objectives, ranges, eqns
+ 0 0	# nonlinear constraints, objectives
+ 0 0	# network constraints: nonlinear, linear
+ 0 0 0	# nonlinear vars in constraints, objectives, both
+ 0 0 0 1	# linear network variables; functions; arith, flags
+ 1 1 0 0 0	# discrete variables: binary, integer, nonlinear (b,c,o)
+ 6 3	# nonzeros in Jacobian, gradients
+ 0 0	# max name lengths: constraints, variables
+ 0 0 0 0 0	# common exprs: b,c,o,c1,o1
+C0
+n0
+C1
+n1
+C2
+n0
+O0 1
+n0
+S0 1 priority
+0 1
+r
+2 1
+1 4
+0 2 3
+b
+0 0 10
+0 0 1
+0 0 5
+k2
+2
+4
+J0 2
+0 1
+1 1
+J1 2
+1 1
+2 1
+J2 2
+0 1
+2 -1
+G0 3
+0 1
+1 2
+2 3
+`
+
+func TestReadNL(t *testing.T) {
+	model, err := mip.ReadNL(strings.NewReader(testNL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `maximize   1 F0 + 2 B1 + 3 I2
+      0: 1 F0 + 1 B1 >= 1
+      1: 1 B1 + 1 I2 <= 3
+      2: 1 F0 + -1 I2 >= 2
+      3: 1 F0 + -1 I2 <= 3
+      0: F0 [0, 10]
+      1: B1 [0, 1]
+      2: I2 [0, 5]
+`
+	if got := model.(fmt.Stringer).String(); got != want {
+		t.Errorf("got\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestReadNLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		nl   string
+	}{
+		{
+			name: "binary format",
+			nl:   strings.Replace(testNL, "g3", "b3", 1),
+		},
+		{
+			name: "nonlinear header",
+			nl:   strings.Replace(testNL, " 0 0\t# nonlinear constraints", " 1 0\t# nonlinear constraints", 1),
+		},
+		{
+			name: "nonlinear expression",
+			nl:   strings.Replace(testNL, "C1\nn1", "C1\no2", 1),
+		},
+		{
+			name: "objective constant",
+			nl:   strings.Replace(testNL, "O0 1\nn0", "O0 1\nn5", 1),
+		},
+		{
+			name: "NaN bound",
+			nl:   strings.Replace(testNL, "b\n0 0 10", "b\n0 nan 1", 1),
+		},
+		{
+			name: "NaN coefficient",
+			nl:   strings.Replace(testNL, "G0 3\n0 1", "G0 3\n0 NaN", 1),
+		},
+		{
+			name: "NaN constant",
+			nl:   strings.Replace(testNL, "C1\nn1", "C1\nnNaN", 1),
+		},
+		{
+			name: "truncated",
+			nl:   testNL[:len(testNL)-10],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := mip.ReadNL(strings.NewReader(tt.nl)); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
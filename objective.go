@@ -4,7 +4,6 @@ package mip
 
 import (
 	"fmt"
-	"math"
 	"sort"
 	"strings"
 )
@@ -19,6 +18,10 @@ import (
 //
 // 2.5 * x and 3.5 * y are 2 terms in this example.
 type Objective interface {
+	// Clear removes all linear and quadratic terms from the invoking
+	// objective, e.g. to replace the objective of a feasibility phase by the
+	// cost of the next phase. The sense is not changed.
+	Clear()
 	// IsLinear returns true if the invoking objective is a linear function.
 	IsLinear() bool
 	// IsMaximize returns true if the invoking objective is a maximization
@@ -37,6 +40,9 @@ type Objective interface {
 	// 		m.Objective().NewTerm(1.0, x)		// results in: maximize 1.0 * x
 	// 		m.Objective().NewTerm(2.0, x)		// results in: maximize 3.0 * x
 	NewTerm(coefficient float64, variable Var) Term
+	// NewTermChecked adds a term like NewTerm but returns an error wrapping
+	// ErrNaN instead of panicking if coefficient is NaN.
+	NewTermChecked(coefficient float64, variable Var) (Term, error)
 	// NewQuadraticTerm adds a new quadratic term to the invoking objective,
 	// invoking this API multiple times for the same variables will take the sum
 	// of coefficients of earlier added terms for that variable.
@@ -54,10 +60,24 @@ type Objective interface {
 	//      m.Objective().NewQuadraticTerm(1.0, x2, x1)
 	//      // results in: maximize 1.0 * x1^2 + 2.0 * x1x2
 	NewQuadraticTerm(coefficient float64, variable1, variable2 Var) QuadraticTerm
+	// NewQuadraticTermChecked adds a quadratic term like NewQuadraticTerm but
+	// returns an error wrapping ErrNaN instead of panicking if coefficient is
+	// NaN.
+	NewQuadraticTermChecked(
+		coefficient float64,
+		variable1, variable2 Var,
+	) (QuadraticTerm, error)
 	// SetMaximize sets the invoking objective to be a maximization objective.
 	SetMaximize()
 	// SetMinimize sets the invoking objective to be a minimization objective.
 	SetMinimize()
+	// SetTerms replaces the linear terms of the invoking objective by the
+	// terms coefficients[i] * vars[i]. Quadratic terms are not changed.
+	// Panics if the lengths of coefficients and vars differ or a coefficient
+	// is NaN, the objective is not changed in that case.
+	//
+	// 		m.Objective().SetTerms(costs, x)	// results in: minimize costs * x
+	SetTerms(coefficients []float64, vars Vars)
 	// Term returns a term for a given variable together with the sum of the
 	// coefficients of all terms referencing that variable. The second return
 	// argument defines how many terms have been defined on the objective for
@@ -83,26 +103,132 @@ type Objective interface {
 	QuadraticTerms() QuadraticTerms
 }
 
+// NewObjective creates an objective which is not the objective of a model,
+// e.g. one of the objectives of ParetoFrontier. The objective minimizes
+// unless set to maximize.
+//
+//	cost := mip.NewObjective()
+//	for _, arc := range arcs {
+//		cost.NewTerm(arc.Cost, x.Get(arc))
+//	}
+func NewObjective() Objective {
+	return &objective{
+		terms: make(Terms, 0),
+	}
+}
+
 type objective struct {
 	terms          Terms
 	quadraticTerms QuadraticTerms
 	maximize       bool
+	// penalties are the violation vars of soft constraints, whose
+	// coefficients are negated when the direction changes, see
+	// Constraint.MakeSoft.
+	penalties Vars
+	// frozen is true if the objective belongs to a frozen model, see
+	// Model.Freeze.
+	frozen bool
+}
+
+// checkMutable returns ErrFrozen if the invoking objective is frozen.
+func (o *objective) checkMutable() error {
+	if o.frozen {
+		return ErrFrozen
+	}
+	return nil
+}
+
+// mustBeMutable panics with ErrFrozen if the invoking objective is frozen.
+func (o *objective) mustBeMutable() {
+	if o.frozen {
+		panic(ErrFrozen)
+	}
+}
+
+func (o *objective) Clear() {
+	o.mustBeMutable()
+	o.terms = make(Terms, 0)
+	o.quadraticTerms = nil
+}
+
+func (o *objective) SetTerms(coefficients []float64, vars Vars) {
+	o.mustBeMutable()
+	if len(coefficients) != len(vars) {
+		panic(fmt.Sprintf(
+			"objective terms have %d coefficients and %d vars",
+			len(coefficients),
+			len(vars),
+		))
+	}
+	terms := make(Terms, len(vars))
+	for i, v := range vars {
+		if err := checkNaN("objective term coefficient", coefficients[i]); err != nil {
+			panic(err)
+		}
+		terms[i] = &term{
+			coefficient: coefficients[i],
+			variable:    v,
+		}
+	}
+	o.terms = terms
 }
 
 func (o *objective) SetMaximize() {
-	o.maximize = true
+	o.mustBeMutable()
+	o.setMaximize(true)
 }
 
 func (o *objective) SetMinimize() {
-	o.maximize = false
+	o.mustBeMutable()
+	o.setMaximize(false)
+}
+
+// setMaximize sets the direction of the invoking objective. The
+// coefficients of the violation vars of soft constraints are negated if the
+// direction changes, so violations remain penalized.
+func (o *objective) setMaximize(maximize bool) {
+	if o.maximize == maximize {
+		return
+	}
+	o.maximize = maximize
+	if len(o.penalties) == 0 {
+		return
+	}
+	penalties := make(map[int]bool, len(o.penalties))
+	for _, v := range o.penalties {
+		penalties[v.Index()] = true
+	}
+	for i, t := range o.terms {
+		if penalties[t.Var().Index()] {
+			// Terms may be shared with copies, they are replaced.
+			o.terms[i] = &term{
+				coefficient: -t.Coefficient(),
+				variable:    t.Var(),
+			}
+		}
+	}
 }
 
 func (o *objective) NewTerm(
 	coefficient float64,
 	variable Var,
 ) Term {
-	if math.IsNaN(coefficient) {
-		panic("objective term coefficient is NaN")
+	t, err := o.NewTermChecked(coefficient, variable)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (o *objective) NewTermChecked(
+	coefficient float64,
+	variable Var,
+) (Term, error) {
+	if err := checkNaN("objective term coefficient", coefficient); err != nil {
+		return nil, err
+	}
+	if err := o.checkMutable(); err != nil {
+		return nil, err
 	}
 
 	term := &term{
@@ -112,7 +238,7 @@ func (o *objective) NewTerm(
 
 	o.terms = append(o.terms, term)
 
-	return term
+	return term, nil
 }
 
 func (o *objective) NewQuadraticTerm(
@@ -120,15 +246,32 @@ func (o *objective) NewQuadraticTerm(
 	variable1 Var,
 	variable2 Var,
 ) QuadraticTerm {
-	if math.IsNaN(coefficient) {
-		panic("constraint quadratic term coefficient is NaN")
+	t, err := o.NewQuadraticTermChecked(coefficient, variable1, variable2)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (o *objective) NewQuadraticTermChecked(
+	coefficient float64,
+	variable1 Var,
+	variable2 Var,
+) (QuadraticTerm, error) {
+	err := checkNaN("objective quadratic term coefficient", coefficient)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.checkMutable(); err != nil {
+		return nil, err
 	}
 
 	term := newQuadraticTerm(coefficient, variable1, variable2)
 
 	o.quadraticTerms = append(o.quadraticTerms, term)
 
-	return term
+	return term, nil
 }
 
 func (o *objective) IsMaximize() bool {
@@ -0,0 +1,148 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrNotPseudoBoolean is returned by WriteOPB for models which are not
+// pseudo-Boolean, see IsPseudoBoolean.
+var ErrNotPseudoBoolean = errors.New("model is not pseudo-Boolean")
+
+// ErrViolatedConstraint is returned by WriteOPB for a constraint without
+// terms which is violated, the OPB format has no constraints without terms.
+var ErrViolatedConstraint = errors.New("constraint without terms is violated")
+
+// IsPseudoBoolean returns true if model only has bool vars with integral
+// fixed values, a linear objective and integral coefficients and right-hand
+// sides, so it can be solved by pseudo-Boolean and MaxSAT solvers, see
+// WriteOPB.
+func IsPseudoBoolean(model Model) bool {
+	for _, v := range model.Vars() {
+		if !v.IsBool() {
+			return false
+		}
+		if value, ok := v.FixedValue(); ok && !isIntegral(value) {
+			return false
+		}
+	}
+	if !model.Objective().IsLinear() || !integralTerms(model.Objective().Terms()) {
+		return false
+	}
+	for _, c := range model.Constraints() {
+		if !isIntegral(c.RightHandSide()) || !integralTerms(c.Terms()) {
+			return false
+		}
+	}
+	return true
+}
+
+func integralTerms(terms Terms) bool {
+	for _, t := range terms {
+		if !isIntegral(t.Coefficient()) {
+			return false
+		}
+	}
+	return true
+}
+
+func isIntegral(value float64) bool {
+	return value == math.Trunc(value) && math.Abs(value) <= MaxIntBound
+}
+
+// WriteOPB writes model to w in the OPB format of the pseudo-Boolean
+// competitions. The var with index i is written as x<i+1>. The objective is
+// minimized, a maximization objective is negated, so the objective value is
+// the negated optimum. Less-than-or-equal constraints are negated to
+// greater-than-or-equal ones and fixed vars are written as equality
+// constraints. Constraints without terms are not written if they hold,
+// ErrViolatedConstraint is returned if they do not. Returns
+// ErrNotPseudoBoolean if model is not pseudo-Boolean, see IsPseudoBoolean.
+func WriteOPB(w io.Writer, model Model) error {
+	if !IsPseudoBoolean(model) {
+		return ErrNotPseudoBoolean
+	}
+	writer := bufio.NewWriter(w)
+	vars := model.Vars()
+	constraints := model.Constraints()
+
+	count := 0
+	for i, c := range constraints {
+		switch {
+		case len(c.Terms()) > 0:
+			count++
+		case constraintViolation(c, 0) > 0:
+			return fmt.Errorf("%w: %s", ErrViolatedConstraint, constraintLabel(c, i))
+		}
+	}
+	for _, v := range vars {
+		if _, ok := v.FixedValue(); ok {
+			count++
+		}
+	}
+	fmt.Fprintf(writer, "* #variable= %d #constraint= %d\n", len(vars), count)
+	fmt.Fprintln(writer, "* written by go-mip")
+
+	if terms := sortedTerms(model.Objective().Terms()); len(terms) > 0 {
+		sign := 1.0
+		if model.Objective().IsMaximize() {
+			sign = -1.0
+		}
+		fmt.Fprintf(writer, "min: %s ;\n", opbTerms(terms, sign))
+	}
+
+	for _, c := range constraints {
+		sign, operator := 1.0, ">="
+		switch c.Sense() {
+		case LessThanOrEqual:
+			sign = -1.0
+		case Equal:
+			operator = "="
+		}
+		terms := sortedTerms(c.Terms())
+		if len(terms) == 0 {
+			continue
+		}
+		fmt.Fprintf(
+			writer,
+			"%s %s %s ;\n",
+			opbTerms(terms, sign),
+			operator,
+			formatOPBNumber(sign*c.RightHandSide()),
+		)
+	}
+	for _, v := range vars {
+		if value, ok := v.FixedValue(); ok {
+			fmt.Fprintf(writer, "+1 x%d = %s ;\n", v.Index()+1, formatOPBNumber(value))
+		}
+	}
+	return writer.Flush()
+}
+
+// opbTerms formats terms with their coefficients multiplied by sign.
+func opbTerms(terms Terms, sign float64) string {
+	formatted := make([]string, len(terms))
+	for i, t := range terms {
+		coefficient := sign * t.Coefficient()
+		number := formatOPBNumber(coefficient)
+		if coefficient >= 0 {
+			number = "+" + number
+		}
+		formatted[i] = fmt.Sprintf("%s x%d", number, t.Var().Index()+1)
+	}
+	return strings.Join(formatted, " ")
+}
+
+func formatOPBNumber(value float64) string {
+	if value == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,71 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestIsPseudoBoolean(t *testing.T) {
+	tests := map[string]func(model mip.Model){
+		"int var": func(model mip.Model) {
+			model.NewInt(0, 3)
+		},
+		"fractional coefficient": func(model mip.Model) {
+			c := model.NewConstraint(mip.LessThanOrEqual, 1)
+			c.NewTerm(0.5, model.NewBool())
+		},
+		"fractional right-hand side": func(model mip.Model) {
+			c := model.NewConstraint(mip.LessThanOrEqual, 1.5)
+			c.NewTerm(1, model.NewBool())
+		},
+		"fractional fixed value": func(model mip.Model) {
+			model.NewBool().Fix(0.5)
+		},
+		"quadratic objective": func(model mip.Model) {
+			x := model.NewBool()
+			model.Objective().NewQuadraticTerm(1, x, x)
+		},
+	}
+	for name, build := range tests {
+		t.Run(name, func(t *testing.T) {
+			model := mip.NewModel()
+			build(model)
+			if mip.IsPseudoBoolean(model) {
+				t.Errorf("model is pseudo-Boolean")
+			}
+			if err := mip.WriteOPB(io.Discard, model); !errors.Is(err, mip.ErrNotPseudoBoolean) {
+				t.Errorf("got error %v, want %v", err, mip.ErrNotPseudoBoolean)
+			}
+		})
+	}
+	if !mip.IsPseudoBoolean(mip.NewModel()) {
+		t.Errorf("empty model is not pseudo-Boolean")
+	}
+}
+
+func TestWriteOPBConstraintsWithoutTerms(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewBool()
+	model.NewConstraint(mip.GreaterThanOrEqual, 1).NewTerm(1, x)
+	model.NewConstraint(mip.LessThanOrEqual, 1)
+	var buffer bytes.Buffer
+	if err := mip.WriteOPB(&buffer, model); err != nil {
+		t.Fatal(err)
+	}
+	want := "* #variable= 1 #constraint= 1\n* written by go-mip\n+1 x1 >= 1 ;\n"
+	if got := buffer.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	model.NewConstraint(mip.GreaterThanOrEqual, 1).SetName("infeasible")
+	err := mip.WriteOPB(io.Discard, model)
+	if !errors.Is(err, mip.ErrViolatedConstraint) {
+		t.Errorf("got error %v, want %v", err, mip.ErrViolatedConstraint)
+	}
+}
@@ -5,6 +5,8 @@ package mip
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"strconv"
 	"strings"
 	"time"
@@ -13,14 +15,76 @@ import (
 // SolveOptions are options that can be configured for any solver.
 type SolveOptions struct {
 	// Duration is the maximum duration of the solver. A duration limit of 0 is
-	// treated as infinity.
+	// treated as infinity. If BuildDuration is set, Duration only limits the
+	// optimization and not the translation of the model to the back-end.
 	Duration time.Duration `json:"duration" usage:"Maximum duration of the solver." default:"30s"`
+	// BuildDuration is the maximum duration of translating the model to the
+	// back-end, see BuildTimer. A duration limit of 0 means the translation
+	// is not limited separately.
+	BuildDuration time.Duration `json:"build_duration" usage:"Maximum duration of translating the model to the solver, 0 to not limit it separately." default:"0s"`
 	// Verbosity of the solver in the console.
 	Verbosity Verbosity `json:"verbosity" usage:"{off, low, medium, high} Verbosity of the solver in the console." default:"off"`
+	// Seed is the random seed handed to the solver. Providers that do not
+	// support seeding ignore it.
+	Seed int `json:"seed" usage:"Random seed of the solver, ignored by providers that do not support seeding." default:"0"`
+	// Deterministic configures the solver to produce reproducible results:
+	// a fixed seed and deterministic parallelism. Only providers which are
+	// known to honor it accept it, see CheckDeterministic.
+	Deterministic bool `json:"deterministic" usage:"Configure the solver to produce reproducible results, rejected by providers which can not guarantee them." default:"false"`
+	// FeasibilityOnly stops the solve at the first feasible solution. The
+	// objective is ignored, back-ends translate the model without it and
+	// apply FeasibilityOnlyControlOptions.
+	FeasibilityOnly bool `json:"feasibility_only" usage:"Stop at the first feasible solution, ignoring the objective." default:"false"`
+	// Limits on the work of the solver.
+	Limits LimitOptions `json:"limits" usage:"Limits on the work of the solver."`
+	// Tolerances of the solver.
+	Tolerances ToleranceOptions `json:"tolerances" usage:"Numerical tolerances of the solver."`
+	// Stop are criteria to stop the solve before optimality is proven.
+	Stop StopOptions `json:"stop" usage:"Criteria to stop the solve before optimality is proven."`
 	// MIP-specific options.
 	MIP MIPOptions `json:"mip" usage:"Options specific to MIP problems. Linear problems do not use these options."`
 	// Control options for the specific solver.
 	Control ControlOptions `json:"control" usage:"Options to control a specific solver, as defined by the provider."`
+	// LogWriter receives the log of the solver instead of the console, nil
+	// to log to the console. Use NewLogRecordWriter to turn the log into
+	// structured records.
+	LogWriter io.Writer `json:"-" flag:""`
+	// Logger receives the lifecycle events of a solve, see SolveLogger. Nil
+	// to not log lifecycle events.
+	Logger *slog.Logger `json:"-" flag:""`
+}
+
+// SetDeterministic configures the solver to produce reproducible results.
+func (o *SolveOptions) SetDeterministic(deterministic bool) {
+	o.Deterministic = deterministic
+}
+
+// SetFeasibilityOnly stops the solve at the first feasible solution,
+// ignoring the objective, e.g. to check whether any valid roster exists.
+func (o *SolveOptions) SetFeasibilityOnly(feasibilityOnly bool) {
+	o.FeasibilityOnly = feasibilityOnly
+}
+
+// SetStopOnObjective stops the solve as soon as an incumbent with an
+// objective value at least as good as value is found.
+func (o *SolveOptions) SetStopOnObjective(value float64) {
+	o.Stop.Objective = &value
+}
+
+// SetStopOnBound stops the solve as soon as the best bound proves that no
+// solution is better than value.
+func (o *SolveOptions) SetStopOnBound(value float64) {
+	o.Stop.Bound = &value
+}
+
+// SetLogWriter sets the writer receiving the log of the solver.
+func (o *SolveOptions) SetLogWriter(w io.Writer) {
+	o.LogWriter = w
+}
+
+// SetLogger sets the logger receiving the lifecycle events of a solve.
+func (o *SolveOptions) SetLogger(logger *slog.Logger) {
+	o.Logger = logger
 }
 
 // MIPOptions are options specific to MIP problems. LP problems do not use
@@ -28,6 +92,93 @@ type SolveOptions struct {
 type MIPOptions struct {
 	// Gap stopping criteria.
 	Gap GapOptions `json:"gap" usage:"Gap stopping criteria."`
+	// KnownBound are bounds on the objective value known beforehand.
+	KnownBound KnownBoundOptions `json:"known_bound" usage:"Bounds on the objective value known beforehand."`
+}
+
+// KnownBoundOptions specify bounds on the objective value which are known
+// from domain knowledge or a previous run. Back-ends use them to prune the
+// search tree earlier. A bound which is not valid can make the solver cut off
+// the optimal solution or report a feasible model as infeasible. Bounds are
+// not available as flags, as they are typically set programmatically.
+type KnownBoundOptions struct {
+	// Primal is the objective value of a known feasible solution. The solver
+	// only searches for solutions which are at least as good, i.e. it is used
+	// as a cutoff. Nil if unknown.
+	Primal *float64 `json:"primal,omitempty" flag:""`
+	// Dual is a bound on the objective value no solution can improve on,
+	// e.g. the best bound of a previous run on the same model. Nil if
+	// unknown.
+	Dual *float64 `json:"dual,omitempty" flag:""`
+}
+
+// SetPrimal sets the objective value of a known feasible solution.
+func (o *KnownBoundOptions) SetPrimal(value float64) {
+	o.Primal = &value
+}
+
+// SetDual sets a bound on the objective value no solution can improve on.
+func (o *KnownBoundOptions) SetDual(value float64) {
+	o.Dual = &value
+}
+
+// StopOptions are criteria to stop a solve as soon as a good enough solution
+// is found, e.g. one within 2% of the best bound or better than the solution
+// of yesterday, instead of proving optimality. Back-ends stop the solve if
+// StopReached returns true for their progress. Criteria are not available as
+// flags, as they are typically set programmatically.
+type StopOptions struct {
+	// Objective stops the solve as soon as an incumbent with an objective
+	// value at least as good is found. Nil to not stop on the objective.
+	Objective *float64 `json:"objective,omitempty" flag:""`
+	// Bound stops the solve as soon as the best bound proves no solution is
+	// better, i.e. the best bound is at least as bad. Nil to not stop on the
+	// bound.
+	Bound *float64 `json:"bound,omitempty" flag:""`
+}
+
+// StopReached returns true if progress satisfies one of the invoking
+// criteria. Maximize is true if the objective is maximized.
+func (o StopOptions) StopReached(progress Progress, maximize bool) bool {
+	better := func(a, b float64) bool {
+		if maximize {
+			return a >= b
+		}
+		return a <= b
+	}
+	if o.Objective != nil && progress.HasIncumbent && better(progress.Incumbent, *o.Objective) {
+		return true
+	}
+	return o.Bound != nil && better(*o.Bound, progress.BestBound)
+}
+
+// LimitOptions bound the work of a solver independently of the time it
+// takes, for reproducible benchmarks and bounded compute. A limit of 0 means
+// no limit. Each limit maps to the corresponding parameter of the back-end,
+// see LimitControlOptions.
+type LimitOptions struct {
+	// Nodes is the maximum number of branch-and-bound nodes.
+	Nodes int `json:"nodes" usage:"Maximum number of branch-and-bound nodes, 0 for no limit." default:"0"`
+	// Iterations is the maximum number of simplex iterations.
+	Iterations int `json:"iterations" usage:"Maximum number of simplex iterations, 0 for no limit." default:"0"`
+	// Solutions is the maximum number of improving solutions found.
+	Solutions int `json:"solutions" usage:"Maximum number of improving solutions found, 0 for no limit." default:"0"`
+}
+
+// ToleranceOptions are the numerical tolerances of a solver. A tolerance of 0
+// leaves the default of the solver in place. Each tolerance maps to the
+// corresponding parameter of the back-end, see ToleranceControlOptions.
+type ToleranceOptions struct {
+	// Integrality is the maximum distance of the value of an integer var to
+	// the nearest integer for it to be considered integral.
+	Integrality float64 `json:"integrality" usage:"Maximum distance of the value of an integer variable to the nearest integer for it to be considered integral, 0 for the solver default." default:"0"`
+	// PrimalFeasibility is the maximum violation of a constraint or bound.
+	PrimalFeasibility float64 `json:"primal_feasibility" usage:"Maximum violation of a constraint or bound, 0 for the solver default." default:"0"`
+	// DualFeasibility is the maximum violation of a reduced cost.
+	DualFeasibility float64 `json:"dual_feasibility" usage:"Maximum violation of a reduced cost, 0 for the solver default." default:"0"`
+	// Optimality is the relative duality gap at which an interior point
+	// method terminates.
+	Optimality float64 `json:"optimality" usage:"Relative duality gap at which an interior point method terminates, 0 for the solver default." default:"0"`
 }
 
 // GapOptions specifies the gap stopping criteria.
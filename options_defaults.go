@@ -0,0 +1,157 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"reflect"
+	"sync"
+)
+
+// defaultSolveOptions holds the default options per provider and the
+// listeners notified when they change.
+var defaultSolveOptions = struct {
+	sync.RWMutex
+	options   map[SolverProvider]SolveOptions
+	listeners []defaultSolveOptionsListener
+	nextID    int
+}{
+	options: make(map[SolverProvider]SolveOptions),
+}
+
+type defaultSolveOptionsListener struct {
+	id int
+	f  func(provider SolverProvider, options SolveOptions)
+}
+
+// SetDefaultSolveOptions sets the default options of provider. Solvers
+// created by NewSolver apply the defaults of their provider at the time of
+// every solve to the options which are not set, see SolveOptions.WithDefaults.
+// Defaults can be updated at runtime, e.g. from a configuration service, to
+// adjust time limits or gaps of all applications without redeploying them.
+// Listeners registered with OnDefaultSolveOptionsChange are invoked after the
+// defaults have been updated. It is safe to invoke SetDefaultSolveOptions
+// concurrently with the other functions managing defaults.
+func SetDefaultSolveOptions(provider SolverProvider, options SolveOptions) {
+	defaultSolveOptions.Lock()
+	defaultSolveOptions.options[provider] = options
+	listeners := make(
+		[]defaultSolveOptionsListener,
+		len(defaultSolveOptions.listeners),
+	)
+	copy(listeners, defaultSolveOptions.listeners)
+	defaultSolveOptions.Unlock()
+
+	for _, listener := range listeners {
+		listener.f(provider, options)
+	}
+}
+
+// DefaultSolveOptions returns the default options of provider. The second
+// return value is false if no defaults have been set for provider.
+func DefaultSolveOptions(provider SolverProvider) (SolveOptions, bool) {
+	defaultSolveOptions.RLock()
+	defer defaultSolveOptions.RUnlock()
+	options, ok := defaultSolveOptions.options[provider]
+	return options, ok
+}
+
+// OnDefaultSolveOptionsChange registers f to be invoked every time the
+// default options of a provider are set. Listeners are invoked in the order
+// of registration on the goroutine invoking SetDefaultSolveOptions. Returns a
+// function which removes the listener.
+func OnDefaultSolveOptionsChange(
+	f func(provider SolverProvider, options SolveOptions),
+) func() {
+	defaultSolveOptions.Lock()
+	defer defaultSolveOptions.Unlock()
+	id := defaultSolveOptions.nextID
+	defaultSolveOptions.nextID++
+	defaultSolveOptions.listeners = append(
+		defaultSolveOptions.listeners,
+		defaultSolveOptionsListener{id: id, f: f},
+	)
+
+	return func() {
+		defaultSolveOptions.Lock()
+		defer defaultSolveOptions.Unlock()
+		for i, listener := range defaultSolveOptions.listeners {
+			if listener.id == id {
+				defaultSolveOptions.listeners = append(
+					defaultSolveOptions.listeners[:i:i],
+					defaultSolveOptions.listeners[i+1:]...,
+				)
+				return
+			}
+		}
+	}
+}
+
+// WithDefaults returns the invoking options with the fields which are not
+// set, i.e. have their zero value, taken from defaults. Nested options are
+// merged field by field, so setting Limits.Nodes keeps the default of
+// Limits.Iterations. A bool which is true in defaults can not be turned off.
+func (o SolveOptions) WithDefaults(defaults SolveOptions) SolveOptions {
+	mergeDefaults(reflect.ValueOf(&o).Elem(), reflect.ValueOf(defaults))
+	return o
+}
+
+// mergeDefaults sets the zero fields of the struct options to the fields of
+// defaults.
+func mergeDefaults(options, defaults reflect.Value) {
+	for i := 0; i < options.NumField(); i++ {
+		field := options.Field(i)
+		switch {
+		case field.Kind() == reflect.Struct:
+			mergeDefaults(field, defaults.Field(i))
+		case field.IsZero():
+			field.Set(defaults.Field(i))
+		}
+	}
+}
+
+// defaultsSolver applies the default options of its provider, see
+// SetDefaultSolveOptions, to the options of every solve.
+type defaultsSolver struct {
+	solver   Solver
+	provider SolverProvider
+	model    Model
+}
+
+func (s *defaultsSolver) Solve(options SolveOptions) (Solution, error) {
+	return s.solver.Solve(withProviderDefaults(s, options))
+}
+
+func (s *defaultsSolver) Unwrap() Solver {
+	return s.solver
+}
+
+// withProviderDefaults returns options with the current defaults of the
+// provider of solver if solver has been created by NewSolver.
+func withProviderDefaults(solver Solver, options SolveOptions) SolveOptions {
+	s, ok := solver.(*defaultsSolver)
+	if !ok {
+		return options
+	}
+	defaults, ok := DefaultSolveOptions(s.provider)
+	if !ok {
+		return options
+	}
+	return options.WithDefaults(defaults)
+}
+
+// UnwrapSolver returns the solver of the back-end wrapped by solver, e.g. by
+// NewSolver, or solver itself if it does not wrap one. Check for optional
+// interfaces such as IncumbentNotifier on the unwrapped solver:
+//
+//	if notifier, ok := mip.UnwrapSolver(solver).(mip.IncumbentNotifier); ok {
+//		notifier.OnIncumbent(report)
+//	}
+func UnwrapSolver(solver Solver) Solver {
+	for {
+		wrapper, ok := solver.(interface{ Unwrap() Solver })
+		if !ok {
+			return solver
+		}
+		solver = wrapper.Unwrap()
+	}
+}
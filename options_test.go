@@ -3,8 +3,10 @@
 package mip_test
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 
 	mip "github.com/nextmv-io/go-mip"
 )
@@ -271,3 +273,186 @@ func TestControlOptions_ToTyped(t *testing.T) {
 		})
 	}
 }
+
+func TestKnownBoundOptions(t *testing.T) {
+	options := mip.KnownBoundOptions{}
+	b, err := json.Marshal(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != "{}" {
+		t.Errorf("json.Marshal(KnownBoundOptions{}) = %v, want {}", got)
+	}
+
+	options.SetPrimal(10.0)
+	options.SetDual(12.5)
+	b, err = json.Marshal(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"primal":10,"dual":12.5}`; got != want {
+		t.Errorf("json.Marshal(KnownBoundOptions) = %v, want %v", got, want)
+	}
+}
+
+func TestStopOptions(t *testing.T) {
+	options := mip.SolveOptions{}
+	progress := mip.Progress{BestBound: 90, HasIncumbent: true, Incumbent: 100}
+	if options.Stop.StopReached(progress, false) {
+		t.Errorf("stopped without criteria")
+	}
+
+	options.SetStopOnObjective(102)
+	if !options.Stop.StopReached(progress, false) {
+		t.Errorf("minimizing did not stop on incumbent 100 for objective 102")
+	}
+	if options.Stop.StopReached(progress, true) {
+		t.Errorf("maximizing stopped on incumbent 100 for objective 102")
+	}
+	if options.Stop.StopReached(mip.Progress{BestBound: 90}, false) {
+		t.Errorf("stopped on objective without incumbent")
+	}
+
+	options = mip.SolveOptions{}
+	options.SetStopOnBound(95)
+	if options.Stop.StopReached(progress, false) {
+		t.Errorf("minimizing stopped on bound 90 for bound 95")
+	}
+	progress.BestBound = 96
+	if !options.Stop.StopReached(progress, false) {
+		t.Errorf("minimizing did not stop on bound 96 for bound 95")
+	}
+
+	b, err := json.Marshal(options.Stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"bound":95}`; got != want {
+		t.Errorf("json.Marshal(StopOptions) = %v, want %v", got, want)
+	}
+}
+
+func TestDefaultSolveOptions(t *testing.T) {
+	provider := mip.SolverProvider("test-defaults")
+	if _, ok := mip.DefaultSolveOptions(provider); ok {
+		t.Fatalf("expected no defaults for %v", provider)
+	}
+
+	var changes []time.Duration
+	remove := mip.OnDefaultSolveOptionsChange(
+		func(p mip.SolverProvider, options mip.SolveOptions) {
+			if p == provider {
+				changes = append(changes, options.Duration)
+			}
+		},
+	)
+
+	mip.SetDefaultSolveOptions(provider, mip.SolveOptions{Duration: time.Minute})
+	options, ok := mip.DefaultSolveOptions(provider)
+	if !ok || options.Duration != time.Minute {
+		t.Errorf("got defaults %v, %v, want duration %v", options, ok, time.Minute)
+	}
+
+	remove()
+	mip.SetDefaultSolveOptions(provider, mip.SolveOptions{Duration: time.Hour})
+	if !reflect.DeepEqual(changes, []time.Duration{time.Minute}) {
+		t.Errorf("got changes %v, want %v", changes, []time.Duration{time.Minute})
+	}
+	options, _ = mip.DefaultSolveOptions(provider)
+	if options.Duration != time.Hour {
+		t.Errorf("got duration %v, want %v", options.Duration, time.Hour)
+	}
+}
+
+// optionsSolver is a Solver recording the options of its solves.
+type optionsSolver struct {
+	options []mip.SolveOptions
+}
+
+func (s *optionsSolver) Solve(options mip.SolveOptions) (mip.Solution, error) {
+	s.options = append(s.options, options)
+	return newTestSolution(0, nil), nil
+}
+
+func TestNewSolverAppliesDefaultSolveOptions(t *testing.T) {
+	provider := mip.SolverProvider("test-applied-defaults")
+	backend := &optionsSolver{}
+	mip.RegisterSolverProvider(provider, func(mip.Model) (mip.Solver, error) {
+		return backend, nil
+	})
+	solver, err := mip.NewSolver(provider, mip.NewModel())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaults := mip.SolveOptions{Duration: time.Minute, Verbosity: mip.Low}
+	defaults.Limits.Nodes = 100
+	defaults.Limits.Iterations = 1000
+	mip.SetDefaultSolveOptions(provider, defaults)
+	options := mip.SolveOptions{Duration: time.Second}
+	options.Limits.Nodes = 5
+	if _, err := solver.Solve(options); err != nil {
+		t.Fatal(err)
+	}
+
+	// Defaults updated at runtime apply to the next solve.
+	mip.SetDefaultSolveOptions(provider, mip.SolveOptions{Duration: time.Hour})
+	if _, err := solver.Solve(mip.SolveOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []mip.SolveOptions{
+		{
+			Duration:  time.Second,
+			Verbosity: mip.Low,
+			Limits:    mip.LimitOptions{Nodes: 5, Iterations: 1000},
+		},
+		{Duration: time.Hour},
+	}
+	if !reflect.DeepEqual(backend.options, want) {
+		t.Errorf("got options %+v, want %+v", backend.options, want)
+	}
+}
+
+func TestToleranceControlOptions(t *testing.T) {
+	tolerances := mip.ToleranceOptions{Integrality: 1e-5, DualFeasibility: 1e-7}
+	got, ok := mip.ToleranceControlOptions("highs", tolerances)
+	want := []mip.TypedControlOption[float64]{
+		{Name: "mip_feasibility_tolerance", Value: 1e-5},
+		{Name: "dual_feasibility_tolerance", Value: 1e-7},
+	}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, %v, want %v, true", got, ok, want)
+	}
+	if _, ok := mip.ToleranceControlOptions("unknown", tolerances); ok {
+		t.Errorf("expected unknown provider to have no tolerance parameters")
+	}
+}
+
+func TestLimitControlOptions(t *testing.T) {
+	limits := mip.LimitOptions{Nodes: 1000, Solutions: 3}
+	got, ok := mip.LimitControlOptions("xpress", limits)
+	want := []mip.TypedControlOption[int]{
+		{Name: "MAXNODE", Value: 1000},
+		{Name: "MAXMIPSOL", Value: 3},
+	}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, %v, want %v, true", got, ok, want)
+	}
+	if _, ok := mip.LimitControlOptions("unknown", limits); ok {
+		t.Errorf("expected unknown provider to have no limit parameters")
+	}
+}
+
+func TestFeasibilityOnlyControlOptions(t *testing.T) {
+	got, ok := mip.FeasibilityOnlyControlOptions("highs")
+	want := []mip.TypedControlOption[int]{
+		{Name: "mip_max_improving_sols", Value: 1},
+	}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, %v, want %v, true", got, ok, want)
+	}
+	if _, ok := mip.FeasibilityOnlyControlOptions("unknown"); ok {
+		t.Errorf("expected unknown provider to have no feasibility parameters")
+	}
+}
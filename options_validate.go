@@ -0,0 +1,274 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// ErrInvalidOptions is returned by SolveOptions.Validate and
+// SolveOptions.ValidateFor for options which are out of range, conflict
+// with each other or set control options the back-end does not know.
+var ErrInvalidOptions = errors.New("invalid solve options")
+
+// ControlParameters are the names of the parameters of a back-end per type,
+// see RegisterControlParameters.
+type ControlParameters struct {
+	Bool   []string `json:"bool"`
+	Float  []string `json:"float"`
+	Int    []string `json:"int"`
+	String []string `json:"string"`
+}
+
+// controlParameters are the parameters registered with
+// RegisterControlParameters, by provider and name.
+var controlParameters = struct {
+	sync.RWMutex
+	parameters map[SolverProvider]map[string]string
+}{
+	parameters: map[SolverProvider]map[string]string{},
+}
+
+// RegisterControlParameters declares the parameters of provider, so
+// SolveOptions.ValidateFor rejects control options with other names or
+// types instead of the back-end silently ignoring them. Back-ends register
+// their parameters from an init function. The control options of providers
+// which have not registered their parameters are not checked.
+func RegisterControlParameters(provider SolverProvider, parameters ControlParameters) {
+	types := map[string]string{}
+	for _, group := range []struct {
+		kind  string
+		names []string
+	}{
+		{"bool", parameters.Bool},
+		{"float", parameters.Float},
+		{"int", parameters.Int},
+		{"string", parameters.String},
+	} {
+		for _, name := range group.names {
+			types[name] = group.kind
+		}
+	}
+	controlParameters.Lock()
+	defer controlParameters.Unlock()
+	controlParameters.parameters[provider] = types
+}
+
+// Validate returns an error wrapping ErrInvalidOptions which lists the
+// problems of the invoking options: negative durations and limits, NaN
+// values, tolerances out of range, an unknown verbosity, malformed or
+// duplicate control options and criteria on the objective combined with
+// FeasibilityOnly, which ignores it.
+func (o SolveOptions) Validate() error {
+	var problems []string
+	report := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if o.Duration < 0 {
+		report("duration %v is negative", o.Duration)
+	}
+	if o.BuildDuration < 0 {
+		report("build duration %v is negative", o.BuildDuration)
+	}
+	switch o.Verbosity {
+	case "", Off, Low, Medium, High:
+	default:
+		report("verbosity %q is not one of off, low, medium and high", o.Verbosity)
+	}
+	for _, limit := range []struct {
+		name  string
+		value int
+	}{
+		{"nodes", o.Limits.Nodes},
+		{"iterations", o.Limits.Iterations},
+		{"solutions", o.Limits.Solutions},
+	} {
+		if limit.value < 0 {
+			report("limit on %s %d is negative", limit.name, limit.value)
+		}
+	}
+	o.validateTolerances(report)
+	o.validateObjectiveCriteria(report)
+	if _, err := o.controls(); err != nil {
+		report("%v", err)
+	}
+	return validationError(ErrInvalidOptions, problems)
+}
+
+// ValidateFor validates the invoking options like Validate and checks them
+// against provider. Returns an error wrapping ErrNotDeterministic if
+// provider does not honor Deterministic, see CheckDeterministic, and an
+// error wrapping ErrInvalidOptions for control options which are not
+// parameters of provider, have another type, see RegisterControlParameters,
+// or set a parameter an option is mapped to, e.g. a limit. Back-ends invoke
+// it at the start of Solve:
+//
+//	func (s *solver) Solve(options mip.SolveOptions) (mip.Solution, error) {
+//		if err := options.ValidateFor("mybackend"); err != nil {
+//			return nil, err
+//		}
+//		...
+//	}
+func (o SolveOptions) ValidateFor(provider SolverProvider) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	if err := CheckDeterministic(provider, o); err != nil {
+		return err
+	}
+
+	// Validate has checked the control options.
+	controls, _ := o.controls()
+	mapped := o.mappedParameters(provider)
+	controlParameters.RLock()
+	types, registered := controlParameters.parameters[provider]
+	controlParameters.RUnlock()
+
+	var problems []string
+	for _, control := range controls {
+		if option, ok := mapped[control.name]; ok {
+			problems = append(problems, fmt.Sprintf(
+				"control option %s conflicts with %s", control.name, option,
+			))
+		}
+		if !registered {
+			continue
+		}
+		switch kind, ok := types[control.name]; {
+		case !ok:
+			problems = append(problems, fmt.Sprintf(
+				"control option %s is not a parameter of provider %q", control.name, provider,
+			))
+		case kind != control.kind:
+			problems = append(problems, fmt.Sprintf(
+				"control option %s is a %s parameter of provider %q, not %s",
+				control.name, kind, provider, control.kind,
+			))
+		}
+	}
+	return validationError(ErrInvalidOptions, problems)
+}
+
+// validateTolerances reports tolerances which are NaN or out of range. The
+// integrality tolerance is below 0.5, the distance of any value to the
+// nearest integer, the others are below 1.
+func (o SolveOptions) validateTolerances(report func(format string, args ...any)) {
+	for _, tolerance := range []struct {
+		name  string
+		value float64
+		limit float64
+	}{
+		{"integrality", o.Tolerances.Integrality, 0.5},
+		{"primal feasibility", o.Tolerances.PrimalFeasibility, 1},
+		{"dual feasibility", o.Tolerances.DualFeasibility, 1},
+		{"optimality", o.Tolerances.Optimality, 1},
+	} {
+		if !(tolerance.value >= 0 && tolerance.value < tolerance.limit) {
+			report("%s tolerance %v is not in [0, %v)", tolerance.name, tolerance.value, tolerance.limit)
+		}
+	}
+	for _, gap := range []struct {
+		name  string
+		value float64
+	}{
+		{"absolute", o.MIP.Gap.Absolute},
+		{"relative", o.MIP.Gap.Relative},
+	} {
+		if !(gap.value >= 0) {
+			report("%s gap %v is not non-negative", gap.name, gap.value)
+		}
+	}
+}
+
+// validateObjectiveCriteria reports NaN stop criteria and known bounds, and
+// criteria on the objective combined with FeasibilityOnly.
+func (o SolveOptions) validateObjectiveCriteria(report func(format string, args ...any)) {
+	criteria := []struct {
+		name  string
+		value *float64
+	}{
+		{"stop objective", o.Stop.Objective},
+		{"stop bound", o.Stop.Bound},
+		{"known primal bound", o.MIP.KnownBound.Primal},
+		{"known dual bound", o.MIP.KnownBound.Dual},
+	}
+	for _, criterion := range criteria {
+		if criterion.value == nil {
+			continue
+		}
+		if math.IsNaN(*criterion.value) {
+			report("%s is NaN", criterion.name)
+		}
+		if o.FeasibilityOnly {
+			report("%s conflicts with feasibility only, which ignores the objective", criterion.name)
+		}
+	}
+	if o.FeasibilityOnly && o.Limits.Solutions > 1 {
+		report(
+			"limit on solutions %d conflicts with feasibility only, which stops at the first solution",
+			o.Limits.Solutions,
+		)
+	}
+}
+
+// controlOption is the name and type of a control option.
+type controlOption struct {
+	name string
+	kind string
+}
+
+// controls returns the control options of the invoking options. Returns an
+// error if they are malformed or a name is used more than once.
+func (o SolveOptions) controls() ([]controlOption, error) {
+	typed, err := o.Control.ToTyped()
+	if err != nil {
+		return nil, err
+	}
+	var controls []controlOption
+	for _, option := range typed.Bool {
+		controls = append(controls, controlOption{option.Name, "bool"})
+	}
+	for _, option := range typed.Float {
+		controls = append(controls, controlOption{option.Name, "float"})
+	}
+	for _, option := range typed.Int {
+		controls = append(controls, controlOption{option.Name, "int"})
+	}
+	for _, option := range typed.String {
+		controls = append(controls, controlOption{option.Name, "string"})
+	}
+	seen := map[string]bool{}
+	for _, control := range controls {
+		if seen[control.name] {
+			return nil, fmt.Errorf("control option %s is set more than once", control.name)
+		}
+		seen[control.name] = true
+	}
+	return controls, nil
+}
+
+// mappedParameters returns the parameters of provider the invoking options
+// set, mapped to the name of the option, see LimitControlOptions,
+// ToleranceControlOptions and FeasibilityOnlyControlOptions.
+func (o SolveOptions) mappedParameters(provider SolverProvider) map[string]string {
+	mapped := map[string]string{}
+	limits, _ := LimitControlOptions(provider, o.Limits)
+	for _, option := range limits {
+		mapped[option.Name] = "limits"
+	}
+	if o.FeasibilityOnly {
+		feasibility, _ := FeasibilityOnlyControlOptions(provider)
+		for _, option := range feasibility {
+			mapped[option.Name] = "feasibility only"
+		}
+	}
+	tolerances, _ := ToleranceControlOptions(provider, o.Tolerances)
+	for _, option := range tolerances {
+		mapped[option.Name] = "tolerances"
+	}
+	return mapped
+}
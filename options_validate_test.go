@@ -0,0 +1,132 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestSolveOptionsValidate(t *testing.T) {
+	if err := (mip.SolveOptions{}).Validate(); err != nil {
+		t.Fatalf("got error %v for zero options", err)
+	}
+
+	tests := []struct {
+		name    string
+		options func(options *mip.SolveOptions)
+		want    string
+	}{
+		{
+			name:    "negative duration",
+			options: func(options *mip.SolveOptions) { options.Duration = -1 },
+			want:    "duration -1ns is negative",
+		},
+		{
+			name:    "unknown verbosity",
+			options: func(options *mip.SolveOptions) { options.Verbosity = "loud" },
+			want:    `verbosity "loud"`,
+		},
+		{
+			name:    "negative limit",
+			options: func(options *mip.SolveOptions) { options.Limits.Nodes = -5 },
+			want:    "limit on nodes -5 is negative",
+		},
+		{
+			name:    "integrality tolerance",
+			options: func(options *mip.SolveOptions) { options.Tolerances.Integrality = 0.5 },
+			want:    "integrality tolerance 0.5 is not in [0, 0.5)",
+		},
+		{
+			name:    "NaN gap",
+			options: func(options *mip.SolveOptions) { options.MIP.Gap.Relative = math.NaN() },
+			want:    "relative gap NaN is not non-negative",
+		},
+		{
+			name: "feasibility only with stop objective",
+			options: func(options *mip.SolveOptions) {
+				options.SetFeasibilityOnly(true)
+				options.SetStopOnObjective(10)
+			},
+			want: "stop objective conflicts with feasibility only",
+		},
+		{
+			name:    "malformed control",
+			options: func(options *mip.SolveOptions) { options.Control.Int = "threads=four" },
+			want:    "option threads with non-valid int value four",
+		},
+		{
+			name: "duplicate control",
+			options: func(options *mip.SolveOptions) {
+				options.Control.Int = "threads=4"
+				options.Control.Float = "threads=2.5"
+			},
+			want: "control option threads is set more than once",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := mip.SolveOptions{}
+			test.options(&options)
+			err := options.Validate()
+			if !errors.Is(err, mip.ErrInvalidOptions) {
+				t.Fatalf("got error %v, want %v", err, mip.ErrInvalidOptions)
+			}
+			if !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got error %q, want it to contain %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestSolveOptionsValidateFor(t *testing.T) {
+	mip.RegisterControlParameters("validating", mip.ControlParameters{
+		Float: []string{"mip_rel_gap"},
+		Int:   []string{"threads"},
+	})
+
+	options := mip.SolveOptions{}
+	options.Control.Int = "threads=4"
+	if err := options.ValidateFor("validating"); err != nil {
+		t.Errorf("got error %v for known control option", err)
+	}
+
+	options.Control.Int = "thread=4"
+	err := options.ValidateFor("validating")
+	if !errors.Is(err, mip.ErrInvalidOptions) ||
+		!strings.Contains(err.Error(), `control option thread is not a parameter of provider "validating"`) {
+		t.Errorf("got error %v for unknown control option", err)
+	}
+
+	options.Control.Int = "mip_rel_gap=1"
+	err = options.ValidateFor("validating")
+	if !errors.Is(err, mip.ErrInvalidOptions) ||
+		!strings.Contains(err.Error(), "is a float parameter") {
+		t.Errorf("got error %v for control option of another type", err)
+	}
+
+	// Providers which have not registered parameters accept any control.
+	options.Control.Int = "anything=1"
+	if err := options.ValidateFor("unregistered"); err != nil {
+		t.Errorf("got error %v for unregistered provider", err)
+	}
+
+	options = mip.SolveOptions{}
+	options.Limits.Nodes = 100
+	options.Control.Int = "mip_max_nodes=50"
+	err = options.ValidateFor("highs")
+	if !errors.Is(err, mip.ErrInvalidOptions) ||
+		!strings.Contains(err.Error(), "control option mip_max_nodes conflicts with limits") {
+		t.Errorf("got error %v for control option conflicting with a limit", err)
+	}
+
+	options = mip.SolveOptions{}
+	options.SetDeterministic(true)
+	if err := options.ValidateFor("unregistered"); !errors.Is(err, mip.ErrNotDeterministic) {
+		t.Errorf("got error %v, want %v", err, mip.ErrNotDeterministic)
+	}
+}
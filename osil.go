@@ -0,0 +1,538 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// osilNamespace is the XML namespace of OSiL documents.
+const osilNamespace = "os.optimizationservices.org"
+
+type osilDocument struct {
+	XMLName xml.Name `xml:"osil"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	Data    osilData `xml:"instanceData"`
+}
+
+type osilData struct {
+	Variables   osilVariables    `xml:"variables"`
+	Objectives  *osilObjectives  `xml:"objectives,omitempty"`
+	Constraints *osilConstraints `xml:"constraints,omitempty"`
+	Linear      *osilLinear      `xml:"linearConstraintCoefficients,omitempty"`
+	Quadratic   *osilQuadratic   `xml:"quadraticCoefficients,omitempty"`
+	Nonlinear   *struct{}        `xml:"nonlinearExpressions,omitempty"`
+}
+
+type osilVariables struct {
+	Number int       `xml:"numberOfVariables,attr"`
+	Vars   []osilVar `xml:"var"`
+}
+
+type osilVar struct {
+	Mult int     `xml:"mult,attr,omitempty"`
+	Name string  `xml:"name,attr,omitempty"`
+	Type string  `xml:"type,attr,omitempty"`
+	LB   *string `xml:"lb,attr"`
+	UB   *string `xml:"ub,attr"`
+}
+
+type osilObjectives struct {
+	Number     int       `xml:"numberOfObjectives,attr"`
+	Objectives []osilObj `xml:"obj"`
+}
+
+type osilObj struct {
+	MaxOrMin string     `xml:"maxOrMin,attr"`
+	Number   int        `xml:"numberOfObjCoef,attr"`
+	Constant string     `xml:"constant,attr,omitempty"`
+	Coefs    []osilCoef `xml:"coef"`
+}
+
+type osilCoef struct {
+	Idx   int     `xml:"idx,attr"`
+	Value float64 `xml:",chardata"`
+}
+
+type osilConstraints struct {
+	Number      int       `xml:"numberOfConstraints,attr"`
+	Constraints []osilCon `xml:"con"`
+}
+
+type osilCon struct {
+	Name     string  `xml:"name,attr,omitempty"`
+	LB       *string `xml:"lb,attr"`
+	UB       *string `xml:"ub,attr"`
+	Constant string  `xml:"constant,attr,omitempty"`
+}
+
+type osilLinear struct {
+	Number int        `xml:"numberOfValues,attr"`
+	Start  osilArray  `xml:"start"`
+	RowIdx *osilArray `xml:"rowIdx,omitempty"`
+	ColIdx *osilArray `xml:"colIdx,omitempty"`
+	Value  osilArray  `xml:"value"`
+}
+
+type osilArray struct {
+	Elements []osilElement `xml:"el"`
+}
+
+type osilElement struct {
+	Mult  int    `xml:"mult,attr,omitempty"`
+	Incr  string `xml:"incr,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type osilQuadratic struct {
+	Number int         `xml:"numberOfQuadraticTerms,attr"`
+	Terms  []osilQTerm `xml:"qTerm"`
+}
+
+type osilQTerm struct {
+	Idx    int     `xml:"idx,attr"`
+	IdxOne int     `xml:"idxOne,attr"`
+	IdxTwo int     `xml:"idxTwo,attr"`
+	Coef   float64 `xml:"coef,attr"`
+}
+
+// WriteOSiL writes model to w in the Optimization Services instance Language
+// (OSiL), the XML model exchange format of COIN-OR tooling. Vars and
+// constraints are written with their names, if set. The constraint matrix is
+// written in row-major order.
+func WriteOSiL(w io.Writer, model Model) error {
+	vars := model.Vars()
+	constraints := model.Constraints()
+
+	document := osilDocument{
+		Xmlns: osilNamespace,
+		Data: osilData{
+			Variables: osilVariables{
+				Number: len(vars),
+				Vars:   make([]osilVar, len(vars)),
+			},
+		},
+	}
+
+	for i, v := range vars {
+		document.Data.Variables.Vars[i] = newOSiLVar(v)
+	}
+
+	objective := model.Objective()
+	obj := osilObj{MaxOrMin: "min"}
+	if objective.IsMaximize() {
+		obj.MaxOrMin = "max"
+	}
+	for _, t := range sortedTerms(objective.Terms()) {
+		obj.Coefs = append(obj.Coefs, osilCoef{Idx: t.Var().Index(), Value: t.Coefficient()})
+	}
+	obj.Number = len(obj.Coefs)
+	document.Data.Objectives = &osilObjectives{
+		Number:     1,
+		Objectives: []osilObj{obj},
+	}
+
+	if len(constraints) > 0 {
+		document.Data.Constraints = &osilConstraints{
+			Number:      len(constraints),
+			Constraints: make([]osilCon, len(constraints)),
+		}
+		linear := &osilLinear{ColIdx: &osilArray{}}
+		for i, c := range constraints {
+			document.Data.Constraints.Constraints[i] = newOSiLCon(c)
+			linear.Start.Elements = append(linear.Start.Elements, osilElement{
+				Value: strconv.Itoa(linear.Number),
+			})
+			for _, t := range sortedTerms(c.Terms()) {
+				linear.ColIdx.Elements = append(linear.ColIdx.Elements, osilElement{
+					Value: strconv.Itoa(t.Var().Index()),
+				})
+				linear.Value.Elements = append(linear.Value.Elements, osilElement{
+					Value: formatFloat(t.Coefficient()),
+				})
+				linear.Number++
+			}
+		}
+		linear.Start.Elements = append(linear.Start.Elements, osilElement{
+			Value: strconv.Itoa(linear.Number),
+		})
+		if linear.Number > 0 {
+			document.Data.Linear = linear
+		}
+	}
+
+	qTerms := sortedQuadraticTerms(objective.QuadraticTerms())
+	if len(qTerms) > 0 {
+		quadratic := &osilQuadratic{Number: len(qTerms)}
+		for _, t := range qTerms {
+			quadratic.Terms = append(quadratic.Terms, osilQTerm{
+				Idx:    -1,
+				IdxOne: t.Var1().Index(),
+				IdxTwo: t.Var2().Index(),
+				Coef:   t.Coefficient(),
+			})
+		}
+		document.Data.Quadratic = quadratic
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newOSiLVar(v Var) osilVar {
+	osilVar := osilVar{Name: v.Name()}
+	switch {
+	case v.IsBool():
+		osilVar.Type = "B"
+		if _, fixed := v.FixedValue(); !fixed {
+			return osilVar
+		}
+	case v.IsInt():
+		osilVar.Type = "I"
+	}
+	lowerBound, upperBound := bounds(v)
+	lower, upper := formatOSiLNumber(lowerBound), formatOSiLNumber(upperBound)
+	osilVar.LB, osilVar.UB = &lower, &upper
+	return osilVar
+}
+
+func newOSiLCon(c Constraint) osilCon {
+	rhs := formatOSiLNumber(c.RightHandSide())
+	con := osilCon{Name: c.Name()}
+	switch c.Sense() {
+	case LessThanOrEqual:
+		con.UB = &rhs
+	case GreaterThanOrEqual:
+		con.LB = &rhs
+	default:
+		con.LB, con.UB = &rhs, &rhs
+	}
+	return con
+}
+
+func formatOSiLNumber(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "INF"
+	case math.IsInf(value, -1):
+		return "-INF"
+	}
+	return formatFloat(value)
+}
+
+// ReadOSiL reads a model in the Optimization Services instance Language
+// (OSiL) from r. Linear constraints and a linear or quadratic objective are
+// supported. Vars and constraints are named after their OSiL names. Ranged
+// constraints with different lower and upper bounds are added as two
+// constraints and free constraints are not added. An error is returned for
+// multiple objectives, constants in the objective, quadratic constraints,
+// var types other than continuous, binary and integer, nonlinear
+// expressions, NaN numbers and counts which do not match the vars and
+// constraints. A var with a multiplicity is repeated.
+func ReadOSiL(r io.Reader) (Model, error) {
+	var document osilDocument
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("osil: %w", err)
+	}
+	data := document.Data
+
+	model := NewModel()
+	vars, err := readOSiLVars(model, data.Variables.Vars)
+	if err != nil {
+		return nil, err
+	}
+	if len(vars) != data.Variables.Number {
+		return nil, fmt.Errorf(
+			"osil: numberOfVariables is %d, got %d vars", data.Variables.Number, len(vars),
+		)
+	}
+
+	if err := readOSiLObjective(model, vars, data.Objectives); err != nil {
+		return nil, err
+	}
+
+	if data.Nonlinear != nil {
+		return nil, fmt.Errorf("osil: nonlinear expressions are not supported")
+	}
+
+	var constraints []osilCon
+	if data.Constraints != nil {
+		constraints = data.Constraints.Constraints
+		if len(constraints) != data.Constraints.Number {
+			return nil, fmt.Errorf(
+				"osil: numberOfConstraints is %d, got %d constraints",
+				data.Constraints.Number, len(constraints),
+			)
+		}
+	}
+	rows, err := readOSiLMatrix(data.Linear, len(constraints), len(vars))
+	if err != nil {
+		return nil, err
+	}
+	if err := readOSiLConstraints(model, vars, constraints, rows); err != nil {
+		return nil, err
+	}
+
+	if data.Quadratic != nil {
+		for _, t := range data.Quadratic.Terms {
+			if t.Idx != -1 {
+				return nil, fmt.Errorf("osil: quadratic constraints are not supported")
+			}
+			if !validIndex(t.IdxOne, len(vars)) || !validIndex(t.IdxTwo, len(vars)) {
+				return nil, fmt.Errorf("osil: invalid var index in quadratic term")
+			}
+			_, err := model.Objective().NewQuadraticTermChecked(t.Coef, vars[t.IdxOne], vars[t.IdxTwo])
+			if err != nil {
+				return nil, fmt.Errorf("osil: quadratic term of %d and %d: %w", t.IdxOne, t.IdxTwo, err)
+			}
+		}
+	}
+
+	return model, nil
+}
+
+func validIndex(index int, n int) bool {
+	return index >= 0 && index < n
+}
+
+func parseOSiLNumber(value *string, defaultValue float64) (float64, error) {
+	if value == nil {
+		return defaultValue, nil
+	}
+	return parseOSiLFloat(*value)
+}
+
+// parseOSiLFloat parses value, infinite values are allowed, NaN is not.
+func parseOSiLFloat(value string) (float64, error) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(number) {
+		return 0, fmt.Errorf("osil: invalid number %q", value)
+	}
+	return number, nil
+}
+
+// readOSiLVars adds the vars to model, a var with a multiplicity is added
+// that many times.
+func readOSiLVars(model Model, osilVars []osilVar) (Vars, error) {
+	vars := make(Vars, 0, len(osilVars))
+	for _, osilVar := range osilVars {
+		mult := osilVar.Mult
+		if mult < 1 {
+			mult = 1
+		}
+		for i := 0; i < mult; i++ {
+			v, err := newOSiLModelVar(model, osilVar)
+			if err != nil {
+				return nil, fmt.Errorf("osil: var %d: %w", len(vars), err)
+			}
+			vars = append(vars, v)
+		}
+	}
+	return vars, nil
+}
+
+// newOSiLModelVar adds the var specified by osilVar to model.
+func newOSiLModelVar(model Model, osilVar osilVar) (Var, error) {
+	lower, err := parseOSiLNumber(osilVar.LB, 0)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := parseOSiLNumber(osilVar.UB, math.Inf(1))
+	if err != nil {
+		return nil, err
+	}
+	var v Var
+	switch osilVar.Type {
+	case "", "C":
+		v, err = model.NewFloatChecked(lower, upper)
+	case "B":
+		v, err = model.NewBoolChecked()
+	case "I":
+		v, err = model.NewIntChecked(intLowerBound(lower), intUpperBound(upper))
+	default:
+		return nil, fmt.Errorf("var type %q is not supported", osilVar.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if osilVar.Name != "" {
+		v.SetName(osilVar.Name)
+	}
+	return v, nil
+}
+
+func readOSiLObjective(model Model, vars Vars, objectives *osilObjectives) error {
+	if objectives == nil || len(objectives.Objectives) == 0 {
+		return nil
+	}
+	if len(objectives.Objectives) > 1 {
+		return fmt.Errorf("osil: multiple objectives are not supported")
+	}
+	obj := objectives.Objectives[0]
+	if obj.Constant != "" {
+		constant, err := parseOSiLFloat(obj.Constant)
+		if err != nil || constant != 0 {
+			return fmt.Errorf("osil: constants in the objective are not supported")
+		}
+	}
+	if obj.MaxOrMin == "max" {
+		model.Objective().SetMaximize()
+	}
+	for _, coef := range obj.Coefs {
+		if !validIndex(coef.Idx, len(vars)) {
+			return fmt.Errorf("osil: invalid var index %d in objective", coef.Idx)
+		}
+		if _, err := model.Objective().NewTermChecked(coef.Value, vars[coef.Idx]); err != nil {
+			return fmt.Errorf("osil: objective coefficient of var %d: %w", coef.Idx, err)
+		}
+	}
+	return nil
+}
+
+// readOSiLMatrix returns the coefficients of the linear constraint matrix by
+// row, keyed by var index.
+func readOSiLMatrix(
+	linear *osilLinear,
+	nrRows int,
+	nrColumns int,
+) ([]map[int]float64, error) {
+	rows := make([]map[int]float64, nrRows)
+	for i := range rows {
+		rows[i] = make(map[int]float64)
+	}
+	if linear == nil || linear.Number == 0 {
+		return rows, nil
+	}
+
+	starts, err := linear.Start.expand()
+	if err != nil {
+		return nil, err
+	}
+	values, err := linear.Value.expand()
+	if err != nil {
+		return nil, err
+	}
+	indices, rowMajor, nrMajor := linear.RowIdx, false, nrColumns
+	if linear.ColIdx != nil {
+		indices, rowMajor, nrMajor = linear.ColIdx, true, nrRows
+	}
+	if indices == nil {
+		return nil, fmt.Errorf("osil: missing rowIdx or colIdx")
+	}
+	minor, err := indices.expand()
+	if err != nil {
+		return nil, err
+	}
+	if len(starts) != nrMajor+1 || len(minor) != len(values) ||
+		int(starts[len(starts)-1]) != len(values) {
+		return nil, fmt.Errorf("osil: inconsistent linear constraint coefficients")
+	}
+
+	for major := 0; major < nrMajor; major++ {
+		for k := int(starts[major]); k < int(starts[major+1]); k++ {
+			row, column := int(minor[k]), major
+			if rowMajor {
+				row, column = major, int(minor[k])
+			}
+			if !validIndex(row, nrRows) || !validIndex(column, nrColumns) {
+				return nil, fmt.Errorf("osil: invalid index in linear constraint coefficients")
+			}
+			rows[row][column] += values[k]
+		}
+	}
+	return rows, nil
+}
+
+// expand returns the values of the array, expanding elements with a
+// multiplicity and an increment.
+func (a osilArray) expand() ([]float64, error) {
+	values := make([]float64, 0, len(a.Elements))
+	for _, element := range a.Elements {
+		value, err := parseOSiLFloat(element.Value)
+		if err != nil {
+			return nil, err
+		}
+		increment := 0.0
+		if element.Incr != "" {
+			increment, err = strconv.ParseFloat(element.Incr, 64)
+			if err != nil || math.IsNaN(increment) {
+				return nil, fmt.Errorf("osil: invalid increment %q", element.Incr)
+			}
+		}
+		mult := element.Mult
+		if mult < 1 {
+			mult = 1
+		}
+		for i := 0; i < mult; i++ {
+			values = append(values, value+float64(i)*increment)
+		}
+	}
+	return values, nil
+}
+
+func readOSiLConstraints(
+	model Model,
+	vars Vars,
+	constraints []osilCon,
+	rows []map[int]float64,
+) error {
+	for i, con := range constraints {
+		lower, err := parseOSiLNumber(con.LB, math.Inf(-1))
+		if err != nil {
+			return err
+		}
+		upper, err := parseOSiLNumber(con.UB, math.Inf(1))
+		if err != nil {
+			return err
+		}
+		constant := 0.0
+		if con.Constant != "" {
+			constant, err = strconv.ParseFloat(con.Constant, 64)
+			if err != nil || math.IsInf(constant, 0) || math.IsNaN(constant) {
+				return fmt.Errorf("osil: invalid constant %q", con.Constant)
+			}
+		}
+		newConstraint := func(sense Sense, rhs float64) error {
+			c, err := model.NewConstraintChecked(sense, rhs-constant)
+			if err != nil {
+				return err
+			}
+			for _, j := range sortedKeys(rows[i]) {
+				if _, err := c.NewTermChecked(rows[i][j], vars[j]); err != nil {
+					return err
+				}
+			}
+			if con.Name != "" {
+				c.SetName(con.Name)
+			}
+			return nil
+		}
+		switch {
+		case lower == upper:
+			err = newConstraint(Equal, lower)
+		default:
+			if !math.IsInf(lower, -1) {
+				err = newConstraint(GreaterThanOrEqual, lower)
+			}
+			if err == nil && !math.IsInf(upper, 1) {
+				err = newConstraint(LessThanOrEqual, upper)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("osil: constraint %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+const testOSiL = `<?xml version="1.0" encoding="UTF-8"?>
+<osil xmlns="os.optimizationservices.org">
+  <instanceHeader>
+    <name>test</name>
+  </instanceHeader>
+  <instanceData>
+    <variables numberOfVariables="3">
+      <var name="x" lb="0" ub="10"/>
+      <var name="y" type="B"/>
+      <var name="z" type="I" lb="-INF" ub="5"/>
+    </variables>
+    <objectives numberOfObjectives="1">
+      <obj maxOrMin="max" numberOfObjCoef="2">
+        <coef idx="0">1</coef>
+        <coef idx="2">3</coef>
+      </obj>
+    </objectives>
+    <constraints numberOfConstraints="2">
+      <con name="c0" ub="4"/>
+      <con name="c1" lb="1" ub="2"/>
+    </constraints>
+    <linearConstraintCoefficients numberOfValues="4">
+      <start>
+        <el>0</el>
+        <el>2</el>
+        <el>3</el>
+        <el>4</el>
+      </start>
+      <rowIdx>
+        <el mult="2" incr="1">0</el>
+        <el>0</el>
+        <el>1</el>
+      </rowIdx>
+      <value>
+        <el mult="3">1</el>
+        <el>-2</el>
+      </value>
+    </linearConstraintCoefficients>
+  </instanceData>
+</osil>
+`
+
+func TestReadOSiL(t *testing.T) {
+	model, err := mip.ReadOSiL(strings.NewReader(testOSiL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `maximize   1 x + 3 z
+      0: 1 x + 1 y <= 4
+      1: 1 x + -2 z >= 1
+      2: 1 x + -2 z <= 2
+      0: x [0, 10]
+      1: y [0, 1]
+      2: z [-Inf, 5]
+`
+	if got := model.(fmt.Stringer).String(); got != want {
+		t.Errorf("got\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestWriteOSiLRoundTrip(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(math.Inf(-1), 10.0)
+	x.SetName("x")
+	y := model.NewInt(0, 5)
+	y.SetName("y")
+	z := model.NewBool()
+	z.SetName("z")
+	model.Objective().SetMaximize()
+	model.Objective().NewTerm(1.0, x)
+	model.Objective().NewTerm(-2.5, y)
+	model.Objective().NewQuadraticTerm(3.0, x, z)
+	c := model.NewConstraint(mip.Equal, -3.0)
+	c.NewTerm(1.0, x)
+	c.NewTerm(-1.0, z)
+	c.SetName("balance")
+	c = model.NewConstraint(mip.LessThanOrEqual, 7.0)
+	c.NewTerm(2.0, y)
+	c.SetName("capacity")
+
+	var buffer bytes.Buffer
+	if err := mip.WriteOSiL(&buffer, model); err != nil {
+		t.Fatal(err)
+	}
+
+	readModel, err := mip.ReadOSiL(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mip.ModelHash(readModel), mip.ModelHash(model); got != want {
+		t.Errorf("round trip changed model:\n%v\nwant\n%v", readModel, model)
+	}
+}
+
+func TestReadOSiLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		osil string
+	}{
+		{
+			name: "malformed xml",
+			osil: "<osil><instanceData>",
+		},
+		{
+			name: "semi-continuous var",
+			osil: strings.Replace(testOSiL, `type="B"`, `type="D"`, 1),
+		},
+		{
+			name: "nonlinear",
+			osil: strings.Replace(testOSiL, "</instanceData>", "<nonlinearExpressions/></instanceData>", 1),
+		},
+		{
+			name: "inconsistent matrix",
+			osil: strings.Replace(testOSiL, `<el>-2</el>`, ``, 1),
+		},
+		{
+			name: "NaN bound",
+			osil: strings.Replace(testOSiL, `lb="0" ub="10"`, `lb="NaN" ub="10"`, 1),
+		},
+		{
+			name: "NaN objective coefficient",
+			osil: strings.Replace(testOSiL, `<coef idx="0">1</coef>`, `<coef idx="0">NaN</coef>`, 1),
+		},
+		{
+			name: "NaN matrix coefficient",
+			osil: strings.Replace(testOSiL, `<el>-2</el>`, `<el>NaN</el>`, 1),
+		},
+		{
+			name: "NaN constraint bound",
+			osil: strings.Replace(testOSiL, `<con name="c0" ub="4"/>`, `<con name="c0" ub="nan"/>`, 1),
+		},
+		{
+			name: "number of variables",
+			osil: strings.Replace(testOSiL, `numberOfVariables="3"`, `numberOfVariables="4"`, 1),
+		},
+		{
+			name: "number of constraints",
+			osil: strings.Replace(testOSiL, `numberOfConstraints="2"`, `numberOfConstraints="1"`, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := mip.ReadOSiL(strings.NewReader(tt.osil)); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestReadOSiLVarMult(t *testing.T) {
+	osil := `<osil>
+  <instanceData>
+    <variables numberOfVariables="3">
+      <var mult="2" type="I" lb="0.5" ub="4.5"/>
+      <var name="y" type="B"/>
+    </variables>
+  </instanceData>
+</osil>`
+	model, err := mip.ReadOSiL(strings.NewReader(osil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vars := model.Vars()
+	if len(vars) != 3 || !vars[2].IsBool() || vars[2].Name() != "y" {
+		t.Fatalf("got vars %v, want two int vars and y", vars)
+	}
+	for _, v := range vars[:2] {
+		if !v.IsInt() || v.LowerBound() != 1 || v.UpperBound() != 4 {
+			t.Errorf("got var %v in [%v, %v], want int in [1, 4]", v, v.LowerBound(), v.UpperBound())
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownParam is returned by Model.SetParam for a name which has not been
+// added with Model.NewParam.
+var ErrUnknownParam = errors.New("unknown param")
+
+// Param is a named value of a model used as right-hand side or coefficient,
+// see Constraint.BindRightHandSide and Constraint.NewParamTerm. Changing the
+// value with Model.SetParam updates all its uses, e.g. to solve a series of
+// models in a parametric study.
+//
+//	demand := model.NewParam("demand", 100)
+//	c := model.NewConstraint(mip.GreaterThanOrEqual, 0)
+//	c.NewTerm(1, x)
+//	c.BindRightHandSide(demand)
+//	for _, value := range []float64{80, 100, 120} {
+//		model.SetParam("demand", value)
+//		solution, err := solver.Solve(options)
+//		...
+//	}
+type Param interface {
+	// Name returns the name of the param.
+	Name() string
+	// Value returns the current value of the param.
+	Value() float64
+}
+
+// paramTerm is a term whose coefficient is the value of a param.
+type paramTerm struct {
+	constraint Constraint
+	variable   Var
+}
+
+type param struct {
+	name           string
+	value          float64
+	rightHandSides Constraints
+	terms          []paramTerm
+}
+
+func (p *param) Name() string {
+	return p.name
+}
+
+func (p *param) Value() float64 {
+	return p.value
+}
+
+func (p *param) String() string {
+	return fmt.Sprintf("%s = %v", p.name, p.value)
+}
+
+func (m *model) NewParam(name string, value float64) Param {
+	m.mustBeMutable()
+	if p, ok := m.params[name]; ok {
+		if err := m.SetParam(name, value); err != nil {
+			panic(err)
+		}
+		return p
+	}
+	if err := checkNaN("param value", value); err != nil {
+		panic(err)
+	}
+	p := &param{
+		name:  name,
+		value: value,
+	}
+	m.params[name] = p
+	return p
+}
+
+func (m *model) SetParam(name string, value float64) error {
+	if err := m.checkMutable(); err != nil {
+		return err
+	}
+	p, ok := m.params[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownParam, name)
+	}
+	if err := checkNaN("param value", value); err != nil {
+		return err
+	}
+	p.value = value
+	for _, c := range p.rightHandSides {
+		c.(*constraint).rightHandSide = value
+	}
+	for _, t := range p.terms {
+		t.constraint.SetTerm(t.variable, value)
+	}
+	return nil
+}
+
+func (c *constraint) BindRightHandSide(p Param) {
+	c.model.mustBeMutable()
+	bound := p.(*param)
+	bound.rightHandSides = append(bound.rightHandSides, c)
+	c.rightHandSide = bound.value
+}
+
+func (c *constraint) NewParamTerm(p Param, variable Var) Term {
+	c.model.mustBeMutable()
+	bound := p.(*param)
+	bound.terms = append(bound.terms, paramTerm{
+		constraint: c,
+		variable:   variable,
+	})
+	return c.SetTerm(variable, bound.value)
+}
+
+// copyParams copies the params of m and their uses to c.
+func copyParams(
+	m *model,
+	c *model,
+	vars func(Var) Var,
+	constraints map[Constraint]Constraint,
+) {
+	c.params = make(map[string]*param, len(m.params))
+	for name, p := range m.params {
+		copied := &param{
+			name:           p.name,
+			value:          p.value,
+			rightHandSides: make(Constraints, len(p.rightHandSides)),
+			terms:          make([]paramTerm, len(p.terms)),
+		}
+		for i, constraint := range p.rightHandSides {
+			copied.rightHandSides[i] = constraints[constraint]
+		}
+		for i, t := range p.terms {
+			copied.terms[i] = paramTerm{
+				constraint: constraints[t.constraint],
+				variable:   vars(t.variable),
+			}
+		}
+		c.params[name] = copied
+	}
+}
+
+// parametricConstraints returns the constraints of m with a right-hand side
+// or a coefficient bound to a param.
+func parametricConstraints(m Model) map[Constraint]bool {
+	impl, ok := m.(*model)
+	if !ok {
+		return nil
+	}
+	parametric := map[Constraint]bool{}
+	for _, p := range impl.params {
+		for _, c := range p.rightHandSides {
+			parametric[c] = true
+		}
+		for _, t := range p.terms {
+			parametric[t.constraint] = true
+		}
+	}
+	return parametric
+}
@@ -0,0 +1,59 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestParam(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewFloat(0, 10)
+	demand := model.NewParam("demand", 5)
+	yield := model.NewParam("yield", 2)
+
+	c := model.NewConstraint(mip.GreaterThanOrEqual, 0)
+	c.NewTerm(1, x)
+	c.BindRightHandSide(demand)
+	c.NewParamTerm(yield, y)
+	if c.RightHandSide() != 5 {
+		t.Errorf("got right-hand side %v, want 5", c.RightHandSide())
+	}
+
+	for _, model := range []mip.Model{model, model.Copy(), model.Fork()} {
+		if err := model.SetParam("demand", 8); err != nil {
+			t.Fatal(err)
+		}
+		if err := model.SetParam("yield", 3); err != nil {
+			t.Fatal(err)
+		}
+		c := model.Constraints()[0]
+		if c.RightHandSide() != 8 {
+			t.Errorf("got right-hand side %v, want 8", c.RightHandSide())
+		}
+		term, _ := c.Term(model.Vars()[1])
+		if term.Coefficient() != 3 {
+			t.Errorf("got coefficient %v, want 3", term.Coefficient())
+		}
+		if len(c.Terms()) != 2 {
+			t.Errorf("got terms %v, want 2", c.Terms())
+		}
+	}
+
+	// Changing the param of a copy does not change the model.
+	copied := model.Copy()
+	if err := copied.SetParam("demand", 1); err != nil {
+		t.Fatal(err)
+	}
+	if c.RightHandSide() != 8 || demand.Value() != 8 {
+		t.Errorf("got right-hand side %v, want 8", c.RightHandSide())
+	}
+
+	if err := model.SetParam("supply", 1); !errors.Is(err, mip.ErrUnknownParam) {
+		t.Errorf("got error %v, want %v", err, mip.ErrUnknownParam)
+	}
+}
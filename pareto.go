@@ -0,0 +1,210 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// paretoTolerance is the tolerance of comparing objective values of points
+// of a Pareto frontier.
+const paretoTolerance = 1e-9
+
+// ParetoPoint is a point of a Pareto frontier, see ParetoFrontier.
+type ParetoPoint struct {
+	// Objective1 is the value of the first objective.
+	Objective1 float64 `json:"objective1"`
+	// Objective2 is the value of the second objective.
+	Objective2 float64 `json:"objective2"`
+	// Solution of the point. Its values are the values of the vars of the
+	// model passed to ParetoFrontier.
+	Solution Solution `json:"-"`
+}
+
+// ParetoFrontier returns non-dominated solutions of model trading off obj1
+// and obj2, e.g. cost and service level, by the epsilon-constraint method.
+// The objective of model is ignored, the objectives are created with
+// NewObjective and each either minimizes or maximizes. The range of obj2 is
+// determined by optimizing each objective alone, then obj1 is optimized
+// with obj2 constrained to points evenly spaced values of the range. Each
+// solve uses a solver created by factory for a copy of model, so model is
+// not changed.
+//
+// The points are ordered from the best value of obj1 to the best value of
+// obj2. Dominated points and points with the same objective values as
+// another point are removed, and so are sweeps which have no solution.
+// Returns no points if model has no solution and an error if points is less
+// than 2, obj2 is not linear or a solve fails.
+//
+//	frontier, err := mip.ParetoFrontier(model, cost, service, 10, factory, options)
+func ParetoFrontier(
+	model Model,
+	obj1 Objective,
+	obj2 Objective,
+	points int,
+	factory SolverFactory,
+	options SolveOptions,
+) ([]ParetoPoint, error) {
+	if points < 2 {
+		return nil, fmt.Errorf("pareto frontier needs at least 2 points, got %d", points)
+	}
+	if !obj2.IsLinear() {
+		return nil, errors.New("second objective of pareto frontier must be linear")
+	}
+
+	solve := func(objective Objective, epsilon float64, constrained bool) (*ParetoPoint, error) {
+		sub, vars := epsilonModel(model, objective, obj2, epsilon, constrained)
+		solver, err := factory(sub)
+		if err != nil {
+			return nil, err
+		}
+		solution, err := solver.Solve(options)
+		if err != nil {
+			return nil, err
+		}
+		if !solution.HasValues() {
+			return nil, nil
+		}
+		mapped := &mappedSolution{Solution: solution, vars: vars}
+		return &ParetoPoint{
+			Objective1: objectiveValue(obj1, mapped.Value),
+			Objective2: objectiveValue(obj2, mapped.Value),
+			Solution:   mapped,
+		}, nil
+	}
+
+	best, err := solve(obj2, 0, false)
+	if err != nil || best == nil {
+		return nil, err
+	}
+	first, err := solve(obj1, 0, false)
+	if err != nil || first == nil {
+		return nil, err
+	}
+
+	frontier := []ParetoPoint{*first}
+	for i := 1; i < points; i++ {
+		epsilon := best.Objective2
+		if i < points-1 {
+			epsilon = first.Objective2 + float64(i)/float64(points-1)*(best.Objective2-first.Objective2)
+		}
+		point, err := solve(obj1, epsilon, true)
+		if err != nil {
+			return nil, err
+		}
+		if point != nil {
+			frontier = append(frontier, *point)
+		}
+	}
+	return nonDominated(frontier, obj1.IsMaximize(), obj2.IsMaximize()), nil
+}
+
+// epsilonModel returns a copy of model with objective as its objective and,
+// if constrained, the constraint that bound is at most epsilon if it
+// minimizes or at least epsilon if it maximizes. The returned vars of the
+// copy correspond to the vars of model.
+func epsilonModel(
+	model Model,
+	objective Objective,
+	bound Objective,
+	epsilon float64,
+	constrained bool,
+) (Model, Vars) {
+	sub := NewModel()
+	vars := model.Vars()
+	mapping := make(Vars, len(vars))
+	for i, v := range vars {
+		mapping[i] = newVarLike(sub, v)
+		mapping[i].SetName(v.Name())
+	}
+	for _, c := range model.Constraints() {
+		constraint := sub.NewConstraint(c.Sense(), c.RightHandSide())
+		constraint.SetName(c.Name())
+		for _, t := range c.Terms() {
+			constraint.NewTerm(t.Coefficient(), mapping[t.Var().Index()])
+		}
+	}
+
+	if objective.IsMaximize() {
+		sub.Objective().SetMaximize()
+	}
+	for _, t := range objective.Terms() {
+		sub.Objective().NewTerm(t.Coefficient(), mapping[t.Var().Index()])
+	}
+	for _, t := range objective.QuadraticTerms() {
+		sub.Objective().NewQuadraticTerm(
+			t.Coefficient(),
+			mapping[t.Var1().Index()],
+			mapping[t.Var2().Index()],
+		)
+	}
+
+	if constrained {
+		sense := LessThanOrEqual
+		if bound.IsMaximize() {
+			sense = GreaterThanOrEqual
+		}
+		constraint := sub.NewConstraint(sense, epsilon)
+		for _, t := range bound.Terms() {
+			constraint.NewTerm(t.Coefficient(), mapping[t.Var().Index()])
+		}
+	}
+	return sub, mapping
+}
+
+// nonDominated returns the points of frontier which are not dominated by or
+// equal to an earlier point, ordered by the first objective from best to
+// worst.
+func nonDominated(frontier []ParetoPoint, maximize1, maximize2 bool) []ParetoPoint {
+	better := func(a, b float64, maximize bool) bool {
+		if maximize {
+			return a > b+paretoTolerance
+		}
+		return a < b-paretoTolerance
+	}
+	notWorse := func(a, b float64, maximize bool) bool {
+		return !better(b, a, maximize)
+	}
+
+	var result []ParetoPoint
+	for i, p := range frontier {
+		dominated := false
+		for j, q := range frontier {
+			if i == j {
+				continue
+			}
+			weakly := notWorse(q.Objective1, p.Objective1, maximize1) &&
+				notWorse(q.Objective2, p.Objective2, maximize2)
+			strictly := better(q.Objective1, p.Objective1, maximize1) ||
+				better(q.Objective2, p.Objective2, maximize2)
+			if weakly && (strictly || j < i) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			result = append(result, p)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return better(result[i].Objective1, result[j].Objective1, maximize1)
+	})
+	return result
+}
+
+// mappedSolution is a solution of a copy of a model whose values are
+// accessed with the vars of the model.
+type mappedSolution struct {
+	Solution
+	vars Vars
+}
+
+func (s *mappedSolution) Value(variable Var) float64 {
+	if !s.Solution.HasValues() {
+		return math.MaxFloat64
+	}
+	return s.Solution.Value(s.vars[variable.Index()])
+}
@@ -0,0 +1,52 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestParetoFrontier(t *testing.T) {
+	model := mip.NewModel()
+	cost := mip.NewObjective()
+	service := mip.NewObjective()
+	service.SetMaximize()
+	atLeastOne := model.NewConstraint(mip.GreaterThanOrEqual, 1)
+	var items mip.Vars
+	for i, c := range []float64{1, 2, 4} {
+		item := model.NewBool()
+		items = append(items, item)
+		atLeastOne.NewTerm(1, item)
+		cost.NewTerm(c, item)
+		service.NewTerm([]float64{1, 3, 4}[i], item)
+	}
+	factory := func(m mip.Model) (mip.Solver, error) {
+		return enumeratingSolver{model: m}, nil
+	}
+
+	frontier, err := mip.ParetoFrontier(model, cost, service, 5, factory, mip.SolveOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]float64{{1, 1}, {2, 3}, {5, 5}, {6, 7}, {7, 8}}
+	if len(frontier) != len(want) {
+		t.Fatalf("got %d points, want %d: %+v", len(frontier), len(want), frontier)
+	}
+	for i, p := range frontier {
+		if p.Objective1 != want[i][0] || p.Objective2 != want[i][1] {
+			t.Errorf("point %d = (%v, %v), want %v", i, p.Objective1, p.Objective2, want[i])
+		}
+	}
+	if v := frontier[1].Solution.Value(items[1]); v != 1 {
+		t.Errorf("got value %v of item 1 at the second point, want 1", v)
+	}
+	if len(model.Constraints()) != 1 {
+		t.Errorf("model was changed")
+	}
+
+	if _, err := mip.ParetoFrontier(model, cost, service, 1, factory, mip.SolveOptions{}); err == nil {
+		t.Error("expected error for less than 2 points")
+	}
+}
@@ -0,0 +1,215 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PrintOptions configure Model.Fprint.
+type PrintOptions struct {
+	// TermsPerLine is the maximum number of terms printed on a line, longer
+	// expressions are wrapped. 0 prints all terms of an expression on one
+	// line.
+	TermsPerLine int `json:"terms_per_line"`
+	// Precision is the number of significant digits of coefficients, bounds
+	// and right-hand sides. 0 prints the shortest representation which
+	// reads back exactly.
+	Precision int `json:"precision"`
+	// NamedOnly omits vars and constraints which have not been named.
+	NamedOnly bool `json:"named_only"`
+	// Group only prints the vars of the group, see Var.SetGroup, their
+	// linear objective terms and the constraints with a term of a var of the
+	// group. Empty prints all vars and constraints.
+	Group string `json:"group"`
+}
+
+// printer writes a model in a human readable form.
+type printer struct {
+	w       *bufio.Writer
+	options PrintOptions
+}
+
+func (m *model) Fprint(w io.Writer, options PrintOptions) error {
+	p := printer{
+		w:       bufio.NewWriter(w),
+		options: options,
+	}
+
+	if m.objective.IsMaximize() {
+		p.line("maximize")
+	} else {
+		p.line("minimize")
+	}
+	p.expression(
+		"  obj:",
+		p.linearTerms(p.groupTerms(sortedTerms(m.objective.Terms())))+
+			p.quadraticTerms(sortedQuadraticTerms(m.objective.QuadraticTerms())),
+		"",
+	)
+
+	p.line("subject to")
+	for i, c := range m.constraints {
+		if !p.includesConstraint(c) {
+			continue
+		}
+		name := c.Name()
+		if name == "" {
+			name = fmt.Sprintf("C%d", i)
+		}
+		p.expression(
+			"  "+name+":",
+			p.linearTerms(sortedTerms(c.Terms())),
+			sense(c.Sense())+" "+p.number(c.RightHandSide()),
+		)
+	}
+
+	p.line("bounds")
+	for _, v := range m.vars {
+		if !p.includesVar(v) {
+			continue
+		}
+		p.bound(v)
+	}
+	return p.w.Flush()
+}
+
+func (p *printer) includesVar(v Var) bool {
+	if p.options.NamedOnly && v.Name() == "" {
+		return false
+	}
+	return p.options.Group == "" || v.Group() == p.options.Group
+}
+
+func (p *printer) includesConstraint(c Constraint) bool {
+	if p.options.NamedOnly && c.Name() == "" {
+		return false
+	}
+	if p.options.Group == "" {
+		return true
+	}
+	for _, t := range c.Terms() {
+		if t.Var().Group() == p.options.Group {
+			return true
+		}
+	}
+	return false
+}
+
+// groupTerms returns the terms of vars of the group, all terms if no group is
+// set.
+func (p *printer) groupTerms(terms Terms) Terms {
+	if p.options.Group == "" {
+		return terms
+	}
+	filtered := make(Terms, 0, len(terms))
+	for _, t := range terms {
+		if t.Var().Group() == p.options.Group {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// linearTerms formats terms as a list of signed terms separated by newlines,
+// which expression wraps.
+func (p *printer) linearTerms(terms Terms) string {
+	var sb strings.Builder
+	for _, t := range terms {
+		sb.WriteString(p.term(t.Coefficient(), fmt.Sprint(t.Var())))
+	}
+	return sb.String()
+}
+
+func (p *printer) quadraticTerms(terms QuadraticTerms) string {
+	var sb strings.Builder
+	for _, t := range terms {
+		product := fmt.Sprint(t.Var1()) + "^2"
+		if t.Var1().Index() != t.Var2().Index() {
+			product = fmt.Sprint(t.Var1()) + "*" + fmt.Sprint(t.Var2())
+		}
+		sb.WriteString(p.term(t.Coefficient(), product))
+	}
+	return sb.String()
+}
+
+func (p *printer) term(coefficient float64, variable string) string {
+	sign := "+"
+	if coefficient < 0 || (coefficient == 0 && math.Signbit(coefficient)) {
+		sign = "-"
+	}
+	return fmt.Sprintf("%s %s %s\n", sign, p.number(math.Abs(coefficient)), variable)
+}
+
+// expression writes the terms, separated by newlines, after prefix, wrapped
+// after TermsPerLine terms and followed by suffix.
+func (p *printer) expression(prefix, terms, suffix string) {
+	split := strings.Split(strings.TrimSuffix(terms, "\n"), "\n")
+	if terms == "" {
+		split = []string{"0"}
+	}
+	perLine := p.options.TermsPerLine
+	if perLine <= 0 {
+		perLine = len(split)
+	}
+	indent := strings.Repeat(" ", len(prefix))
+	for start := 0; start < len(split); start += perLine {
+		end := start + perLine
+		if end > len(split) {
+			end = len(split)
+		}
+		line := indent
+		if start == 0 {
+			line = prefix
+		}
+		line += " " + strings.Join(split[start:end], " ")
+		if end == len(split) && suffix != "" {
+			line += " " + suffix
+		}
+		p.line(line)
+	}
+}
+
+func (p *printer) bound(v Var) {
+	lower, upper := bounds(v)
+	kind := "float"
+	switch {
+	case v.IsBool():
+		kind = "bool"
+	case v.IsInt():
+		kind = "int"
+	}
+	if lower == upper {
+		p.line(fmt.Sprintf("  %v = %s (%s)", v, p.number(lower), kind))
+		return
+	}
+	p.line(fmt.Sprintf("  %s <= %v <= %s (%s)", p.number(lower), v, p.number(upper), kind))
+}
+
+func (p *printer) number(value float64) string {
+	precision := p.options.Precision
+	if precision <= 0 {
+		precision = -1
+	}
+	return strconv.FormatFloat(value, 'g', precision, 64)
+}
+
+func (p *printer) line(line string) {
+	p.w.WriteString(line)
+	p.w.WriteByte('\n')
+}
+
+func sense(s Sense) string {
+	switch s {
+	case LessThanOrEqual:
+		return "<="
+	case GreaterThanOrEqual:
+		return ">="
+	}
+	return "="
+}
@@ -0,0 +1,62 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"math"
+)
+
+// LinearizeProduct returns a new var z of model which equals b * x and adds
+// the linear constraints defining it. If x is a bool var, z is a bool var
+// with z <= b, z <= x and z >= b + x - 1. Otherwise x must be bounded, z has
+// the type of x and the bounds [min(0, L), max(0, U)] for the bounds [L, U]
+// of x, and the McCormick constraints L b <= z <= U b and
+// x - U (1 - b) <= z <= x - L (1 - b) are exact. Panics if x is not a bool var
+// and has an infinite bound.
+//
+//	// Revenue is the price of a product if it is sold.
+//	revenue := mip.LinearizeProduct(model, sold, price)
+func LinearizeProduct(model Model, b Bool, x Var) Var {
+	if x.Type() == Binary {
+		z := model.NewBool()
+		addLiterals(model, LessThanOrEqual, 1, Literal{Var: z, Value: true}, Literal{Var: b, Value: false})
+		addLiterals(model, LessThanOrEqual, 1, Literal{Var: z, Value: true}, Literal{Var: x, Value: false})
+		addLiterals(model, GreaterThanOrEqual, 1,
+			Literal{Var: z, Value: true},
+			Literal{Var: b, Value: false},
+			Literal{Var: x, Value: false},
+		)
+		return z
+	}
+
+	lower, upper := x.LowerBound(), x.UpperBound()
+	if math.IsInf(lower, 0) || math.IsInf(upper, 0) {
+		panic(fmt.Sprintf("product of %v and %v with infinite bounds can not be linearized", b, x))
+	}
+	var z Var
+	if x.Type() == Integer {
+		z = model.NewInt(int64(math.Min(0, lower)), int64(math.Max(0, upper)))
+	} else {
+		z = model.NewFloat(math.Min(0, lower), math.Max(0, upper))
+	}
+
+	// z <= U b and z >= L b.
+	below := model.NewConstraint(LessThanOrEqual, 0)
+	below.NewTerm(1, z)
+	below.NewTerm(-upper, b)
+	above := model.NewConstraint(GreaterThanOrEqual, 0)
+	above.NewTerm(1, z)
+	above.NewTerm(-lower, b)
+
+	// z <= x - L (1 - b) and z >= x - U (1 - b).
+	belowX := model.NewConstraint(LessThanOrEqual, -lower)
+	belowX.NewTerm(1, z)
+	belowX.NewTerm(-1, x)
+	belowX.NewTerm(-lower, b)
+	aboveX := model.NewConstraint(GreaterThanOrEqual, -upper)
+	aboveX.NewTerm(1, z)
+	aboveX.NewTerm(-1, x)
+	aboveX.NewTerm(-upper, b)
+	return z
+}
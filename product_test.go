@@ -0,0 +1,65 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestLinearizeProductBool(t *testing.T) {
+	model := mip.NewModel()
+	b, x := model.NewBool(), model.NewBool()
+	z := mip.LinearizeProduct(model, b, x)
+	if !z.IsBool() {
+		t.Errorf("product of bool vars is not a bool var")
+	}
+	for mask := 0; mask < 8; mask++ {
+		values := []bool{mask&1 == 1, mask&2 == 2, mask&4 == 4}
+		want := values[2] == (values[0] && values[1])
+		if got := satisfiable(model, values...); got != want {
+			t.Errorf("got %v for %v, want %v", got, values, want)
+		}
+	}
+}
+
+func TestLinearizeProduct(t *testing.T) {
+	model := mip.NewModel()
+	b := model.NewBool()
+	x := model.NewFloat(-2, 5)
+	z := mip.LinearizeProduct(model, b, x)
+	if z.LowerBound() != -2 || z.UpperBound() != 5 || !z.IsFloat() {
+		t.Errorf("got %v with bounds [%v, %v]", z, z.LowerBound(), z.UpperBound())
+	}
+
+	tests := []struct {
+		b, x, z  float64
+		feasible bool
+	}{
+		{b: 0, x: 3, z: 0, feasible: true},
+		{b: 0, x: -2, z: 0, feasible: true},
+		{b: 0, x: 3, z: 1, feasible: false},
+		{b: 1, x: 3, z: 3, feasible: true},
+		{b: 1, x: -1.5, z: -1.5, feasible: true},
+		{b: 1, x: 3, z: 2.5, feasible: false},
+		{b: 1, x: 3, z: 0, feasible: false},
+	}
+	for _, test := range tests {
+		evaluation := mip.Evaluate(model, map[mip.Var]float64{b: test.b, x: test.x, z: test.z})
+		if got := evaluation.TotalViolation == 0; got != test.feasible {
+			t.Errorf("b = %v, x = %v, z = %v: got feasible %v", test.b, test.x, test.z, got)
+		}
+	}
+
+	if y := mip.LinearizeProduct(model, b, model.NewInt(1, 4)); !y.IsInt() || y.LowerBound() != 0 {
+		t.Errorf("got %v with lower bound %v, want an int var from 0", y, y.LowerBound())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for an unbounded var")
+		}
+	}()
+	mip.LinearizeProduct(model, b, model.NewFloat(0, mip.Infinity()))
+}
@@ -0,0 +1,179 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// RandomModelSpec configures the instances generated by RandomModel.
+type RandomModelSpec struct {
+	// Vars is the number of vars.
+	Vars int `json:"vars"`
+	// Constraints is the number of constraints.
+	Constraints int `json:"constraints"`
+	// Density is the probability of a var to have a term in a constraint,
+	// in (0, 1]. Each constraint has at least one term.
+	Density float64 `json:"density"`
+	// BoolFraction is the fraction of vars which are bool vars.
+	BoolFraction float64 `json:"bool_fraction"`
+	// IntFraction is the fraction of vars which are int vars, the remaining
+	// vars are float vars.
+	IntFraction float64 `json:"int_fraction"`
+	// EqualityFraction is the fraction of constraints which are equality
+	// constraints.
+	EqualityFraction float64 `json:"equality_fraction"`
+	// MaxBound is the largest upper bound of int and float vars, the lower
+	// bound is 0. Defaults to 10 if 0.
+	MaxBound int `json:"max_bound"`
+	// MaxCoefficient is the largest magnitude of coefficients. Defaults to 10
+	// if 0.
+	MaxCoefficient int `json:"max_coefficient"`
+}
+
+// RandomModel generates a random model with bounded vars which is feasible
+// by construction: a random point within the bounds is drawn first and the
+// right-hand sides are chosen such that it satisfies all constraints.
+// Coefficients, bounds and right-hand sides are integers, so the point is
+// exactly feasible. The same seed and spec generate the same model. Returns
+// the model and the feasible point, indexed by Var.Index. Use it to fuzz
+// back-ends, e.g. with CompareSolvers.
+func RandomModel(seed int64, spec RandomModelSpec) (Model, []float64) {
+	if spec.MaxBound <= 0 {
+		spec.MaxBound = 10
+	}
+	if spec.MaxCoefficient <= 0 {
+		spec.MaxCoefficient = 10
+	}
+	r := rand.New(rand.NewSource(seed))
+	coefficient := func() float64 {
+		c := float64(r.Intn(spec.MaxCoefficient) + 1)
+		if r.Intn(2) == 0 {
+			return -c
+		}
+		return c
+	}
+
+	model := NewModel()
+	point := make([]float64, spec.Vars)
+	for i := range point {
+		upper := r.Intn(spec.MaxBound) + 1
+		switch p := r.Float64(); {
+		case p < spec.BoolFraction:
+			model.NewBool()
+			point[i] = float64(r.Intn(2))
+		case p < spec.BoolFraction+spec.IntFraction:
+			model.NewInt(0, int64(upper))
+			point[i] = float64(r.Intn(upper + 1))
+		default:
+			model.NewFloat(0, float64(upper))
+			point[i] = float64(r.Intn(upper + 1))
+		}
+	}
+	vars := model.Vars()
+
+	if r.Intn(2) == 0 {
+		model.Objective().SetMaximize()
+	}
+	for _, v := range vars {
+		model.Objective().NewTerm(coefficient(), v)
+	}
+
+	for i := 0; i < spec.Constraints && len(vars) > 0; i++ {
+		terms := make(map[int]float64)
+		for j := range vars {
+			if r.Float64() < spec.Density {
+				terms[j] = coefficient()
+			}
+		}
+		if len(terms) == 0 {
+			terms[r.Intn(len(vars))] = coefficient()
+		}
+		activity := 0.0
+		for j, c := range terms {
+			activity += c * point[j]
+		}
+		slack := float64(r.Intn(spec.MaxCoefficient + 1))
+
+		var c Constraint
+		switch p := r.Float64(); {
+		case p < spec.EqualityFraction:
+			c = model.NewConstraint(Equal, activity)
+		case r.Intn(2) == 0:
+			c = model.NewConstraint(LessThanOrEqual, activity+slack)
+		default:
+			c = model.NewConstraint(GreaterThanOrEqual, activity-slack)
+		}
+		for _, j := range sortedKeys(terms) {
+			c.NewTerm(terms[j], vars[j])
+		}
+	}
+
+	return model, point
+}
+
+// ErrSolverMismatch is returned by CompareSolvers if the solvers disagree.
+var ErrSolverMismatch = errors.New("solvers disagree")
+
+// CompareSolvers solves model with a reference and a candidate solver and
+// returns an error wrapping ErrSolverMismatch if the candidate disagrees with
+// the reference: if only one of them finds a solution, if both are optimal
+// but their objective values deviate by more than tolerances.Objective, or if
+// the solution of the candidate does not pass Verify. Use it in a fuzz test
+// together with RandomModel to test a back-end against an established one.
+func CompareSolvers(
+	model Model,
+	reference SolverFactory,
+	candidate SolverFactory,
+	options SolveOptions,
+	tolerances Tolerances,
+) error {
+	solve := func(factory SolverFactory) (Solution, error) {
+		solver, err := factory(model.Copy())
+		if err != nil {
+			return nil, err
+		}
+		return solver.Solve(options)
+	}
+	referenceSolution, err := solve(reference)
+	if err != nil {
+		return fmt.Errorf("reference: %w", err)
+	}
+	candidateSolution, err := solve(candidate)
+	if err != nil {
+		return fmt.Errorf("candidate: %w", err)
+	}
+
+	if referenceSolution.HasValues() != candidateSolution.HasValues() {
+		return fmt.Errorf(
+			"%w: reference has values %v, candidate has values %v",
+			ErrSolverMismatch,
+			referenceSolution.HasValues(),
+			candidateSolution.HasValues(),
+		)
+	}
+	if !candidateSolution.HasValues() {
+		return nil
+	}
+	if report := Verify(model, candidateSolution, tolerances); !report.IsValid() {
+		return fmt.Errorf("%w: candidate solution is not valid: %+v", ErrSolverMismatch, report)
+	}
+	if referenceSolution.IsOptimal() && candidateSolution.IsOptimal() {
+		difference := math.Abs(
+			referenceSolution.ObjectiveValue() - candidateSolution.ObjectiveValue(),
+		)
+		scale := math.Max(1, math.Abs(referenceSolution.ObjectiveValue()))
+		if difference > tolerances.Objective*scale {
+			return fmt.Errorf(
+				"%w: reference objective value %v, candidate objective value %v",
+				ErrSolverMismatch,
+				referenceSolution.ObjectiveValue(),
+				candidateSolution.ObjectiveValue(),
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// pointSolution returns a testSolution for point, a value per var.
+func pointSolution(model mip.Model, point []float64) *testSolution {
+	values := make(map[mip.Var]float64, len(point))
+	for i, v := range model.Vars() {
+		values[v] = point[i]
+	}
+	evaluation := mip.Evaluate(model, values)
+	return newTestSolution(evaluation.ObjectiveValue, values)
+}
+
+func FuzzRandomModel(f *testing.F) {
+	f.Add(int64(1), 5, 3, 0.5)
+	f.Add(int64(2), 20, 10, 0.1)
+	f.Add(int64(3), 1, 0, 1.0)
+	f.Fuzz(func(t *testing.T, seed int64, vars, constraints int, density float64) {
+		if vars < 0 || vars > 100 || constraints < 0 || constraints > 100 {
+			t.Skip()
+		}
+		spec := mip.RandomModelSpec{
+			Vars:             vars,
+			Constraints:      constraints,
+			Density:          density,
+			BoolFraction:     0.3,
+			IntFraction:      0.3,
+			EqualityFraction: 0.2,
+		}
+		model, point := mip.RandomModel(seed, spec)
+		if len(model.Vars()) != vars {
+			t.Fatalf("got %d vars, want %d", len(model.Vars()), vars)
+		}
+		if vars > 0 && len(model.Constraints()) != constraints {
+			t.Fatalf("got %d constraints, want %d", len(model.Constraints()), constraints)
+		}
+
+		report := mip.Verify(model, pointSolution(model, point), mip.DefaultTolerances())
+		if !report.IsValid() {
+			t.Fatalf("planted point is not feasible: %+v", report)
+		}
+
+		again, _ := mip.RandomModel(seed, spec)
+		if mip.ModelHash(again) != mip.ModelHash(model) {
+			t.Fatal("same seed generated different models")
+		}
+	})
+}
+
+func TestCompareSolvers(t *testing.T) {
+	model, point := mip.RandomModel(7, mip.RandomModelSpec{
+		Vars:        10,
+		Constraints: 5,
+		Density:     0.5,
+	})
+	feasible := pointSolution(model, point)
+	reference := &testSolver{solution: feasible}
+	options := mip.SolveOptions{}
+	tolerances := mip.DefaultTolerances()
+
+	err := mip.CompareSolvers(model, reference.factory(), reference.factory(), options, tolerances)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	worse := *feasible
+	worse.objectiveValue++
+	candidate := &testSolver{solution: &worse}
+	err = mip.CompareSolvers(model, reference.factory(), candidate.factory(), options, tolerances)
+	if !errors.Is(err, mip.ErrSolverMismatch) {
+		t.Errorf("got error %v, want %v", err, mip.ErrSolverMismatch)
+	}
+
+	candidate = &testSolver{solution: &testSolution{optimal: true}}
+	err = mip.CompareSolvers(model, reference.factory(), candidate.factory(), options, tolerances)
+	if !errors.Is(err, mip.ErrSolverMismatch) {
+		t.Errorf("got error %v, want %v", err, mip.ErrSolverMismatch)
+	}
+}
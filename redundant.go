@@ -0,0 +1,309 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RemoveRedundant returns a copy of m without its redundant
+// constraints, which slow down the presolve of back-ends, and the removed
+// constraints of m. A constraint is redundant if the bounds of its vars
+// imply it, e.g. x + y <= 10 with x and y in [0, 5], if it duplicates an
+// earlier constraint, also when multiplied by a factor or -1, or if a
+// constraint with proportional terms and a tighter right-hand side dominates
+// it, e.g. 2 x + 2 y <= 12 is dominated by x + y <= 5. Of equivalent
+// constraints the first one is kept, and equality constraints are only
+// removed as duplicates of other equality constraints. Constraints with a
+// right-hand side or coefficient bound to a param are neither removed nor
+// considered to imply others, as changing the param changes them.
+//
+// The vars of the copy have the same indices as the vars of m, so a solution
+// of the copy is a solution of m. The removed constraints are ordered as in
+// m, which is not changed.
+//
+//	reduced, removed := mip.RemoveRedundant(model)
+func RemoveRedundant(m Model) (Model, Constraints) {
+	constraints := m.Constraints()
+	found := redundancies(constraints, parametricConstraints(m))
+	reduced := m.Copy().(*model)
+	if len(found) == 0 {
+		return reduced, nil
+	}
+	removed := make(Constraints, len(found))
+	indices := make(map[int]bool, len(found))
+	for i, r := range found {
+		removed[i] = constraints[r.index]
+		indices[r.index] = true
+	}
+	reduced.removeConstraints(indices)
+	return reduced, removed
+}
+
+// removeConstraints removes the constraints with indices from the invoking
+// model, together with their attributes, soft constraint data and param
+// bindings.
+func (m *model) removeConstraints(indices map[int]bool) {
+	removed := make(map[Constraint]bool, len(indices))
+	kept := m.constraints[:0]
+	for i, c := range m.constraints {
+		if !indices[i] {
+			kept = append(kept, c)
+			continue
+		}
+		removed[c] = true
+		m.nonZeros -= c.(*constraint).termCount()
+		delete(m.soft, c)
+		delete(m.attributes, c)
+	}
+	clear(m.constraints[len(kept):])
+	m.constraints = kept
+
+	for _, p := range m.params {
+		rightHandSides := p.rightHandSides[:0]
+		for _, c := range p.rightHandSides {
+			if !removed[c] {
+				rightHandSides = append(rightHandSides, c)
+			}
+		}
+		p.rightHandSides = rightHandSides
+		terms := p.terms[:0]
+		for _, t := range p.terms {
+			if !removed[t.constraint] {
+				terms = append(terms, t)
+			}
+		}
+		p.terms = terms
+	}
+}
+
+// redundancyTolerance is the tolerance of comparing activities and
+// right-hand sides when detecting redundant constraints.
+const redundancyTolerance = 1e-9
+
+// redundancy is a constraint which can be removed without changing the
+// feasible region of its model.
+type redundancy struct {
+	// index of the constraint in the model.
+	index int
+	// by is the index of the constraint which makes it redundant, -1 if it
+	// is implied by the bounds of its vars.
+	by     int
+	reason string
+}
+
+// redundancies returns the redundant constraints of constraints in order.
+// A constraint is redundant if the bounds of its vars imply it, or if an
+// earlier or tighter constraint with proportional terms implies it. Of a
+// group of equivalent constraints the first one is kept. Parametric
+// constraints are left out.
+func redundancies(constraints Constraints, parametric map[Constraint]bool) []redundancy {
+	result, redundant := boundRedundancies(constraints, parametric)
+
+	// Inequalities by their normalized terms, with their normalized
+	// right-hand sides. Equality constraints add both inequalities.
+	type half struct {
+		index         int
+		rightHandSide float64
+		equal         bool
+	}
+	groups := make(map[string][]half)
+	var keys []string
+	for i, c := range constraints {
+		if redundant[i] || parametric[c] {
+			continue
+		}
+		for _, sign := range constraintSigns(c.Sense()) {
+			key, scale, ok := normalizedKey(c.Terms(), sign)
+			if !ok {
+				continue
+			}
+			if _, exists := groups[key]; !exists {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], half{
+				index:         i,
+				rightHandSide: sign * c.RightHandSide() / scale,
+				equal:         c.Sense() == Equal,
+			})
+		}
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		// The tightest half, equality constraints and earlier constraints
+		// first on ties.
+		tightest := group[0]
+		for _, h := range group[1:] {
+			if h.rightHandSide < tightest.rightHandSide-redundancyTolerance ||
+				(math.Abs(h.rightHandSide-tightest.rightHandSide) <= redundancyTolerance &&
+					h.equal && !tightest.equal) {
+				tightest = h
+			}
+		}
+		for _, h := range group {
+			if h.index == tightest.index || h.equal || redundant[h.index] {
+				continue
+			}
+			reason := "dominated by %s"
+			if math.Abs(h.rightHandSide-tightest.rightHandSide) <= redundancyTolerance {
+				reason = "duplicate of %s"
+			}
+			result = append(result, redundancy{
+				index:  h.index,
+				by:     tightest.index,
+				reason: fmt.Sprintf(reason, constraintLabel(constraints[tightest.index], tightest.index)),
+			})
+			redundant[h.index] = true
+		}
+	}
+	result = append(result, duplicateEqualities(constraints, parametric, redundant)...)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].index < result[j].index
+	})
+	return result
+}
+
+// boundRedundancies returns the constraints which are not parametric and
+// implied by the bounds of their vars, and the set of their indices.
+func boundRedundancies(
+	constraints Constraints,
+	parametric map[Constraint]bool,
+) ([]redundancy, map[int]bool) {
+	var result []redundancy
+	redundant := make(map[int]bool)
+	for i, c := range constraints {
+		if !parametric[c] && impliedByBounds(c) {
+			result = append(result, redundancy{index: i, by: -1, reason: "implied by the bounds of its vars"})
+			redundant[i] = true
+		}
+	}
+	return result, redundant
+}
+
+// duplicateEqualities returns the equality constraints which are not
+// parametric, not redundant yet and duplicate an earlier one.
+func duplicateEqualities(
+	constraints Constraints,
+	parametric map[Constraint]bool,
+	redundant map[int]bool,
+) []redundancy {
+	var result []redundancy
+	first := make(map[string]int)
+	for i, c := range constraints {
+		if c.Sense() != Equal || redundant[i] || parametric[c] {
+			continue
+		}
+		key, scale, ok := normalizedKey(c.Terms(), 1)
+		if !ok {
+			continue
+		}
+		// An equality is the same multiplied by -1, the lesser key is used.
+		sign := 1.0
+		if negated, _, _ := normalizedKey(c.Terms(), -1); negated < key {
+			key, sign = negated, -1
+		}
+		key += "=" + strconv.FormatFloat(sign*c.RightHandSide()/scale, 'g', 12, 64)
+		if j, ok := first[key]; ok {
+			result = append(result, redundancy{
+				index:  i,
+				by:     j,
+				reason: "duplicate of " + constraintLabel(constraints[j], j),
+			})
+			redundant[i] = true
+			continue
+		}
+		first[key] = i
+	}
+	return result
+}
+
+// constraintSigns returns the signs by which c is multiplied to get its
+// inequalities in the form terms <= right-hand side.
+func constraintSigns(sense Sense) []float64 {
+	switch sense {
+	case LessThanOrEqual:
+		return []float64{1}
+	case GreaterThanOrEqual:
+		return []float64{-1}
+	}
+	return []float64{1, -1}
+}
+
+// normalizedKey returns a key identifying sign * terms up to a positive
+// factor, which is the absolute value of the coefficient of the var with
+// the lowest index, and the factor. Returns false for terms without
+// non-zero coefficients.
+func normalizedKey(terms Terms, sign float64) (string, float64, bool) {
+	sorted := sortedTerms(terms)
+	scale := 0.0
+	var sb strings.Builder
+	for _, t := range sorted {
+		if t.Coefficient() == 0 {
+			continue
+		}
+		if scale == 0 {
+			scale = math.Abs(t.Coefficient())
+		}
+		sb.WriteString(strconv.Itoa(t.Var().Index()))
+		sb.WriteByte(':')
+		sb.WriteString(strconv.FormatFloat(sign*t.Coefficient()/scale, 'g', 12, 64))
+		sb.WriteByte(' ')
+	}
+	return sb.String(), scale, scale != 0
+}
+
+// impliedByBounds returns true if the bounds of the vars of c imply c.
+// Equality constraints are never implied.
+func impliedByBounds(c Constraint) bool {
+	minimum, maximum := activityBounds(c.Terms())
+	switch c.Sense() {
+	case LessThanOrEqual:
+		return maximum <= c.RightHandSide()+redundancyTolerance
+	case GreaterThanOrEqual:
+		return minimum >= c.RightHandSide()-redundancyTolerance
+	}
+	return false
+}
+
+// activityBounds returns the minimum and maximum activity of terms given
+// the bounds of their vars.
+func activityBounds(terms Terms) (float64, float64) {
+	minimum, maximum := 0.0, 0.0
+	for _, t := range terms {
+		low, high := typedBounds(t.Var())
+		a := t.Coefficient()
+		if a == 0 {
+			continue
+		}
+		if a > 0 {
+			minimum += a * low
+			maximum += a * high
+			continue
+		}
+		minimum += a * high
+		maximum += a * low
+	}
+	return minimum, maximum
+}
+
+// typedBounds returns the bounds of v limited to [0, 1] if v is binary.
+func typedBounds(v Var) (float64, float64) {
+	lower, upper := v.LowerBound(), v.UpperBound()
+	if v.Type() == Binary {
+		lower, upper = math.Max(lower, 0), math.Min(upper, 1)
+	}
+	return lower, upper
+}
+
+// constraintLabel returns the name of c, C<index> if it has none.
+func constraintLabel(c Constraint, index int) string {
+	if name := c.Name(); name != "" {
+		return name
+	}
+	return fmt.Sprintf("C%d", index)
+}
@@ -0,0 +1,96 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestRemoveRedundant(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 5)
+	y := model.NewFloat(0, 5)
+	z := model.NewInt(0, 100)
+
+	bounds := model.NewConstraint(mip.LessThanOrEqual, 10)
+	bounds.SetName("bounds")
+	bounds.NewTerm(1, x)
+	bounds.NewTerm(1, y)
+
+	kept := model.NewConstraint(mip.LessThanOrEqual, 6)
+	kept.SetName("kept")
+	kept.NewTerm(1, x)
+	kept.NewTerm(1, z)
+
+	dominated := model.NewConstraint(mip.LessThanOrEqual, 14)
+	dominated.NewTerm(2, x)
+	dominated.NewTerm(2, z)
+
+	duplicate := model.NewConstraint(mip.GreaterThanOrEqual, -18)
+	duplicate.NewTerm(-3, x)
+	duplicate.NewTerm(-3, z)
+	duplicate.SetAttr("shift", 7)
+
+	equal := model.NewConstraint(mip.Equal, 2)
+	equal.NewTerm(1, y)
+	equal.NewTerm(-1, z)
+	equalDuplicate := model.NewConstraint(mip.Equal, -4)
+	equalDuplicate.NewTerm(-2, y)
+	equalDuplicate.NewTerm(2, z)
+
+	reduced, removed := mip.RemoveRedundant(model)
+	want := []mip.Constraint{bounds, dominated, duplicate, equalDuplicate}
+	if fmt.Sprint(removed) != fmt.Sprint(want) {
+		t.Errorf("got removed %v, want %v", removed, want)
+	}
+	if len(model.Constraints()) != 6 {
+		t.Errorf("got %d constraints in model, want 6", len(model.Constraints()))
+	}
+
+	constraints := reduced.Constraints()
+	if len(constraints) != 2 || constraints[0].Name() != "kept" ||
+		fmt.Sprint(constraints[1]) != fmt.Sprint(equal) {
+		t.Fatalf("got constraints %v, want kept and equal", constraints)
+	}
+	if len(reduced.Vars()) != 3 || reduced.Vars()[2].Name() != z.Name() {
+		t.Errorf("got vars %v, want the vars of model", reduced.Vars())
+	}
+
+	_, removed = mip.RemoveRedundant(reduced)
+	if len(removed) != 0 {
+		t.Errorf("got removed %v from reduced model, want none", removed)
+	}
+}
+
+func TestRemoveRedundantParams(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 5)
+	y := model.NewFloat(0, 5)
+
+	// Implied by the bounds of x for the current capacity only.
+	capacity := model.NewParam("cap", 10)
+	bound := model.NewConstraint(mip.LessThanOrEqual, 0)
+	bound.NewTerm(1, x)
+	bound.BindRightHandSide(capacity)
+
+	// Dominates the constraint after it for the current weight only.
+	weight := model.NewParam("weight", 1)
+	weighted := model.NewConstraint(mip.LessThanOrEqual, 4)
+	weighted.NewParamTerm(weight, y)
+	loose := model.NewConstraint(mip.LessThanOrEqual, 8)
+	loose.NewTerm(2, y)
+
+	reduced, removed := mip.RemoveRedundant(model)
+	if len(removed) != 0 {
+		t.Errorf("got removed constraints %v, want none", removed)
+	}
+	if err := reduced.SetParam("cap", 3); err != nil {
+		t.Fatal(err)
+	}
+	if got := reduced.Constraints()[0].RightHandSide(); got != 3 {
+		t.Errorf("got right-hand side %v of the copy, want 3", got)
+	}
+}
@@ -0,0 +1,150 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"math"
+)
+
+// ReformulationKind is the kind of a Reformulation.
+type ReformulationKind string
+
+// Kinds of a Reformulation.
+const (
+	// TightenBigM replaces the coefficient of a bool var which switches off
+	// a constraint by the smallest value that still switches it off.
+	TightenBigM ReformulationKind = "tighten_big_m"
+	// IntToBool changes the type of an int var with bounds in [0, 1] to
+	// Binary.
+	IntToBool ReformulationKind = "int_to_bool"
+	// RedundantConstraint removes a constraint which is implied by the
+	// bounds of its vars or by another constraint.
+	RedundantConstraint ReformulationKind = "redundant_constraint"
+)
+
+// Reformulation is a suggested change of a model which does not change its
+// solutions but makes it easier to solve, see SuggestReformulations.
+type Reformulation struct {
+	// Kind of the reformulation.
+	Kind ReformulationKind `json:"kind"`
+	// Constraint to change, nil for IntToBool.
+	Constraint Constraint `json:"-"`
+	// Var to change, nil for RedundantConstraint.
+	Var Var `json:"-"`
+	// Coefficient is the coefficient of Var in Constraint for TightenBigM.
+	Coefficient float64 `json:"coefficient,omitempty"`
+	// Suggested is the tighter coefficient for TightenBigM.
+	Suggested float64 `json:"suggested,omitempty"`
+	// Message describes the reformulation using the names of the constraint
+	// and var, C<index> for an unnamed constraint.
+	Message string `json:"message"`
+}
+
+// bigMTolerance is the relative amount by which a big-M has to exceed its
+// tightest value to be reported.
+const bigMTolerance = 1e-6
+
+// SuggestReformulations returns reformulations which make model easier to
+// solve without changing its solutions, to surface weak formulations in code
+// review:
+//
+//   - TightenBigM for a bool var b in an inequality like x - M b <= r whose
+//     coefficient is larger than needed to switch the constraint off. The
+//     tighter M is the maximum activity of the other terms given the bounds
+//     of their vars minus r. Constraints with an unbounded activity are
+//     skipped.
+//   - IntToBool for an int var with bounds in [0, 1].
+//   - RedundantConstraint for a constraint which the bounds of its vars
+//     imply, or which duplicates or is dominated by another constraint with
+//     proportional terms, see RemoveRedundant.
+//
+// Constraints with a right-hand side or coefficient bound to a param are
+// skipped, as changing the param invalidates the reformulations.
+//
+// The reformulations are ordered by constraint, then by var. Each one is valid
+// on its own and together with the others, model is not changed.
+//
+//	for _, r := range mip.SuggestReformulations(model) {
+//		fmt.Println(r.Kind, r.Message)
+//	}
+func SuggestReformulations(model Model) []Reformulation {
+	var result []Reformulation
+	constraints := model.Constraints()
+	parametric := parametricConstraints(model)
+	redundant := make(map[int]redundancy)
+	for _, r := range redundancies(constraints, parametric) {
+		redundant[r.index] = r
+	}
+	for i, c := range constraints {
+		if parametric[c] {
+			continue
+		}
+		if r, ok := redundant[i]; ok {
+			result = append(result, Reformulation{
+				Kind:       RedundantConstraint,
+				Constraint: c,
+				Message:    fmt.Sprintf("constraint %s is %s", constraintLabel(c, i), r.reason),
+			})
+			continue
+		}
+		result = append(result, bigMs(c, i)...)
+	}
+	for _, v := range model.Vars() {
+		if v.Type() != Integer {
+			continue
+		}
+		if lower, upper := v.LowerBound(), v.UpperBound(); lower >= 0 && upper <= 1 {
+			result = append(result, Reformulation{
+				Kind:    IntToBool,
+				Var:     v,
+				Message: fmt.Sprintf("int var %v has bounds [%v, %v] and can be bool", v, lower, upper),
+			})
+		}
+	}
+	return result
+}
+
+// bigMs returns the TightenBigM reformulations of c, the constraint with
+// index in its model.
+func bigMs(c Constraint, index int) []Reformulation {
+	if c.Sense() == Equal {
+		return nil
+	}
+	// Multiplying by sign turns c into terms <= right-hand side.
+	sign := 1.0
+	if c.Sense() == GreaterThanOrEqual {
+		sign = -1.0
+	}
+	terms := c.Terms()
+	_, maximum := activityBounds(terms)
+	var result []Reformulation
+	for _, t := range terms {
+		coefficient := sign * t.Coefficient()
+		if coefficient >= 0 || t.Var().Type() != Binary {
+			continue
+		}
+		// The term is 0 at the maximum activity since its coefficient is
+		// negative, so the maximum activity is the one of the other terms.
+		tightest := maximum - sign*c.RightHandSide()
+		if math.IsInf(tightest, 0) || math.IsNaN(tightest) || tightest <= 0 ||
+			-coefficient <= tightest*(1+bigMTolerance) {
+			continue
+		}
+		result = append(result, Reformulation{
+			Kind:        TightenBigM,
+			Constraint:  c,
+			Var:         t.Var(),
+			Coefficient: t.Coefficient(),
+			Suggested:   -sign * tightest,
+			Message: fmt.Sprintf(
+				"coefficient %v of %v in constraint %s can be tightened to %v",
+				t.Coefficient(),
+				t.Var(),
+				constraintLabel(c, index),
+				-sign*tightest,
+			),
+		})
+	}
+	return result
+}
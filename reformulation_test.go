@@ -0,0 +1,116 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestSuggestReformulations(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 50)
+	y := model.NewFloat(0, 30)
+	open := model.NewBool()
+	flag := model.NewInt(0, 1)
+	count := model.NewInt(0, 5)
+
+	// x + y <= 1000 open with x + y at most 80.
+	bigM := model.NewConstraint(mip.LessThanOrEqual, 0)
+	bigM.SetName("capacity")
+	bigM.NewTerm(1, x)
+	bigM.NewTerm(1, y)
+	bigM.NewTerm(-1000, open)
+
+	// Already tight: x >= 10 unless open, x at least 0.
+	tight := model.NewConstraint(mip.GreaterThanOrEqual, 10)
+	tight.NewTerm(1, x)
+	tight.NewTerm(10, open)
+
+	// Implied by the bounds of x and y.
+	bounds := model.NewConstraint(mip.LessThanOrEqual, 100)
+	bounds.NewTerm(1, x)
+	bounds.NewTerm(1, y)
+
+	// Dominated by the constraint after it.
+	loose := model.NewConstraint(mip.LessThanOrEqual, 40)
+	loose.NewTerm(2, x)
+	loose.NewTerm(2, count)
+	strict := model.NewConstraint(mip.LessThanOrEqual, 10)
+	strict.NewTerm(1, x)
+	strict.NewTerm(1, count)
+
+	reformulations := mip.SuggestReformulations(model)
+	if len(reformulations) != 4 {
+		t.Fatalf("got %d reformulations %+v, want 4", len(reformulations), reformulations)
+	}
+
+	r := reformulations[0]
+	if r.Kind != mip.TightenBigM || r.Constraint != bigM || r.Var.Index() != open.Index() ||
+		r.Coefficient != -1000 || r.Suggested != -80 {
+		t.Errorf("got %+v, want big-M of capacity tightened to -80", r)
+	}
+	if want := "coefficient -1000 of B2 in constraint capacity can be tightened to -80"; r.Message != want {
+		t.Errorf("got message %q, want %q", r.Message, want)
+	}
+
+	r = reformulations[1]
+	if r.Kind != mip.RedundantConstraint || r.Constraint != bounds ||
+		r.Message != "constraint C2 is implied by the bounds of its vars" {
+		t.Errorf("got %+v, want C2 implied by bounds", r)
+	}
+
+	r = reformulations[2]
+	if r.Kind != mip.RedundantConstraint || r.Constraint != loose ||
+		r.Message != "constraint C3 is dominated by C4" {
+		t.Errorf("got %+v, want C3 dominated by C4", r)
+	}
+
+	r = reformulations[3]
+	if r.Kind != mip.IntToBool || r.Var.Index() != flag.Index() || r.Constraint != nil {
+		t.Errorf("got %+v, want int var I3 to bool", r)
+	}
+
+	// A duplicate equality and a >= duplicate of a <= constraint.
+	model = mip.NewModel()
+	a := model.NewFloat(0, mip.Infinity())
+	b := model.NewFloat(0, mip.Infinity())
+	for _, scale := range []float64{1, -3} {
+		c := model.NewConstraint(mip.Equal, 4*scale)
+		c.NewTerm(scale, a)
+		c.NewTerm(2*scale, b)
+	}
+	le := model.NewConstraint(mip.LessThanOrEqual, 5)
+	le.NewTerm(1, a)
+	le.NewTerm(-1, b)
+	ge := model.NewConstraint(mip.GreaterThanOrEqual, -5)
+	ge.NewTerm(-1, a)
+	ge.NewTerm(1, b)
+
+	reformulations = mip.SuggestReformulations(model)
+	if len(reformulations) != 2 ||
+		reformulations[0].Message != "constraint C1 is duplicate of C0" ||
+		reformulations[1].Message != "constraint C3 is duplicate of C2" {
+		t.Errorf("got %+v, want C1 and C3 duplicates", reformulations)
+	}
+}
+
+func TestSuggestReformulationsParams(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 50)
+	open := model.NewBool()
+
+	// The coefficient of open follows m, SetParam would undo a tightening.
+	m := model.NewParam("m", 1000)
+	bigM := model.NewConstraint(mip.LessThanOrEqual, 0)
+	bigM.NewTerm(1, x)
+	bigM.NewParamTerm(m, open)
+	if err := model.SetParam("m", -1000); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := mip.SuggestReformulations(model); len(got) != 0 {
+		t.Errorf("got reformulations %+v, want none", got)
+	}
+}
@@ -0,0 +1,185 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownProvider is returned when a solver is requested from a provider
+// which has not been registered.
+var ErrUnknownProvider = errors.New("unknown solver provider")
+
+// ErrConfigNotSupported is returned when a solver is requested with a
+// configuration from a provider which does not accept one. It is an
+// ErrUnsupportedFeature.
+var ErrConfigNotSupported = NewKindError(ErrUnsupportedFeature, "solver configuration not supported")
+
+// SolverConfig configures a back-end when a solver is created, instead of
+// relying on environment variables. Back-ends document which fields they use.
+type SolverConfig struct {
+	// LicensePath is the location of the license file of the back-end.
+	LicensePath string `json:"license_path"`
+	// LibraryPath is the location of the shared library of the back-end.
+	LibraryPath string `json:"library_path"`
+	// Environment is a handle to a back-end environment, e.g. to share a
+	// licensed environment between solvers.
+	Environment any `json:"-"`
+	// Settings are additional back-end specific settings, e.g. the address
+	// of a token server.
+	Settings map[string]string `json:"settings"`
+}
+
+// IsZero returns true if no field of the invoking config is set.
+func (c SolverConfig) IsZero() bool {
+	return c.LicensePath == "" &&
+		c.LibraryPath == "" &&
+		c.Environment == nil &&
+		len(c.Settings) == 0
+}
+
+// ConfigurableSolverFactory creates a solver for a model using config.
+type ConfigurableSolverFactory func(model Model, config SolverConfig) (Solver, error)
+
+// solverProviders are the factories registered with RegisterSolverProvider
+// and RegisterConfigurableSolverProvider.
+var solverProviders = struct {
+	sync.RWMutex
+	factories map[SolverProvider]ConfigurableSolverFactory
+	versions  map[SolverProvider]string
+}{
+	factories: map[SolverProvider]ConfigurableSolverFactory{},
+	versions:  map[SolverProvider]string{},
+}
+
+// RegisterSolverProvider makes the back-end provider available to NewSolver,
+// creating solvers with factory. Back-ends register themselves from an init
+// function, so importing the module of a back-end is enough to use it:
+//
+//	import _ "example.com/mybackend"
+//
+//	solver, err := mip.NewSolver("mybackend", model)
+//
+// Panics if provider is empty, factory is nil or provider is already
+// registered.
+func RegisterSolverProvider(provider SolverProvider, factory SolverFactory) {
+	if factory == nil {
+		panic(fmt.Sprintf("mip: RegisterSolverProvider %q with nil factory", provider))
+	}
+	registerSolverProvider(
+		"RegisterSolverProvider",
+		provider,
+		func(model Model, config SolverConfig) (Solver, error) {
+			if !config.IsZero() {
+				return nil, fmt.Errorf("%w: provider %q", ErrConfigNotSupported, provider)
+			}
+			return factory(model)
+		},
+	)
+}
+
+// RegisterConfigurableSolverProvider makes the back-end provider available
+// to NewSolver and NewSolverWithConfig, creating solvers with factory. NewSolver
+// invokes factory with a zero config. Panics if provider is empty, factory is
+// nil or provider is already registered.
+func RegisterConfigurableSolverProvider(
+	provider SolverProvider,
+	factory ConfigurableSolverFactory,
+) {
+	if factory == nil {
+		panic(fmt.Sprintf("mip: RegisterConfigurableSolverProvider %q with nil factory", provider))
+	}
+	registerSolverProvider("RegisterConfigurableSolverProvider", provider, factory)
+}
+
+func registerSolverProvider(
+	caller string,
+	provider SolverProvider,
+	factory ConfigurableSolverFactory,
+) {
+	if provider == "" {
+		panic(fmt.Sprintf("mip: %s with empty provider", caller))
+	}
+	solverProviders.Lock()
+	defer solverProviders.Unlock()
+	if _, ok := solverProviders.factories[provider]; ok {
+		panic(fmt.Sprintf("mip: %s %q registered twice", caller, provider))
+	}
+	solverProviders.factories[provider] = factory
+}
+
+// RegisterSolverProviderVersion records the version of the back-end
+// provider, e.g. the version of the linked library, for the manifests of its
+// solves, see NewManifest. Back-ends register their version next to their
+// factory. Registering a version again replaces it. Panics if provider is
+// empty.
+func RegisterSolverProviderVersion(provider SolverProvider, version string) {
+	if provider == "" {
+		panic("mip: RegisterSolverProviderVersion with empty provider")
+	}
+	solverProviders.Lock()
+	defer solverProviders.Unlock()
+	solverProviders.versions[provider] = version
+}
+
+// SolverProviderVersion returns the version registered for provider with
+// RegisterSolverProviderVersion, empty if none has been registered.
+func SolverProviderVersion(provider SolverProvider) string {
+	solverProviders.RLock()
+	defer solverProviders.RUnlock()
+	return solverProviders.versions[provider]
+}
+
+// SolverProviders returns the registered providers in lexicographic order.
+func SolverProviders() []SolverProvider {
+	solverProviders.RLock()
+	defer solverProviders.RUnlock()
+	providers := make([]SolverProvider, 0, len(solverProviders.factories))
+	for provider := range solverProviders.factories {
+		providers = append(providers, provider)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i] < providers[j]
+	})
+	return providers
+}
+
+// NewSolver creates a solver for model using the factory registered for
+// provider. The solver applies the default options of provider, see
+// SetDefaultSolveOptions, use UnwrapSolver to get the solver of the
+// back-end. Returns an error wrapping ErrUnknownProvider if provider has not
+// been registered, see RegisterSolverProvider.
+func NewSolver(provider SolverProvider, model Model) (Solver, error) {
+	return NewSolverWithConfig(provider, model, SolverConfig{})
+}
+
+// NewSolverWithConfig creates a solver for model like NewSolver, handing
+// config to the back-end, e.g. the location of its license:
+//
+//	solver, err := mip.NewSolverWithConfig("mybackend", model, mip.SolverConfig{
+//		LicensePath: "/opt/licenses/mybackend.lic",
+//	})
+//
+// Returns an error wrapping ErrConfigNotSupported if config is not zero and
+// provider has been registered with RegisterSolverProvider instead of
+// RegisterConfigurableSolverProvider.
+func NewSolverWithConfig(
+	provider SolverProvider,
+	model Model,
+	config SolverConfig,
+) (Solver, error) {
+	solverProviders.RLock()
+	factory, ok := solverProviders.factories[provider]
+	solverProviders.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q, registered: %v", ErrUnknownProvider, provider, SolverProviders())
+	}
+	solver, err := factory(model, config)
+	if err != nil {
+		return nil, err
+	}
+	return &defaultsSolver{solver: solver, provider: provider, model: model}, nil
+}
@@ -0,0 +1,73 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestRegisterSolverProvider(t *testing.T) {
+	model := mip.NewModel()
+	if _, err := mip.NewSolver("test-registry", model); !errors.Is(err, mip.ErrUnknownProvider) {
+		t.Errorf("got error %v, want %v", err, mip.ErrUnknownProvider)
+	}
+
+	solver := &testSolver{solution: newTestSolution(1, nil)}
+	mip.RegisterSolverProvider("test-registry", solver.factory())
+
+	found := false
+	for _, provider := range mip.SolverProviders() {
+		found = found || provider == "test-registry"
+	}
+	if !found {
+		t.Errorf("test-registry not in %v", mip.SolverProviders())
+	}
+
+	created, err := mip.NewSolver("test-registry", model)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if mip.UnwrapSolver(created) != solver {
+		t.Errorf("NewSolver did not use the registered factory")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("registering a provider twice did not panic")
+		}
+	}()
+	mip.RegisterSolverProvider("test-registry", solver.factory())
+}
+
+func TestNewSolverWithConfig(t *testing.T) {
+	model := mip.NewModel()
+	config := mip.SolverConfig{LicensePath: "test.lic"}
+
+	solver := &testSolver{solution: newTestSolution(1, nil)}
+	mip.RegisterSolverProvider("test-unconfigurable", solver.factory())
+	_, err := mip.NewSolverWithConfig("test-unconfigurable", model, config)
+	if !errors.Is(err, mip.ErrConfigNotSupported) {
+		t.Errorf("got error %v, want %v", err, mip.ErrConfigNotSupported)
+	}
+
+	var got []mip.SolverConfig
+	mip.RegisterConfigurableSolverProvider(
+		"test-configurable",
+		func(_ mip.Model, config mip.SolverConfig) (mip.Solver, error) {
+			got = append(got, config)
+			return solver, nil
+		},
+	)
+	if _, err := mip.NewSolverWithConfig("test-configurable", model, config); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := mip.NewSolver("test-configurable", model); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(got) != 2 || got[0].LicensePath != "test.lic" || !got[1].IsZero() {
+		t.Errorf("got configs %v", got)
+	}
+}
@@ -0,0 +1,162 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ModelChanges captures the modifications of a model between two solves, see
+// Resolve.
+type ModelChanges struct {
+	// Bounds are the modified bounds of vars.
+	Bounds []BoundChange
+	// RightHandSides are the modified right-hand sides of constraints.
+	RightHandSides []RightHandSideChange
+	// NewConstraints are the constraints added to the model, in order.
+	NewConstraints []ConstraintSpec
+}
+
+// BoundChange sets the bounds of a var. Bounds of int vars are rounded
+// inwards to integers. Bounds of bool vars can not be changed, use Var.Fix
+// instead.
+type BoundChange struct {
+	// Var of which the bounds change.
+	Var Var
+	// LowerBound is the new lower bound of Var.
+	LowerBound float64
+	// UpperBound is the new upper bound of Var.
+	UpperBound float64
+}
+
+// RightHandSideChange sets the right-hand side of a constraint.
+type RightHandSideChange struct {
+	// Constraint of which the right-hand side changes.
+	Constraint Constraint
+	// RightHandSide is the new right-hand side of Constraint.
+	RightHandSide float64
+}
+
+// Resolver is implemented by solvers which keep the state of the back-end
+// between solves and can apply changes to it incrementally, warm-starting
+// from the previous basis or incumbent.
+type Resolver interface {
+	// Resolve solves the model of the invoking solver again after changes
+	// have been applied to it. The model already reflects changes when
+	// Resolve is invoked, the constraints added by changes are the last
+	// len(changes.NewConstraints) constraints of the model.
+	Resolve(changes ModelChanges, options SolveOptions) (Solution, error)
+}
+
+// Apply applies the invoking changes to model. Returns the added constraints
+// in the order of NewConstraints. The changes are validated before model is
+// modified, only adding constraints can fail after that, if model exceeds its
+// limits.
+func (c ModelChanges) Apply(model Model) (Constraints, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if err := checkModelMutable(model); err != nil {
+		return nil, err
+	}
+	for _, change := range c.Bounds {
+		switch v := change.Var.(type) {
+		case *floatVariable:
+			v.lowerBound = change.LowerBound
+			v.upperBound = change.UpperBound
+		case *intVariable:
+			v.lowerBound = intBound(math.Ceil(change.LowerBound))
+			v.upperBound = intBound(math.Floor(change.UpperBound))
+		}
+	}
+	for _, change := range c.RightHandSides {
+		change.Constraint.(*constraint).rightHandSide = change.RightHandSide
+	}
+	constraints := make(Constraints, len(c.NewConstraints))
+	for i, spec := range c.NewConstraints {
+		constraint, err := model.NewConstraintChecked(spec.Sense, spec.RightHandSide)
+		if err != nil {
+			return constraints[:i], err
+		}
+		for _, t := range spec.Terms {
+			if _, err := constraint.NewTermChecked(t.Coefficient, t.Var); err != nil {
+				return constraints[:i], err
+			}
+		}
+		constraints[i] = constraint
+	}
+	return constraints, nil
+}
+
+func (c ModelChanges) validate() error {
+	for _, change := range c.Bounds {
+		if err := checkNaN("lower bound", change.LowerBound); err != nil {
+			return err
+		}
+		if err := checkNaN("upper bound", change.UpperBound); err != nil {
+			return err
+		}
+		switch change.Var.(type) {
+		case *floatVariable:
+		case *intVariable:
+			for _, bound := range []float64{change.LowerBound, change.UpperBound} {
+				if !math.IsInf(bound, 0) && math.Abs(bound) > MaxIntBound {
+					return fmt.Errorf(
+						"%w: %v exceeds %d in magnitude",
+						ErrIntBoundOutOfRange,
+						bound,
+						int64(MaxIntBound),
+					)
+				}
+			}
+		default:
+			return fmt.Errorf("bounds of var %v can not be changed", change.Var)
+		}
+	}
+	for _, change := range c.RightHandSides {
+		if err := checkNaN("right-hand side", change.RightHandSide); err != nil {
+			return err
+		}
+		if _, ok := change.Constraint.(*constraint); !ok {
+			return errors.New("right-hand side of constraint can not be changed")
+		}
+	}
+	for _, spec := range c.NewConstraints {
+		if err := checkNaN("constraint right-hand side", spec.RightHandSide); err != nil {
+			return err
+		}
+		for _, t := range spec.Terms {
+			if err := checkNaN("constraint term coefficient", t.Coefficient); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Resolve applies changes to model and solves it again with solver, which
+// must have been created for model. Rolling-horizon planning re-solves
+// nearly identical models, if solver is a Resolver the back-end applies the
+// changes incrementally and warm-starts, otherwise solver solves the changed
+// model from scratch.
+//
+//	changes := mip.ModelChanges{
+//		RightHandSides: []mip.RightHandSideChange{{Constraint: demand, RightHandSide: 120}},
+//	}
+//	solution, err := mip.Resolve(model, solver, changes, options)
+func Resolve(
+	model Model,
+	solver Solver,
+	changes ModelChanges,
+	options SolveOptions,
+) (Solution, error) {
+	if _, err := changes.Apply(model); err != nil {
+		return nil, err
+	}
+	if resolver, ok := UnwrapSolver(solver).(Resolver); ok {
+		return resolver.Resolve(changes, withProviderDefaults(solver, options))
+	}
+	return solver.Solve(options)
+}
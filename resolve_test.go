@@ -0,0 +1,106 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// resolvingSolver is a testSolver which records the changes it resolves.
+type resolvingSolver struct {
+	testSolver
+	changes []mip.ModelChanges
+}
+
+func (s *resolvingSolver) Resolve(
+	changes mip.ModelChanges,
+	options mip.SolveOptions,
+) (mip.Solution, error) {
+	s.changes = append(s.changes, changes)
+	return s.Solve(options)
+}
+
+func TestResolve(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewInt(0, 10)
+	c := model.NewConstraint(mip.LessThanOrEqual, 5)
+	c.NewTerm(1, x)
+	c.NewTerm(1, y)
+
+	changes := mip.ModelChanges{
+		Bounds: []mip.BoundChange{
+			{Var: x, LowerBound: 1, UpperBound: 2},
+			{Var: y, LowerBound: 0.5, UpperBound: math.Inf(1)},
+		},
+		RightHandSides: []mip.RightHandSideChange{{Constraint: c, RightHandSide: 7}},
+		NewConstraints: []mip.ConstraintSpec{{
+			Sense:         mip.GreaterThanOrEqual,
+			RightHandSide: 1,
+			Terms:         []mip.TermSpec{{Coefficient: 1, Var: y}},
+		}},
+	}
+	solver := &resolvingSolver{testSolver: testSolver{solution: newTestSolution(1, nil)}}
+	if _, err := mip.Resolve(model, solver, changes, mip.SolveOptions{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(solver.changes) != 1 {
+		t.Errorf("got %d resolves, want 1", len(solver.changes))
+	}
+	if x.LowerBound() != 1 || x.UpperBound() != 2 {
+		t.Errorf("got x in [%v, %v], want [1, 2]", x.LowerBound(), x.UpperBound())
+	}
+	if y.LowerBound() != 1 || !math.IsInf(y.UpperBound(), 1) {
+		t.Errorf("got y in [%v, %v], want [1, +Inf]", y.LowerBound(), y.UpperBound())
+	}
+	if c.RightHandSide() != 7 {
+		t.Errorf("got right-hand side %v, want 7", c.RightHandSide())
+	}
+	if len(model.Constraints()) != 2 {
+		t.Errorf("got %d constraints, want 2", len(model.Constraints()))
+	}
+
+	plain := &testSolver{solution: newTestSolution(1, nil)}
+	changes = mip.ModelChanges{
+		RightHandSides: []mip.RightHandSideChange{{Constraint: c, RightHandSide: 3}},
+	}
+	if _, err := mip.Resolve(model, plain, changes, mip.SolveOptions{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if c.RightHandSide() != 3 {
+		t.Errorf("got right-hand side %v, want 3", c.RightHandSide())
+	}
+}
+
+func TestModelChangesInvalid(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	b := model.NewBool()
+	c := model.NewConstraint(mip.LessThanOrEqual, 5)
+
+	changes := mip.ModelChanges{
+		Bounds:         []mip.BoundChange{{Var: x, LowerBound: 1, UpperBound: 2}},
+		RightHandSides: []mip.RightHandSideChange{{Constraint: c, RightHandSide: math.NaN()}},
+	}
+	if _, err := changes.Apply(model); !errors.Is(err, mip.ErrNaN) {
+		t.Errorf("got error %v, want %v", err, mip.ErrNaN)
+	}
+	if x.LowerBound() != 0 {
+		t.Errorf("invalid changes modified the model")
+	}
+
+	changes = mip.ModelChanges{Bounds: []mip.BoundChange{{Var: b, LowerBound: 1, UpperBound: 1}}}
+	if _, err := changes.Apply(model); err == nil {
+		t.Errorf("changing bounds of a bool var did not fail")
+	}
+
+	y := model.NewInt(0, 10)
+	changes = mip.ModelChanges{Bounds: []mip.BoundChange{{Var: y, LowerBound: 0, UpperBound: 1e300}}}
+	if _, err := changes.Apply(model); !errors.Is(err, mip.ErrIntBoundOutOfRange) {
+		t.Errorf("got error %v, want %v", err, mip.ErrIntBoundOutOfRange)
+	}
+}
@@ -0,0 +1,140 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidBudget is returned if the budget of uncertainty of a robust
+// constraint is not in [0, number of uncertain terms].
+var ErrInvalidBudget = errors.New("invalid budget of uncertainty")
+
+// UncertainTermSpec specifies a term of a RobustConstraintSpec whose
+// coefficient takes any value in [Coefficient - Deviation, Coefficient +
+// Deviation].
+type UncertainTermSpec struct {
+	// Coefficient is the nominal coefficient of the term.
+	Coefficient float64
+	// Deviation is the maximum deviation of the coefficient from its nominal
+	// value, 0 for a certain coefficient.
+	Deviation float64
+	// Var of the term.
+	Var Var
+}
+
+// RobustConstraintSpec specifies a constraint with uncertain coefficients
+// added by NewRobustConstraint.
+type RobustConstraintSpec struct {
+	// Sense of the constraint, LessThanOrEqual or GreaterThanOrEqual.
+	Sense Sense
+	// RightHandSide of the constraint.
+	RightHandSide float64
+	// Terms of the constraint.
+	Terms []UncertainTermSpec
+	// Budget is the number of coefficients which deviate from their nominal
+	// value at the same time, Γ in the model of Bertsimas and Sim. 0 ignores
+	// the uncertainty, the number of uncertain terms protects against all
+	// coefficients deviating. A fractional budget lets one more coefficient
+	// deviate by that fraction.
+	Budget float64
+}
+
+// NewRobustConstraint adds the robust counterpart of spec by Bertsimas and
+// Sim to model: the constraint holds for any Budget coefficients deviating
+// from their nominal values. The counterpart of Σ a_j x_j <= b is
+//
+//	Σ a_j x_j + Γ z + Σ p_j <= b
+//	z + p_j >= â_j y_j  for each uncertain term j
+//	-y_j <= x_j <= y_j  for each uncertain term j
+//	z, p_j, y_j >= 0
+//
+// with the deviations â_j. The returned constraint is the first one, which
+// the caller may name. Vars with a non-negative lower bound are their own
+// absolute value and need no y_j. Returns an error wrapping
+// ErrInvalidBudget if the budget is not in [0, number of uncertain terms]
+// and an error if the sense is Equal, a deviation is negative or a number
+// is NaN.
+//
+//	c, err := mip.NewRobustConstraint(model, mip.RobustConstraintSpec{
+//		Sense:         mip.LessThanOrEqual,
+//		RightHandSide: capacity,
+//		Terms:         weights, // nominal weights with deviations
+//		Budget:        2,
+//	})
+func NewRobustConstraint(model Model, spec RobustConstraintSpec) (Constraint, error) {
+	if spec.Sense == Equal {
+		return nil, errors.New("robust constraint must be an inequality")
+	}
+	uncertain := 0
+	for _, t := range spec.Terms {
+		if err := checkNaN("coefficient", t.Coefficient); err != nil {
+			return nil, err
+		}
+		if err := checkNaN("deviation", t.Deviation); err != nil {
+			return nil, err
+		}
+		if t.Deviation < 0 {
+			return nil, fmt.Errorf("deviation %v is negative", t.Deviation)
+		}
+		if t.Deviation > 0 {
+			uncertain++
+		}
+	}
+	if math.IsNaN(spec.Budget) || spec.Budget < 0 || spec.Budget > float64(uncertain) {
+		return nil, fmt.Errorf(
+			"%w: %v not in [0, %d]",
+			ErrInvalidBudget,
+			spec.Budget,
+			uncertain,
+		)
+	}
+
+	// The protection is added to the left-hand side of a <= constraint and
+	// subtracted from the left-hand side of a >= constraint.
+	sign := 1.0
+	if spec.Sense == GreaterThanOrEqual {
+		sign = -1.0
+	}
+	c := model.NewConstraint(spec.Sense, spec.RightHandSide)
+	for _, t := range spec.Terms {
+		c.NewTerm(t.Coefficient, t.Var)
+	}
+	if uncertain == 0 {
+		return c, nil
+	}
+
+	z := model.NewFloat(0, Infinity())
+	c.NewTerm(sign*spec.Budget, z)
+	for _, t := range spec.Terms {
+		if t.Deviation == 0 {
+			continue
+		}
+		p := model.NewFloat(0, Infinity())
+		c.NewTerm(sign, p)
+
+		protection := model.NewConstraint(GreaterThanOrEqual, 0)
+		protection.NewTerm(1, z)
+		protection.NewTerm(1, p)
+		protection.NewTerm(-t.Deviation, absoluteValue(model, t.Var))
+	}
+	return c, nil
+}
+
+// absoluteValue returns a var which is at least the absolute value of v, v
+// itself if it is non-negative.
+func absoluteValue(model Model, v Var) Var {
+	if v.LowerBound() >= 0 {
+		return v
+	}
+	y := model.NewFloat(0, Infinity())
+	upper := model.NewConstraint(LessThanOrEqual, 0)
+	upper.NewTerm(1, v)
+	upper.NewTerm(-1, y)
+	lower := model.NewConstraint(GreaterThanOrEqual, 0)
+	lower.NewTerm(1, v)
+	lower.NewTerm(1, y)
+	return y
+}
@@ -0,0 +1,90 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestNewRobustConstraint(t *testing.T) {
+	model := mip.NewModel()
+	x := []mip.Bool{model.NewBool(), model.NewBool(), model.NewBool()}
+	c, err := mip.NewRobustConstraint(model, mip.RobustConstraintSpec{
+		Sense:         mip.LessThanOrEqual,
+		RightHandSide: 10,
+		Terms: []mip.UncertainTermSpec{
+			{Coefficient: 5, Deviation: 2, Var: x[0]},
+			{Coefficient: 4, Deviation: 1, Var: x[1]},
+			{Coefficient: 3, Deviation: 3, Var: x[2]},
+		},
+		Budget: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// z and one p per uncertain term, no absolute values for bools.
+	vars := model.Vars()
+	if len(vars) != 7 || len(model.Constraints()) != 4 {
+		t.Fatalf("got %d vars and %d constraints, want 7 and 4", len(vars), len(model.Constraints()))
+	}
+	z := vars[3]
+	if term, _ := c.Term(z); term.Coefficient() != 1 {
+		t.Errorf("got budget coefficient %v, want 1", term.Coefficient())
+	}
+
+	// Nominal weight 7 plus the largest deviation 3 fits.
+	feasible := mip.Evaluate(model, map[mip.Var]float64{x[1]: 1, x[2]: 1, z: 3})
+	if violated := feasible.Violated(1e-9); len(violated) > 0 {
+		t.Errorf("violated constraints: %v", violated)
+	}
+	// Nominal weight 9 fits, but not with the deviation 2 of x[0].
+	infeasible := mip.Evaluate(model, map[mip.Var]float64{x[0]: 1, x[1]: 1, z: 2})
+	if violated := infeasible.Violated(1e-9); len(violated) != 1 || violated[0].Constraint != c {
+		t.Errorf("got violated constraints %v, want the robust constraint", violated)
+	}
+}
+
+func TestNewRobustConstraintFree(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(-5, 5)
+	_, err := mip.NewRobustConstraint(model, mip.RobustConstraintSpec{
+		Sense:         mip.GreaterThanOrEqual,
+		RightHandSide: 1,
+		Terms:         []mip.UncertainTermSpec{{Coefficient: 1, Deviation: 0.5, Var: x}},
+		Budget:        1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// x, z, p and y with two rows for |x|.
+	if len(model.Vars()) != 4 || len(model.Constraints()) != 4 {
+		t.Fatalf("got %d vars and %d constraints, want 4 and 4", len(model.Vars()), len(model.Constraints()))
+	}
+	vars := model.Vars()
+	// x = 2 holds with the protection 0.5 * |x| = 1.
+	evaluation := mip.Evaluate(model, map[mip.Var]float64{x: 2, vars[1]: 1, vars[3]: 2})
+	if violated := evaluation.Violated(1e-9); len(violated) > 0 {
+		t.Errorf("violated constraints: %v", violated)
+	}
+}
+
+func TestNewRobustConstraintErrors(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewBool()
+	terms := []mip.UncertainTermSpec{{Coefficient: 1, Deviation: 1, Var: x}}
+	_, err := mip.NewRobustConstraint(model, mip.RobustConstraintSpec{Terms: terms, Budget: 2})
+	if !errors.Is(err, mip.ErrInvalidBudget) {
+		t.Errorf("got error %v, want ErrInvalidBudget", err)
+	}
+	_, err = mip.NewRobustConstraint(model, mip.RobustConstraintSpec{Sense: mip.Equal, Terms: terms})
+	if err == nil {
+		t.Error("expected error for an equality")
+	}
+	terms[0].Deviation = -1
+	if _, err = mip.NewRobustConstraint(model, mip.RobustConstraintSpec{Terms: terms}); err == nil {
+		t.Error("expected error for a negative deviation")
+	}
+}
@@ -0,0 +1,107 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// ErrSessionClosed is returned when a closed session is used.
+var ErrSessionClosed = errors.New("session closed")
+
+// Session keeps one solver for a model across solves, so back-ends which
+// hold state, e.g. the translated model, basis, cuts and incumbent, reuse it
+// instead of rebuilding the back-end model for every solve. Small models
+// solved frequently spend most of their time in the translation otherwise.
+// Close releases the solver.
+//
+//	session := mip.NewSession(model, factory)
+//	defer session.Close()
+//
+//	for range ticker.C {
+//		solution, err := session.Resolve(changes(), options)
+//		...
+//	}
+//
+// The methods of a session are safe for concurrent use, solves are
+// serialized.
+type Session struct {
+	model   Model
+	factory SolverFactory
+	solver  Solver
+	closed  bool
+	mutex   sync.Mutex
+}
+
+// NewSession creates a session for model. The solver is created by factory on
+// the first solve. A session which is not closed closes its solver when it is
+// garbage collected, but the time of collection is undefined, always Close a
+// session when it is no longer used.
+func NewSession(model Model, factory SolverFactory) *Session {
+	session := &Session{
+		model:   model,
+		factory: factory,
+	}
+	runtime.SetFinalizer(session, func(s *Session) {
+		_ = s.Close()
+	})
+	return session
+}
+
+// Model returns the model of the invoking session.
+func (s *Session) Model() Model {
+	return s.model
+}
+
+// Solve solves the model with the solver of the invoking session.
+func (s *Session) Solve(options SolveOptions) (Solution, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	solver, err := s.ensureSolver()
+	if err != nil {
+		return nil, err
+	}
+	return solver.Solve(options)
+}
+
+// Resolve applies changes to the model and solves it again with the solver of
+// the invoking session, see Resolve.
+func (s *Session) Resolve(changes ModelChanges, options SolveOptions) (Solution, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	solver, err := s.ensureSolver()
+	if err != nil {
+		return nil, err
+	}
+	return Resolve(s.model, solver, changes, options)
+}
+
+// Close releases the solver of the invoking session, see CloseSolver. Solving
+// after Close returns ErrSessionClosed, closing a closed session is a no-op.
+func (s *Session) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	solver := s.solver
+	s.solver = nil
+	return CloseSolver(solver)
+}
+
+func (s *Session) ensureSolver() (Solver, error) {
+	if s.closed {
+		return nil, ErrSessionClosed
+	}
+	if s.solver == nil {
+		solver, err := s.factory(s.model)
+		if err != nil {
+			return nil, err
+		}
+		s.solver = solver
+	}
+	return s.solver, nil
+}
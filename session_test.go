@@ -0,0 +1,64 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// closingSolver is a testSolver which counts how often it is closed.
+type closingSolver struct {
+	testSolver
+	closed int
+}
+
+func (s *closingSolver) Close() error {
+	s.closed++
+	return nil
+}
+
+func TestSession(t *testing.T) {
+	model := mip.NewModel()
+	c := model.NewConstraint(mip.LessThanOrEqual, 1)
+
+	created := 0
+	solver := &closingSolver{testSolver: testSolver{solution: newTestSolution(1, nil)}}
+	session := mip.NewSession(model, func(mip.Model) (mip.Solver, error) {
+		created++
+		return solver, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := session.Solve(mip.SolveOptions{}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	changes := mip.ModelChanges{
+		RightHandSides: []mip.RightHandSideChange{{Constraint: c, RightHandSide: 2}},
+	}
+	if _, err := session.Resolve(changes, mip.SolveOptions{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if created != 1 {
+		t.Errorf("created %d solvers, want 1", created)
+	}
+	if c.RightHandSide() != 2 {
+		t.Errorf("got right-hand side %v, want 2", c.RightHandSide())
+	}
+
+	if err := session.Close(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := session.Close(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if solver.closed != 1 {
+		t.Errorf("closed solver %d times, want 1", solver.closed)
+	}
+	if _, err := session.Solve(mip.SolveOptions{}); !errors.Is(err, mip.ErrSessionClosed) {
+		t.Errorf("got error %v, want %v", err, mip.ErrSessionClosed)
+	}
+}
@@ -0,0 +1,182 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// WriteSMTLIB writes the feasibility problem of model to w in the SMT-LIB 2
+// format, so an SMT solver such as Z3 or cvc5 can independently confirm that
+// a model is infeasible or check a solution. The objective is not written.
+// Bool and int vars are declared as Int, float vars as Real. The logic is
+// QF_LIA if all vars are integer and all numbers are integral, QF_LRA if all
+// vars are floats and QF_LIRA otherwise. Constraints with reals convert
+// integer vars with to_real. Bounds and constraints are named assertions,
+// "c%d" for constraints without a name, so the unsat core names the
+// conflicting ones. Names are written as quoted symbols, a name which is
+// already used by an earlier var or assertion gets a "_%d" suffix so all
+// symbols are unique.
+//
+//	$ z3 model.smt2
+//	unsat
+func WriteSMTLIB(w io.Writer, model Model) error {
+	writer := bufio.NewWriter(w)
+	vars := model.Vars()
+	constraints := model.Constraints()
+
+	fmt.Fprintln(writer, "; written by go-mip")
+	fmt.Fprintln(writer, "(set-option :produce-unsat-cores true)")
+	fmt.Fprintf(writer, "(set-logic %s)\n", smtLogic(vars, constraints))
+	symbols := make(smtSymbols)
+	names := make([]string, len(vars))
+	for i, v := range vars {
+		names[i] = symbols.unique(fmt.Sprint(v))
+		fmt.Fprintf(writer, "(declare-fun %s () %s)\n", names[i], smtSort(v))
+	}
+
+	for i, v := range vars {
+		lower, upper := bounds(v)
+		real := !v.IsInt()
+		switch {
+		case lower == upper:
+			smtAssert(writer, "(= "+names[i]+" "+smtNumber(lower, real)+")", symbols.unique("fix "+fmt.Sprint(v)))
+			continue
+		case !math.IsInf(lower, -1):
+			smtAssert(writer, "(>= "+names[i]+" "+smtNumber(lower, real)+")", symbols.unique("lower "+fmt.Sprint(v)))
+		}
+		if !math.IsInf(upper, 1) {
+			smtAssert(writer, "(<= "+names[i]+" "+smtNumber(upper, real)+")", symbols.unique("upper "+fmt.Sprint(v)))
+		}
+	}
+
+	for i, c := range constraints {
+		name := c.Name()
+		if name == "" {
+			name = fmt.Sprintf("c%d", i)
+		}
+		smtAssert(writer, smtConstraint(c, names), symbols.unique(name))
+	}
+
+	fmt.Fprintln(writer, "(check-sat)")
+	fmt.Fprintln(writer, "(exit)")
+	return writer.Flush()
+}
+
+func smtLogic(vars Vars, constraints Constraints) string {
+	integers, reals := 0, 0
+	for _, v := range vars {
+		if v.IsInt() {
+			integers++
+		} else {
+			reals++
+		}
+	}
+	switch {
+	case integers == 0:
+		return "QF_LRA"
+	case reals > 0:
+		return "QF_LIRA"
+	}
+	for _, c := range constraints {
+		if smtIsReal(c) {
+			return "QF_LIRA"
+		}
+	}
+	return "QF_LIA"
+}
+
+// smtIsReal returns true if c has a float var or a fractional number.
+func smtIsReal(c Constraint) bool {
+	if c.RightHandSide() != math.Trunc(c.RightHandSide()) {
+		return true
+	}
+	for _, t := range c.Terms() {
+		if !t.Var().IsInt() || t.Coefficient() != math.Trunc(t.Coefficient()) {
+			return true
+		}
+	}
+	return false
+}
+
+// smtConstraint returns the expression of c, true or false for an infinite
+// right-hand side. Vars are written using their symbols in names by index.
+func smtConstraint(c Constraint, names []string) string {
+	rightHandSide := c.RightHandSide()
+	switch {
+	case math.IsInf(rightHandSide, 1) && c.Sense() == LessThanOrEqual,
+		math.IsInf(rightHandSide, -1) && c.Sense() == GreaterThanOrEqual:
+		return "true"
+	case math.IsInf(rightHandSide, 0):
+		return "false"
+	}
+
+	real := smtIsReal(c)
+	terms := sortedTerms(c.Terms())
+	products := make([]string, len(terms))
+	for i, t := range terms {
+		name := names[t.Var().Index()]
+		if real && t.Var().IsInt() {
+			name = "(to_real " + name + ")"
+		}
+		products[i] = "(* " + smtNumber(t.Coefficient(), real) + " " + name + ")"
+	}
+	sum := smtNumber(0, real)
+	switch len(products) {
+	case 0:
+	case 1:
+		sum = products[0]
+	default:
+		sum = "(+ " + strings.Join(products, " ") + ")"
+	}
+	return "(" + sense(c.Sense()) + " " + sum + " " + smtNumber(rightHandSide, real) + ")"
+}
+
+func smtAssert(w io.Writer, expression, name string) {
+	fmt.Fprintf(w, "(assert (! %s :named %s))\n", expression, name)
+}
+
+func smtSort(v Var) string {
+	if v.IsInt() {
+		return "Int"
+	}
+	return "Real"
+}
+
+// smtSymbols is the set of quoted symbols written so far.
+type smtSymbols map[string]bool
+
+// unique returns name as a quoted symbol which is not in the set yet and adds
+// it. A "_%d" suffix is added to name if needed.
+func (s smtSymbols) unique(name string) string {
+	symbol := smtQuote(name)
+	for i := 1; s[symbol]; i++ {
+		symbol = smtQuote(fmt.Sprintf("%s_%d", name, i))
+	}
+	s[symbol] = true
+	return symbol
+}
+
+// smtQuote returns name as a quoted symbol. Quoted symbols may not contain
+// pipes and backslashes, they are replaced by underscores.
+func smtQuote(name string) string {
+	return "|" + strings.NewReplacer("|", "_", "\\", "_").Replace(name) + "|"
+}
+
+// smtNumber formats value as a numeral, or a decimal if real. Negative
+// numbers are negations in SMT-LIB.
+func smtNumber(value float64, real bool) string {
+	if value < 0 {
+		return "(- " + smtNumber(-value, real) + ")"
+	}
+	number := strconv.FormatFloat(value, 'f', -1, 64)
+	if real && !strings.Contains(number, ".") {
+		number += ".0"
+	}
+	return number
+}
@@ -0,0 +1,54 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestWriteSMTLIBDuplicateNames(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewBool()
+	x.SetName("x")
+	y := model.NewBool()
+	y.SetName("x")
+	for _, name := range []string{"c", "c", "x"} {
+		c := model.NewConstraint(mip.LessThanOrEqual, 1)
+		c.SetName(name)
+		c.NewTerm(1, x)
+		c.NewTerm(1, y)
+	}
+	var buffer bytes.Buffer
+	if err := mip.WriteSMTLIB(&buffer, model); err != nil {
+		t.Fatal(err)
+	}
+
+	symbols := make(map[string]bool)
+	for _, line := range strings.Split(buffer.String(), "\n") {
+		var symbol string
+		switch {
+		case strings.HasPrefix(line, "(declare-fun "):
+			symbol = strings.Fields(line)[1]
+		case strings.Contains(line, ":named "):
+			symbol = strings.TrimSuffix(line[strings.Index(line, ":named ")+len(":named "):], "))")
+		default:
+			continue
+		}
+		if symbols[symbol] {
+			t.Errorf("symbol %s is defined twice", symbol)
+		}
+		symbols[symbol] = true
+	}
+	for _, want := range []string{"|x|", "|x_1|", "|c|", "|c_1|", "|x_2|"} {
+		if !symbols[want] {
+			t.Errorf("symbol %s is not defined", want)
+		}
+	}
+	if !strings.Contains(buffer.String(), "(+ (* 1 |x|) (* 1 |x_1|))") {
+		t.Errorf("constraints do not use the symbols of the vars:\n%s", buffer.String())
+	}
+}
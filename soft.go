@@ -0,0 +1,124 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "fmt"
+
+// softConstraint holds the violation vars of a soft constraint, see
+// Constraint.MakeSoft.
+type softConstraint struct {
+	under Var
+	over  Var
+}
+
+// SoftViolation is the violation of a soft constraint in a solution, see
+// Constraint.MakeSoft.
+type SoftViolation struct {
+	// Constraint is the soft constraint.
+	Constraint Constraint `json:"-"`
+	// Name is the name of the constraint.
+	Name string `json:"name"`
+	// Under is the amount the left-hand side falls short of the right-hand
+	// side.
+	Under float64 `json:"under"`
+	// Over is the amount the left-hand side exceeds the right-hand side.
+	Over float64 `json:"over"`
+	// Penalty is the contribution of the violation to the objective value.
+	Penalty float64 `json:"penalty"`
+}
+
+func (c *constraint) MakeSoft(penaltyUnder, penaltyOver float64) (Var, Var) {
+	c.model.mustBeMutable()
+	if soft, ok := c.model.soft[c]; ok {
+		return soft.under, soft.over
+	}
+	for _, penalty := range []struct {
+		name  string
+		value float64
+	}{
+		{"under penalty", penaltyUnder},
+		{"over penalty", penaltyOver},
+	} {
+		if err := checkNaN(penalty.name, penalty.value); err != nil {
+			panic(err)
+		}
+		if penalty.value < 0 {
+			panic(fmt.Sprintf("mip: MakeSoft with negative %s %v", penalty.name, penalty.value))
+		}
+	}
+
+	o := c.model.objective.(*objective)
+	sign := 1.0
+	if o.IsMaximize() {
+		sign = -1.0
+	}
+	var soft softConstraint
+	if c.sense != LessThanOrEqual {
+		soft.under = c.model.NewFloat(0, Infinity())
+		c.NewTerm(1, soft.under)
+		o.NewTerm(sign*penaltyUnder, soft.under)
+		o.penalties = append(o.penalties, soft.under)
+	}
+	if c.sense != GreaterThanOrEqual {
+		soft.over = c.model.NewFloat(0, Infinity())
+		c.NewTerm(-1, soft.over)
+		o.NewTerm(sign*penaltyOver, soft.over)
+		o.penalties = append(o.penalties, soft.over)
+	}
+	c.model.soft[c] = soft
+	return soft.under, soft.over
+}
+
+// SoftViolations returns the violations of the soft constraints of model in
+// solution, see Constraint.MakeSoft, in the order of Model.Constraints.
+// Satisfied soft constraints are not reported. Returns nil if solution has no
+// values.
+//
+//	for _, violation := range mip.SoftViolations(model, solution) {
+//		fmt.Printf("%s: %v over, costs %v\n", violation.Name, violation.Over, violation.Penalty)
+//	}
+func SoftViolations(model Model, solution Solution) []SoftViolation {
+	if !solution.HasValues() {
+		return nil
+	}
+	softConstraints := softConstraintsOf(model)
+	if len(softConstraints) == 0 {
+		return nil
+	}
+	coefficients := map[int]float64{}
+	for _, t := range model.Objective().Terms() {
+		coefficients[t.Var().Index()] = t.Coefficient()
+	}
+
+	var violations []SoftViolation
+	for _, c := range model.Constraints() {
+		soft, ok := softConstraints[c]
+		if !ok {
+			continue
+		}
+		violation := SoftViolation{
+			Constraint: c,
+			Name:       c.Name(),
+		}
+		if soft.under != nil {
+			violation.Under = solution.Value(soft.under)
+			violation.Penalty += coefficients[soft.under.Index()] * violation.Under
+		}
+		if soft.over != nil {
+			violation.Over = solution.Value(soft.over)
+			violation.Penalty += coefficients[soft.over.Index()] * violation.Over
+		}
+		if violation.Under > 0 || violation.Over > 0 {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+func softConstraintsOf(m Model) map[Constraint]softConstraint {
+	impl, ok := m.(*model)
+	if !ok {
+		return nil
+	}
+	return impl.soft
+}
@@ -0,0 +1,116 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestMakeSoft(t *testing.T) {
+	model := mip.NewModel()
+	hours := model.NewFloat(0, 60)
+	limit := model.NewConstraint(mip.LessThanOrEqual, 40)
+	limit.SetName("hours")
+	limit.NewTerm(1, hours)
+	demand := model.NewConstraint(mip.Equal, 10)
+	demand.SetName("demand")
+	demand.NewTerm(1, hours)
+
+	under, over := limit.MakeSoft(5, 25)
+	if under != nil || over == nil {
+		t.Fatalf("got under %v, over %v, want only over", under, over)
+	}
+	if u, o := limit.MakeSoft(1, 1); u != nil || o != over {
+		t.Errorf("MakeSoft on a soft constraint created new vars")
+	}
+	demandUnder, demandOver := demand.MakeSoft(3, 4)
+	if demandUnder == nil || demandOver == nil {
+		t.Fatalf("expected both violation vars for an equality")
+	}
+	if term, _ := limit.Term(over); term.Coefficient() != -1 {
+		t.Errorf("got over coefficient %v, want -1", term.Coefficient())
+	}
+	if term, _ := model.Objective().Term(over); term.Coefficient() != 25 {
+		t.Errorf("got over penalty %v, want 25", term.Coefficient())
+	}
+
+	solution := newTestSolution(0, map[mip.Var]float64{
+		hours:       45,
+		over:        5,
+		demandUnder: 0,
+		demandOver:  35,
+	})
+	for _, m := range []mip.Model{model, model.Copy()} {
+		violations := mip.SoftViolations(m, solution)
+		if len(violations) != 2 {
+			t.Fatalf("got %d violations, want 2", len(violations))
+		}
+		if v := violations[0]; v.Name != "hours" || v.Over != 5 || v.Penalty != 125 {
+			t.Errorf("got violation %+v", v)
+		}
+		if v := violations[1]; v.Name != "demand" || v.Under != 0 || v.Over != 35 || v.Penalty != 140 {
+			t.Errorf("got violation %+v", v)
+		}
+	}
+}
+
+func TestMakeSoftMaximize(t *testing.T) {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	x := model.NewFloat(0, 10)
+	c := model.NewConstraint(mip.GreaterThanOrEqual, 5)
+	c.NewTerm(1, x)
+
+	under, over := c.MakeSoft(2, 3)
+	if under == nil || over != nil {
+		t.Fatalf("got under %v, over %v, want only under", under, over)
+	}
+	if term, _ := model.Objective().Term(under); term.Coefficient() != -2 {
+		t.Errorf("got under penalty %v, want -2", term.Coefficient())
+	}
+	solution := newTestSolution(0, map[mip.Var]float64{x: 5, under: 0})
+	if violations := mip.SoftViolations(model, solution); len(violations) != 0 {
+		t.Errorf("got violations %+v, want none", violations)
+	}
+}
+
+func TestMakeSoftDirectionChange(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	c := model.NewConstraint(mip.LessThanOrEqual, 5)
+	c.NewTerm(1, x)
+	model.Objective().NewTerm(1, x)
+
+	_, over := c.MakeSoft(0, 3)
+	model.Objective().SetMaximize()
+	if term, _ := model.Objective().Term(over); term.Coefficient() != -3 {
+		t.Errorf("got over penalty %v after maximizing, want -3", term.Coefficient())
+	}
+	if term, _ := model.Objective().Term(x); term.Coefficient() != 1 {
+		t.Errorf("got coefficient %v of x, want 1", term.Coefficient())
+	}
+
+	// Copies negate the penalties of their own objective.
+	copied := model.Copy()
+	copied.Objective().SetMinimize()
+	if term, _ := copied.Objective().Term(copied.Vars()[over.Index()]); term.Coefficient() != 3 {
+		t.Errorf("got over penalty %v of the copy, want 3", term.Coefficient())
+	}
+	if term, _ := model.Objective().Term(over); term.Coefficient() != -3 {
+		t.Errorf("got over penalty %v of the original, want -3", term.Coefficient())
+	}
+}
+
+func TestMakeSoftNegativePenalty(t *testing.T) {
+	model := mip.NewModel()
+	c := model.NewConstraint(mip.LessThanOrEqual, 5)
+	c.NewTerm(1, model.NewFloat(0, 10))
+	defer func() {
+		if recover() == nil {
+			t.Error("MakeSoft with a negative penalty did not panic")
+		}
+	}()
+	c.MakeSoft(0, -1)
+}
@@ -2,9 +2,18 @@
 
 package mip
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 // Solution contains the results of a Solver.Solve invocation.
+//
+// A solution must not change once Solver.Solve has returned it and all its
+// methods must be safe for concurrent use by multiple goroutines, so results
+// can be fanned out for downstream processing. Implementations which can not
+// give this guarantee, e.g. because they read values lazily from a back-end,
+// can be turned into an immutable value using NewSolutionSnapshot.
 type Solution interface {
 	// HasValues returns true if the solver was able to associate values with
 	// variables.
@@ -43,3 +52,77 @@ type Solution interface {
 	// returns true. Returns math.MaxFloat64 if HasValues is false.
 	Value(variable Var) float64
 }
+
+// DualSolution is implemented by solutions which provide dual information.
+// Back-ends typically provide dual information for linear problems only. Use
+// a type assertion to check whether a solution provides it:
+//
+//	if dualSolution, ok := solution.(mip.DualSolution); ok {
+//		fmt.Println(dualSolution.ReducedCost(x))
+//	}
+type DualSolution interface {
+	Solution
+	// Dual returns the dual value of constraint, also known as its shadow
+	// price. The value should only be used if HasValues returns true.
+	Dual(constraint Constraint) float64
+	// ReducedCost returns the reduced cost of variable. The value should
+	// only be used if HasValues returns true.
+	ReducedCost(variable Var) float64
+}
+
+// RelaxationSolution is implemented by solutions which provide the values of
+// the root LP relaxation, e.g. to visualize fractionality patterns and decide
+// where to add valid inequalities or priorities. Use a type assertion to check
+// whether a solution provides them.
+type RelaxationSolution interface {
+	Solution
+	// HasRelaxationValues returns true if the solver solved the root LP
+	// relaxation and associated values with variables.
+	HasRelaxationValues() bool
+	// RelaxationValue returns the value of variable in the root LP
+	// relaxation. The value should only be used if HasRelaxationValues
+	// returns true.
+	RelaxationValue(variable Var) float64
+}
+
+// RelaxationValues returns the root LP relaxation values of all vars of model,
+// indexed by Var.Index. Returns false if solution is not a RelaxationSolution
+// or has no relaxation values.
+func RelaxationValues(model Model, solution Solution) ([]float64, bool) {
+	relaxation, ok := solution.(RelaxationSolution)
+	if !ok || !relaxation.HasRelaxationValues() {
+		return nil, false
+	}
+	vars := model.Vars()
+	values := make([]float64, len(vars))
+	for i, v := range vars {
+		values[i] = relaxation.RelaxationValue(v)
+	}
+	return values, true
+}
+
+// BoundSolution is implemented by solutions which report the best bound on
+// the objective value proven by the solver. Use a type assertion to check
+// whether a solution provides it.
+type BoundSolution interface {
+	Solution
+	// BestBound returns the best bound on the objective value, e.g. the dual
+	// bound of a branch-and-bound search. The value should only be used if
+	// HasValues returns true.
+	BestBound() float64
+}
+
+// RelativeGap returns the relative gap between the objective value and the
+// best bound of solution, |objective - bound| / |objective|. Returns false if
+// solution is not a BoundSolution or has no values.
+func RelativeGap(solution Solution) (float64, bool) {
+	bounded, ok := solution.(BoundSolution)
+	if !ok || !bounded.HasValues() {
+		return 0, false
+	}
+	difference := math.Abs(bounded.ObjectiveValue() - bounded.BestBound())
+	if difference == 0 {
+		return 0, true
+	}
+	return difference / math.Abs(bounded.ObjectiveValue()), true
+}
@@ -0,0 +1,82 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"math"
+	"sort"
+)
+
+// Activity returns the value of the left-hand side of constraint in solution.
+// The value should only be used if solution has values.
+func Activity(solution Solution, constraint Constraint) float64 {
+	return constraintActivity(constraint, solution.Value)
+}
+
+// IsBinding returns true if constraint is satisfied with equality in
+// solution, within tolerance: its activity is at most tolerance away from its
+// right-hand side. Returns false if solution has no values.
+func IsBinding(solution Solution, constraint Constraint, tolerance float64) bool {
+	if !solution.HasValues() {
+		return false
+	}
+	return math.Abs(Activity(solution, constraint)-constraint.RightHandSide()) <= tolerance
+}
+
+// BindingConstraint is a constraint which is binding in a solution, see
+// BindingConstraints.
+type BindingConstraint struct {
+	// Constraint which is binding.
+	Constraint Constraint `json:"-"`
+	// Name of the constraint.
+	Name string `json:"name"`
+	// Activity of the constraint.
+	Activity float64 `json:"activity"`
+	// Dual value of the constraint, 0 if the solution does not provide dual
+	// information.
+	Dual float64 `json:"dual"`
+}
+
+// BindingConstraints returns the at most k constraints of model which are
+// binding in solution within tolerance, the constraints which limit the
+// plan. If solution is a DualSolution the constraints are ordered by the
+// magnitude of their dual value, largest first, i.e. by how much the
+// objective value would change if they were relaxed. Otherwise they are in
+// the order of Model.Constraints. A k of 0 or less returns all binding
+// constraints. Returns nil if solution has no values.
+func BindingConstraints(
+	model Model,
+	solution Solution,
+	k int,
+	tolerance float64,
+) []BindingConstraint {
+	if !solution.HasValues() {
+		return nil
+	}
+	dualSolution, hasDuals := solution.(DualSolution)
+
+	var binding []BindingConstraint
+	for _, c := range model.Constraints() {
+		if !IsBinding(solution, c, tolerance) {
+			continue
+		}
+		b := BindingConstraint{
+			Constraint: c,
+			Name:       c.Name(),
+			Activity:   Activity(solution, c),
+		}
+		if hasDuals {
+			b.Dual = dualSolution.Dual(c)
+		}
+		binding = append(binding, b)
+	}
+	if hasDuals {
+		sort.SliceStable(binding, func(i, j int) bool {
+			return math.Abs(binding[i].Dual) > math.Abs(binding[j].Dual)
+		})
+	}
+	if k > 0 && len(binding) > k {
+		binding = binding[:k]
+	}
+	return binding
+}
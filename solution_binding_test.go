@@ -0,0 +1,63 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// dualSolution is a testSolution providing dual information.
+type dualSolution struct {
+	*testSolution
+	duals        map[mip.Constraint]float64
+	reducedCosts map[mip.Var]float64
+}
+
+func (s *dualSolution) Dual(constraint mip.Constraint) float64 {
+	return s.duals[constraint]
+}
+
+func (s *dualSolution) ReducedCost(variable mip.Var) float64 {
+	return s.reducedCosts[variable]
+}
+
+func TestBindingConstraints(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewFloat(0, 10)
+	constraints := make(mip.Constraints, 3)
+	for i, rhs := range []float64{4, 6, 9} {
+		constraints[i] = model.NewConstraint(mip.LessThanOrEqual, rhs)
+		constraints[i].SetNamef("c%d", i)
+		constraints[i].NewTerm(1, x)
+		constraints[i].NewTerm(float64(i), y)
+	}
+	// x = 4, y = 1: activities 4, 5 and 6.
+	solution := newTestSolution(0, map[mip.Var]float64{x: 4, y: 1})
+
+	if got := mip.Activity(solution, constraints[2]); got != 6 {
+		t.Errorf("activity = %v, want 6", got)
+	}
+	if !mip.IsBinding(solution, constraints[0], 1e-9) || mip.IsBinding(solution, constraints[1], 1e-9) {
+		t.Errorf("expected only c0 to be binding")
+	}
+	if !mip.IsBinding(solution, constraints[1], 1) {
+		t.Errorf("expected c1 to be binding within 1")
+	}
+
+	binding := mip.BindingConstraints(model, solution, 0, 1)
+	if len(binding) != 2 || binding[0].Name != "c0" || binding[1].Name != "c1" {
+		t.Errorf("got binding %v, want c0, c1", binding)
+	}
+
+	duals := &dualSolution{
+		testSolution: solution,
+		duals:        map[mip.Constraint]float64{constraints[0]: 0.5, constraints[1]: -2},
+	}
+	binding = mip.BindingConstraints(model, duals, 1, 1)
+	if len(binding) != 1 || binding[0].Name != "c1" || binding[0].Dual != -2 {
+		t.Errorf("got binding %v, want c1 with dual -2", binding)
+	}
+}
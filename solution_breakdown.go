@@ -0,0 +1,70 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ObjectiveContribution is the contribution of an objective term to the
+// objective value of a solution.
+type ObjectiveContribution struct {
+	// Name of the var of the term, the names of both vars joined by * for a
+	// quadratic term.
+	Name string `json:"name"`
+	// Group of the var of the term, see Var.SetGroup. For a quadratic term
+	// the group of both vars if they are in the same group, otherwise empty.
+	Group string `json:"group,omitempty"`
+	// Coefficient of the term.
+	Coefficient float64 `json:"coefficient"`
+	// Value of the var of the term, the product of the values of both vars
+	// for a quadratic term.
+	Value float64 `json:"value"`
+	// Contribution to the objective value, coefficient times value.
+	Contribution float64 `json:"contribution"`
+}
+
+// ObjectiveBreakdown returns the contribution of each objective term of model
+// to the objective value of solution, largest magnitude first, so cost
+// reports can be generated directly from the model. Terms which do not
+// contribute are omitted. The contributions sum up to the objective value.
+// Use GroupSums for the contributions per group. Returns nil if solution has
+// no values.
+func ObjectiveBreakdown(model Model, solution Solution) []ObjectiveContribution {
+	if !solution.HasValues() {
+		return nil
+	}
+	objective := model.Objective()
+	var breakdown []ObjectiveContribution
+	add := func(contribution ObjectiveContribution) {
+		contribution.Contribution = contribution.Coefficient * contribution.Value
+		if contribution.Contribution != 0 {
+			breakdown = append(breakdown, contribution)
+		}
+	}
+	for _, t := range sortedTerms(objective.Terms()) {
+		add(ObjectiveContribution{
+			Name:        fmt.Sprint(t.Var()),
+			Group:       t.Var().Group(),
+			Coefficient: t.Coefficient(),
+			Value:       solution.Value(t.Var()),
+		})
+	}
+	for _, t := range sortedQuadraticTerms(objective.QuadraticTerms()) {
+		contribution := ObjectiveContribution{
+			Name:        fmt.Sprintf("%v*%v", t.Var1(), t.Var2()),
+			Coefficient: t.Coefficient(),
+			Value:       solution.Value(t.Var1()) * solution.Value(t.Var2()),
+		}
+		if t.Var1().Group() == t.Var2().Group() {
+			contribution.Group = t.Var1().Group()
+		}
+		add(contribution)
+	}
+	sort.SliceStable(breakdown, func(i, j int) bool {
+		return math.Abs(breakdown[i].Contribution) > math.Abs(breakdown[j].Contribution)
+	})
+	return breakdown
+}
@@ -0,0 +1,91 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// SolutionCSVOptions configure the CSV representation of a solution.
+type SolutionCSVOptions struct {
+	// SkipZeros omits vars whose absolute value is less than or equal to
+	// ZeroTolerance.
+	SkipZeros bool `json:"skip_zeros"`
+	// ZeroTolerance is the absolute value at or below which a value is
+	// considered to be zero.
+	ZeroTolerance float64 `json:"zero_tolerance"`
+}
+
+// solutionCSVHeader are the columns written by WriteSolutionCSV.
+var solutionCSVHeader = []string{
+	"name",
+	"index",
+	"type",
+	"value",
+	"reduced_cost",
+}
+
+// WriteSolutionCSV writes the values of solution for model to w as CSV, one
+// row per var, so solutions can be loaded into analytics tools directly. The
+// columns are name, index, type (bool, int or float), value and reduced cost.
+// The reduced cost is empty if solution is not a DualSolution. Nothing but
+// the header is written if the solution has no values.
+func WriteSolutionCSV(
+	w io.Writer,
+	model Model,
+	solution Solution,
+	options SolutionCSVOptions,
+) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(solutionCSVHeader); err != nil {
+		return err
+	}
+
+	if solution.HasValues() {
+		dualSolution, hasDuals := solution.(DualSolution)
+
+		for _, v := range model.Vars() {
+			value := solution.Value(v)
+			if options.SkipZeros && math.Abs(value) <= options.ZeroTolerance {
+				continue
+			}
+			reducedCost := ""
+			if hasDuals {
+				reducedCost = formatFloat(dualSolution.ReducedCost(v))
+			}
+			err := writer.Write([]string{
+				fmt.Sprint(v),
+				strconv.Itoa(v.Index()),
+				varTypeName(v),
+				formatFloat(value),
+				reducedCost,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func varTypeName(v Var) string {
+	switch {
+	case v.IsBool():
+		return "bool"
+	case v.IsInt():
+		return "int"
+	default:
+		return "float"
+	}
+}
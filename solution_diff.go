@@ -0,0 +1,65 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "math"
+
+// VarChange reports a var whose value differs between two solutions.
+type VarChange struct {
+	// Var whose value changed.
+	Var Var
+	// From is the value of the var in the first solution.
+	From float64
+	// To is the value of the var in the second solution.
+	To float64
+}
+
+// SolutionDiff is the result of DiffSolutions.
+type SolutionDiff struct {
+	// ObjectiveDelta is the objective value of the second solution minus
+	// the one of the first solution.
+	ObjectiveDelta float64
+	// Changes are the vars whose values changed by group, see Var.SetGroup,
+	// in the order of their index. Vars without a group are listed under
+	// the empty group.
+	Changes map[string][]VarChange
+}
+
+// Len returns the number of vars whose values changed.
+func (d SolutionDiff) Len() int {
+	n := 0
+	for _, changes := range d.Changes {
+		n += len(changes)
+	}
+	return n
+}
+
+// DiffSolutions returns the vars of model whose values differ by more than
+// tolerance between solutions a and b, e.g. to explain the churn between the
+// plans of consecutive runs. Returns an empty diff if a or b has no values.
+//
+//	diff := mip.DiffSolutions(model, previous, solution, 1e-6)
+//	for group, changes := range diff.Changes {
+//		fmt.Println(group, len(changes))
+//	}
+func DiffSolutions(model Model, a, b Solution, tolerance float64) SolutionDiff {
+	diff := SolutionDiff{Changes: make(map[string][]VarChange)}
+	if !a.HasValues() || !b.HasValues() {
+		return diff
+	}
+
+	diff.ObjectiveDelta = b.ObjectiveValue() - a.ObjectiveValue()
+	for _, v := range model.Vars() {
+		from, to := a.Value(v), b.Value(v)
+		if math.Abs(to-from) <= tolerance {
+			continue
+		}
+		group := v.Group()
+		diff.Changes[group] = append(diff.Changes[group], VarChange{
+			Var:  v,
+			From: from,
+			To:   to,
+		})
+	}
+	return diff
+}
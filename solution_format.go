@@ -10,12 +10,22 @@ import (
 // CustomResultStatistics is an example of custom statistics that can be added
 // to the output and used in experiments.
 type CustomResultStatistics struct {
+	// BuildTime is the time in seconds spent translating the model to the
+	// back-end, if reported by the solver.
+	BuildTime float64 `json:"build_time,omitempty"`
 	// Constraints in the matrix, i.e. the number of constraints.
 	Constraints int `json:"constraints,omitempty"`
+	// Phases breaks down the run time by phase, if reported by the solver.
+	Phases *PhaseTimes `json:"phases,omitempty"`
 	// Provider of the solution.
 	Provider SolverProvider `json:"provider,omitempty"`
+	// SolveTime is the time in seconds spent optimizing after translating
+	// the model, if reported by the solver.
+	SolveTime float64 `json:"solve_time,omitempty"`
 	// Status of the solution.
 	Status string `json:"status,omitempty"`
+	// Tolerances used by the solver, if reported by the solver.
+	Tolerances *ToleranceOptions `json:"tolerances,omitempty"`
 	// Variables in the matrix, i.e. the number of variables.
 	Variables int `json:"variables,omitempty"`
 }
@@ -51,22 +61,39 @@ func Format(
 // DefaultCustomResultStatistics creates default custom statistics for a given
 // solution.
 func DefaultCustomResultStatistics(model Model, solution Solution) CustomResultStatistics {
-	status := "unknown"
+	statistics := CustomResultStatistics{
+		Status:      solutionStatus(solution),
+		Variables:   len(model.Vars()),
+		Constraints: len(model.Constraints()),
+		Provider:    solution.Provider(),
+	}
+
+	if timedSolution, ok := solution.(PhaseTimedSolution); ok {
+		phases := timedSolution.PhaseTimes()
+		statistics.Phases = &phases
+		statistics.BuildTime = phases.Translation.Seconds()
+		statistics.SolveTime = phases.Optimization().Seconds()
+	}
+
+	if toleranceSolution, ok := solution.(ToleranceSolution); ok {
+		tolerances := toleranceSolution.Tolerances()
+		statistics.Tolerances = &tolerances
+	}
+
+	return statistics
+}
+
+// solutionStatus returns a human readable status of solution.
+func solutionStatus(solution Solution) string {
 	switch {
 	case solution.IsOptimal():
-		status = "optimal"
+		return "optimal"
 	case solution.IsUnbounded():
-		status = "unbounded"
+		return "unbounded"
 	case solution.IsSubOptimal():
-		status = "suboptimal"
+		return "suboptimal"
 	case solution.IsInfeasible():
-		status = "infeasible"
-	}
-
-	return CustomResultStatistics{
-		Status:      status,
-		Variables:   len(model.Vars()),
-		Constraints: len(model.Constraints()),
-		Provider:    solution.Provider(),
+		return "infeasible"
 	}
+	return "unknown"
 }
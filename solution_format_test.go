@@ -0,0 +1,81 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// phaseTimedSolution is a testSolution reporting phase times.
+type phaseTimedSolution struct {
+	*testSolution
+	phases mip.PhaseTimes
+}
+
+func (s *phaseTimedSolution) PhaseTimes() mip.PhaseTimes {
+	return s.phases
+}
+
+func TestDefaultCustomResultStatisticsPhases(t *testing.T) {
+	model := mip.NewModel()
+	model.NewBool()
+
+	solution := newTestSolution(0.0, nil)
+	statistics := mip.DefaultCustomResultStatistics(model, solution)
+	if statistics.Phases != nil {
+		t.Errorf("expected no phases, got %v", statistics.Phases)
+	}
+
+	timedSolution := &phaseTimedSolution{
+		testSolution: solution,
+		phases: mip.PhaseTimes{
+			Translation:    500 * time.Millisecond,
+			BranchAndBound: 2 * time.Second,
+		},
+	}
+	statistics = mip.DefaultCustomResultStatistics(model, timedSolution)
+	if statistics.Phases == nil {
+		t.Fatal("expected phases")
+	}
+	if statistics.Phases.Total() != 2500*time.Millisecond {
+		t.Errorf("total = %v, want 2.5s", statistics.Phases.Total())
+	}
+	if statistics.BuildTime != 0.5 || statistics.SolveTime != 2 {
+		t.Errorf("build time = %v, solve time = %v, want 0.5, 2", statistics.BuildTime, statistics.SolveTime)
+	}
+
+	b, err := json.Marshal(statistics.Phases)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"branch_and_bound":2,"postprocessing":0,"presolve":0,"root_lp":0,"translation":0.5}`
+	if string(b) != want {
+		t.Errorf("json = %s, want %s", b, want)
+	}
+}
+
+// toleranceSolution is a testSolution reporting the tolerances used.
+type toleranceSolution struct {
+	*testSolution
+	tolerances mip.ToleranceOptions
+}
+
+func (s *toleranceSolution) Tolerances() mip.ToleranceOptions {
+	return s.tolerances
+}
+
+func TestDefaultCustomResultStatisticsTolerances(t *testing.T) {
+	model := mip.NewModel()
+	solution := &toleranceSolution{
+		testSolution: newTestSolution(0.0, nil),
+		tolerances:   mip.ToleranceOptions{Integrality: 1e-6},
+	}
+	statistics := mip.DefaultCustomResultStatistics(model, solution)
+	if statistics.Tolerances == nil || statistics.Tolerances.Integrality != 1e-6 {
+		t.Errorf("unexpected tolerances %v", statistics.Tolerances)
+	}
+}
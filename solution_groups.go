@@ -0,0 +1,49 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+// GroupSum is the aggregated result of all vars of a group.
+type GroupSum struct {
+	// ObjectiveContribution is the sum of the linear objective terms of the
+	// vars of the group, coefficient times value. Quadratic objective terms
+	// are not attributed to groups.
+	ObjectiveContribution float64 `json:"objective_contribution"`
+	// Value is the sum of the values of the vars of the group.
+	Value float64 `json:"value"`
+	// Vars is the number of vars in the group.
+	Vars int `json:"vars"`
+}
+
+// GroupSums returns the sum of the values and of the objective contribution
+// of the vars of model in solution per group, see Var.SetGroup. Vars which
+// do not belong to a group are ignored. Returns an empty map if solution has
+// no values.
+func GroupSums(model Model, solution Solution) map[string]GroupSum {
+	sums := make(map[string]GroupSum)
+	if !solution.HasValues() {
+		return sums
+	}
+
+	for _, v := range model.Vars() {
+		group := v.Group()
+		if group == "" {
+			continue
+		}
+		sum := sums[group]
+		sum.Value += solution.Value(v)
+		sum.Vars++
+		sums[group] = sum
+	}
+
+	for _, t := range model.Objective().Terms() {
+		group := t.Var().Group()
+		if group == "" {
+			continue
+		}
+		sum := sums[group]
+		sum.ObjectiveContribution += t.Coefficient() * solution.Value(t.Var())
+		sums[group] = sum
+	}
+
+	return sums
+}
@@ -0,0 +1,84 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNoValues is returned when reading values of a solution which has none,
+// see Solution.HasValues.
+var ErrNoValues = errors.New("solution has no values")
+
+// ErrNotIntegral is returned by IntValue, BoolValue and IntObjectiveValue if
+// a value is farther from the nearest integer than the integrality tolerance,
+// can not be represented exactly as an int64 or, for BoolValue, is neither 0
+// nor 1.
+var ErrNotIntegral = errors.New("value not integral")
+
+// IntValue returns the value of v in solution rounded to the nearest integer.
+// The integrality tolerance is the one reported by solution if it is a
+// ToleranceSolution, DefaultTolerances otherwise.
+//
+//	trucks, err := mip.IntValue(solution, x)
+func IntValue(solution Solution, v Var) (int64, error) {
+	if !solution.HasValues() {
+		return 0, ErrNoValues
+	}
+	return integral(fmt.Sprint(v), solution.Value(v), integralityTolerance(solution))
+}
+
+// BoolValue returns the value of v in solution as a bool, see IntValue.
+// Returns an error wrapping ErrNotIntegral if the rounded value is neither 0
+// nor 1.
+func BoolValue(solution Solution, v Var) (bool, error) {
+	value, err := IntValue(solution, v)
+	if err != nil {
+		return false, err
+	}
+	if value != 0 && value != 1 {
+		return false, fmt.Errorf("%w: %v is %d, not 0 or 1", ErrNotIntegral, v, value)
+	}
+	return value == 1, nil
+}
+
+// IntObjectiveValue returns the objective value of solution rounded to the
+// nearest integer, for models with integral objective coefficients on int
+// and bool vars only. See IntValue for the tolerance.
+func IntObjectiveValue(solution Solution) (int64, error) {
+	if !solution.HasValues() {
+		return 0, ErrNoValues
+	}
+	return integral("objective", solution.ObjectiveValue(), integralityTolerance(solution))
+}
+
+// integralityTolerance returns the integrality tolerance of solution.
+func integralityTolerance(solution Solution) float64 {
+	if toleranceSolution, ok := solution.(ToleranceSolution); ok {
+		if tolerance := toleranceSolution.Tolerances().Integrality; tolerance > 0 {
+			return tolerance
+		}
+	}
+	return DefaultTolerances().Integrality
+}
+
+// integral returns value rounded to the nearest integer, an error if it is
+// farther than tolerance from it or exceeds MaxIntBound in magnitude.
+func integral(what string, value float64, tolerance float64) (int64, error) {
+	rounded := math.Round(value)
+	if math.IsNaN(value) || math.Abs(value-rounded) > tolerance {
+		return 0, fmt.Errorf("%w: %s is %v", ErrNotIntegral, what, value)
+	}
+	if math.Abs(rounded) > MaxIntBound {
+		return 0, fmt.Errorf(
+			"%w: %s is %v, exceeds %d in magnitude",
+			ErrNotIntegral,
+			what,
+			value,
+			int64(MaxIntBound),
+		)
+	}
+	return int64(rounded), nil
+}
@@ -0,0 +1,61 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestIntValue(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewInt(0, 10)
+
+	tests := []struct {
+		value float64
+		want  int64
+		err   error
+	}{
+		{value: 3, want: 3},
+		{value: 2.999999, want: 3},
+		{value: -4.000001, want: -4},
+		{value: 2.5, err: mip.ErrNotIntegral},
+		{value: math.NaN(), err: mip.ErrNotIntegral},
+		{value: 1 << 60, err: mip.ErrNotIntegral},
+	}
+	for _, test := range tests {
+		solution := newTestSolution(0, map[mip.Var]float64{x: test.value})
+		got, err := mip.IntValue(solution, x)
+		if !errors.Is(err, test.err) {
+			t.Errorf("value %v: got error %v, want %v", test.value, err, test.err)
+		}
+		if got != test.want {
+			t.Errorf("value %v: got %d, want %d", test.value, got, test.want)
+		}
+	}
+
+	if _, err := mip.IntValue(&testSolution{}, x); !errors.Is(err, mip.ErrNoValues) {
+		t.Errorf("got error %v, want %v", err, mip.ErrNoValues)
+	}
+}
+
+func TestBoolValue(t *testing.T) {
+	model := mip.NewModel()
+	b := model.NewBool()
+
+	solution := newTestSolution(7.0000001, map[mip.Var]float64{b: 0.9999999})
+	if got, err := mip.BoolValue(solution, b); err != nil || !got {
+		t.Errorf("got %v, %v, want true", got, err)
+	}
+	if got, err := mip.IntObjectiveValue(solution); err != nil || got != 7 {
+		t.Errorf("got objective %v, %v, want 7", got, err)
+	}
+
+	solution = newTestSolution(0, map[mip.Var]float64{b: 2})
+	if _, err := mip.BoolValue(solution, b); !errors.Is(err, mip.ErrNotIntegral) {
+		t.Errorf("got error %v, want %v", err, mip.ErrNotIntegral)
+	}
+}
@@ -0,0 +1,115 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrDuplicateName is returned when vars which must be identified by their
+// name share a name.
+var ErrDuplicateName = errors.New("duplicate var name")
+
+// SolutionJSONOptions configure the JSON representation of a solution.
+type SolutionJSONOptions struct {
+	// SkipZeros omits vars whose absolute value is less than or equal to
+	// ZeroTolerance.
+	SkipZeros bool `json:"skip_zeros"`
+	// ZeroTolerance is the absolute value at or below which a value is
+	// considered to be zero.
+	ZeroTolerance float64 `json:"zero_tolerance"`
+}
+
+// SolutionJSON is the JSON representation of a solution. Values are keyed by
+// the name of the var. Vars without a name are keyed by their auto-generated
+// name, e.g. "F0" or "B1". Keys are unique, see NewSolutionJSON.
+type SolutionJSON struct {
+	// ObjectiveValue of the solution, omitted if the solution has no values.
+	ObjectiveValue *float64 `json:"objective_value,omitempty"`
+	// Provider of the solver that produced the solution.
+	Provider SolverProvider `json:"provider"`
+	// RunTime of the solver in seconds.
+	RunTime float64 `json:"run_time"`
+	// Statistics of the model.
+	Statistics SolutionJSONStatistics `json:"statistics"`
+	// Status of the solution.
+	Status string `json:"status"`
+	// Values of the vars keyed by name, omitted if the solution has no
+	// values.
+	Values map[string]float64 `json:"values,omitempty"`
+}
+
+// SolutionJSONStatistics are the statistics of the model reported in
+// SolutionJSON.
+type SolutionJSONStatistics struct {
+	// Constraints is the number of constraints of the model.
+	Constraints int `json:"constraints"`
+	// Variables is the number of vars of the model.
+	Variables int `json:"variables"`
+}
+
+// NewSolutionJSON creates the JSON representation of solution for model.
+// Returns an error wrapping ErrDuplicateName if two vars have the same key,
+// e.g. the same name, as one value would be lost.
+func NewSolutionJSON(
+	model Model,
+	solution Solution,
+	options SolutionJSONOptions,
+) (SolutionJSON, error) {
+	vars := model.Vars()
+
+	solutionJSON := SolutionJSON{
+		Provider: solution.Provider(),
+		RunTime:  solution.RunTime().Seconds(),
+		Statistics: SolutionJSONStatistics{
+			Constraints: len(model.Constraints()),
+			Variables:   len(vars),
+		},
+		Status: solutionStatus(solution),
+	}
+
+	if !solution.HasValues() {
+		return solutionJSON, nil
+	}
+
+	objectiveValue := solution.ObjectiveValue()
+	solutionJSON.ObjectiveValue = &objectiveValue
+	solutionJSON.Values = make(map[string]float64, len(vars))
+
+	// Skipped vars are keyed too, so the keys do not depend on the values.
+	keys := make(map[string]Var, len(vars))
+	for _, v := range vars {
+		key := fmt.Sprint(v)
+		if other, ok := keys[key]; ok {
+			return SolutionJSON{}, fmt.Errorf(
+				"%w: vars %d and %d are both keyed %q",
+				ErrDuplicateName, other.Index(), v.Index(), key,
+			)
+		}
+		keys[key] = v
+		value := solution.Value(v)
+		if options.SkipZeros && math.Abs(value) <= options.ZeroTolerance {
+			continue
+		}
+		solutionJSON.Values[key] = value
+	}
+
+	return solutionJSON, nil
+}
+
+// MarshalSolution returns the JSON encoding of solution for model, see
+// NewSolutionJSON.
+func MarshalSolution(
+	model Model,
+	solution Solution,
+	options SolutionJSONOptions,
+) ([]byte, error) {
+	solutionJSON, err := NewSolutionJSON(model, solution, options)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(solutionJSON)
+}
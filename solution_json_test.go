@@ -0,0 +1,42 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestMarshalSolutionDuplicateName(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewFloat(0, 10)
+	x.SetName("shift")
+	y.SetName("shift")
+	solution := newTestSolution(3, map[mip.Var]float64{x: 1, y: 2})
+
+	_, err := mip.MarshalSolution(model, solution, mip.SolutionJSONOptions{})
+	if !errors.Is(err, mip.ErrDuplicateName) {
+		t.Errorf("got error %v, want %v", err, mip.ErrDuplicateName)
+	}
+
+	// An unnamed var is keyed by its auto-generated name.
+	x.SetName("")
+	y.SetName("F0")
+	_, err = mip.MarshalSolution(model, solution, mip.SolutionJSONOptions{})
+	if !errors.Is(err, mip.ErrDuplicateName) {
+		t.Errorf("got error %v for a name of an unnamed var, want %v", err, mip.ErrDuplicateName)
+	}
+
+	y.SetName("overtime")
+	b, err := mip.MarshalSolution(model, solution, mip.SolutionJSONOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `"values":{"F0":1,"overtime":2}`; !strings.Contains(got, want) {
+		t.Errorf("got %s, want it to contain %s", got, want)
+	}
+}
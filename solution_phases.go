@@ -0,0 +1,61 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PhaseTimes break down the run time of a solve by phase, so performance
+// work can target the actual bottleneck. A phase the solver did not go
+// through, or did not measure, has a duration of zero.
+type PhaseTimes struct {
+	// Translation is the time spent translating the model to the back-end.
+	Translation time.Duration
+	// Presolve is the time spent in presolve.
+	Presolve time.Duration
+	// RootLP is the time spent solving the root LP relaxation.
+	RootLP time.Duration
+	// BranchAndBound is the time spent in branch and bound.
+	BranchAndBound time.Duration
+	// Postprocessing is the time spent after the search, e.g. to postsolve
+	// and to extract values.
+	Postprocessing time.Duration
+}
+
+// Total returns the sum of the durations of all phases.
+func (p PhaseTimes) Total() time.Duration {
+	return p.Translation +
+		p.Presolve +
+		p.RootLP +
+		p.BranchAndBound +
+		p.Postprocessing
+}
+
+// Optimization returns the time spent after translating the model to the
+// back-end, the sum of the durations of all phases but Translation.
+func (p PhaseTimes) Optimization() time.Duration {
+	return p.Total() - p.Translation
+}
+
+// MarshalJSON implements the [json.Marshaler] interface. Durations are
+// reported in seconds.
+func (p PhaseTimes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]float64{
+		"translation":      p.Translation.Seconds(),
+		"presolve":         p.Presolve.Seconds(),
+		"root_lp":          p.RootLP.Seconds(),
+		"branch_and_bound": p.BranchAndBound.Seconds(),
+		"postprocessing":   p.Postprocessing.Seconds(),
+	})
+}
+
+// PhaseTimedSolution is implemented by solutions which report the time spent
+// in each phase of the solve. Use a type assertion to check whether a
+// solution reports them.
+type PhaseTimedSolution interface {
+	Solution
+	// PhaseTimes returns the time spent in each phase of the solve.
+	PhaseTimes() PhaseTimes
+}
@@ -0,0 +1,109 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "math"
+
+// Impact is the effect of a change of the model on the objective value,
+// estimated from dual information, see RightHandSideImpact and BoundImpact.
+// The estimate is exact as long as the change does not change the optimal
+// basis, i.e. for small changes.
+type Impact struct {
+	// ObjectiveChange is the change of the objective value.
+	ObjectiveChange float64 `json:"objective_change"`
+	// Improvement is the change of the objective value in the direction of
+	// optimization: positive if the objective value gets better, negative if
+	// it gets worse.
+	Improvement float64 `json:"improvement"`
+}
+
+func newImpact(model Model, change float64) Impact {
+	improvement := -change
+	if model.Objective().IsMaximize() {
+		improvement = change
+	}
+	return Impact{
+		ObjectiveChange: change,
+		Improvement:     improvement,
+	}
+}
+
+// ShadowPriceOfBound returns the change of the objective value per unit
+// increase of the bound of v which is active in solution, i.e. the reduced
+// cost of v. A bound is active if the value of v is within tolerance of it.
+// Returns false if solution is not a DualSolution, has no values or v is not
+// at one of its bounds, in which case changing a bound does not change the
+// objective value.
+//
+// Dual information is only meaningful for linear problems. For a MIP, fix
+// the integer vars with FixIntegers and solve the resulting LP to obtain it.
+func ShadowPriceOfBound(solution Solution, v Var, tolerance float64) (float64, bool) {
+	dualSolution, ok := solution.(DualSolution)
+	if !ok || !solution.HasValues() {
+		return 0, false
+	}
+	lower, upper := bounds(v)
+	value := solution.Value(v)
+	if math.Abs(value-lower) > tolerance && math.Abs(value-upper) > tolerance {
+		return 0, false
+	}
+	return dualSolution.ReducedCost(v), true
+}
+
+// BoundImpact returns the impact of increasing the active bound of v in
+// solution by delta, e.g. "if we add one more truck, the cost decreases by
+// 420". Returns false if the impact can not be estimated, see
+// ShadowPriceOfBound.
+func BoundImpact(
+	model Model,
+	solution Solution,
+	v Var,
+	delta float64,
+	tolerance float64,
+) (Impact, bool) {
+	price, ok := ShadowPriceOfBound(solution, v, tolerance)
+	if !ok {
+		return Impact{}, false
+	}
+	return newImpact(model, price*delta), true
+}
+
+// RightHandSideImpact returns the impact of increasing the right-hand side
+// of constraint by delta, e.g. "if we add one more unit of capacity, the
+// profit increases by 12". Returns false if solution is not a DualSolution or
+// has no values.
+func RightHandSideImpact(
+	model Model,
+	solution Solution,
+	constraint Constraint,
+	delta float64,
+) (Impact, bool) {
+	dualSolution, ok := solution.(DualSolution)
+	if !ok || !solution.HasValues() {
+		return Impact{}, false
+	}
+	return newImpact(model, dualSolution.Dual(constraint)*delta), true
+}
+
+// FixIntegers fixes the int and bool vars of model to their rounded values in
+// solution and returns the fixed vars. Solving the fixed model is an LP which
+// provides the dual information of the MIP solution for what-if analysis.
+// Unfix the returned vars to restore the model. Vars which were already fixed
+// are not changed and not returned. Returns nil if solution has no values.
+func FixIntegers(model Model, solution Solution) Vars {
+	if !solution.HasValues() {
+		return nil
+	}
+	var fixed Vars
+	for _, v := range model.Vars() {
+		if !v.IsInt() {
+			continue
+		}
+		if _, ok := v.FixedValue(); ok {
+			continue
+		}
+		v.Fix(math.Round(solution.Value(v)))
+		fixed = append(fixed, v)
+	}
+	return fixed
+}
@@ -0,0 +1,64 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestImpact(t *testing.T) {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	trucks := model.NewFloat(0, 3)
+	load := model.NewFloat(0, 100)
+	capacity := model.NewConstraint(mip.LessThanOrEqual, 50)
+	capacity.NewTerm(1, load)
+
+	solution := &dualSolution{
+		testSolution: newTestSolution(0, map[mip.Var]float64{trucks: 3, load: 50}),
+		duals:        map[mip.Constraint]float64{capacity: 12},
+		reducedCosts: map[mip.Var]float64{trucks: 420},
+	}
+
+	if price, ok := mip.ShadowPriceOfBound(solution, trucks, 1e-9); !ok || price != 420 {
+		t.Errorf("got shadow price %v, %v, want 420, true", price, ok)
+	}
+	if _, ok := mip.ShadowPriceOfBound(solution, load, 1e-9); ok {
+		t.Errorf("expected no shadow price for a var between its bounds")
+	}
+	if _, ok := mip.ShadowPriceOfBound(solution.testSolution, trucks, 1e-9); ok {
+		t.Errorf("expected no shadow price without dual information")
+	}
+
+	impact, ok := mip.BoundImpact(model, solution, trucks, 1, 1e-9)
+	if !ok || impact.ObjectiveChange != 420 || impact.Improvement != 420 {
+		t.Errorf("got bound impact %+v, %v", impact, ok)
+	}
+	model.Objective().SetMinimize()
+	impact, ok = mip.RightHandSideImpact(model, solution, capacity, 2)
+	if !ok || impact.ObjectiveChange != 24 || impact.Improvement != -24 {
+		t.Errorf("got right-hand side impact %+v, %v", impact, ok)
+	}
+}
+
+func TestFixIntegers(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewInt(0, 10)
+	b := model.NewBool()
+	f := model.NewFloat(0, 1)
+	b.Fix(0)
+	solution := newTestSolution(0, map[mip.Var]float64{x: 3.0000001, b: 0, f: 0.5})
+
+	fixed := mip.FixIntegers(model, solution)
+	if len(fixed) != 1 || fixed[0] != x {
+		t.Fatalf("got fixed %v, want x", fixed)
+	}
+	if value, ok := x.FixedValue(); !ok || value != 3 {
+		t.Errorf("got x fixed to %v, %v, want 3, true", value, ok)
+	}
+	if _, ok := f.FixedValue(); ok {
+		t.Errorf("float var was fixed")
+	}
+}
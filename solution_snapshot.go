@@ -0,0 +1,127 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"math"
+	"time"
+)
+
+// SolutionSnapshot is an immutable copy of a Solution. It is safe for
+// concurrent use by multiple goroutines, all methods returning slices return
+// a copy.
+type SolutionSnapshot struct {
+	provider         SolverProvider
+	values           []float64
+	objectiveValue   float64
+	runTime          time.Duration
+	hasValues        bool
+	infeasible       bool
+	numericalFailure bool
+	optimal          bool
+	subOptimal       bool
+	timeOut          bool
+	unbounded        bool
+}
+
+// NewSolutionSnapshot creates an immutable copy of solution for the vars of
+// model. Optional information such as dual values is not part of the
+// snapshot.
+func NewSolutionSnapshot(model Model, solution Solution) *SolutionSnapshot {
+	snapshot := &SolutionSnapshot{
+		provider:         solution.Provider(),
+		objectiveValue:   solution.ObjectiveValue(),
+		runTime:          solution.RunTime(),
+		hasValues:        solution.HasValues(),
+		infeasible:       solution.IsInfeasible(),
+		numericalFailure: solution.IsNumericalFailure(),
+		optimal:          solution.IsOptimal(),
+		subOptimal:       solution.IsSubOptimal(),
+		timeOut:          solution.IsTimeOut(),
+		unbounded:        solution.IsUnbounded(),
+	}
+
+	if snapshot.hasValues {
+		vars := model.Vars()
+		snapshot.values = make([]float64, len(vars))
+		for i, v := range vars {
+			snapshot.values[i] = solution.Value(v)
+		}
+	}
+
+	return snapshot
+}
+
+// HasValues implements Solution.
+func (s *SolutionSnapshot) HasValues() bool {
+	return s.hasValues
+}
+
+// IsInfeasible implements Solution.
+func (s *SolutionSnapshot) IsInfeasible() bool {
+	return s.infeasible
+}
+
+// IsNumericalFailure implements Solution.
+func (s *SolutionSnapshot) IsNumericalFailure() bool {
+	return s.numericalFailure
+}
+
+// IsOptimal implements Solution.
+func (s *SolutionSnapshot) IsOptimal() bool {
+	return s.optimal
+}
+
+// IsSubOptimal implements Solution.
+func (s *SolutionSnapshot) IsSubOptimal() bool {
+	return s.subOptimal
+}
+
+// IsTimeOut implements Solution.
+func (s *SolutionSnapshot) IsTimeOut() bool {
+	return s.timeOut
+}
+
+// IsUnbounded implements Solution.
+func (s *SolutionSnapshot) IsUnbounded() bool {
+	return s.unbounded
+}
+
+// ObjectiveValue implements Solution.
+func (s *SolutionSnapshot) ObjectiveValue() float64 {
+	if !s.hasValues {
+		return 0.0
+	}
+	return s.objectiveValue
+}
+
+// Provider implements Solution.
+func (s *SolutionSnapshot) Provider() SolverProvider {
+	return s.provider
+}
+
+// RunTime implements Solution.
+func (s *SolutionSnapshot) RunTime() time.Duration {
+	return s.runTime
+}
+
+// Value implements Solution. Returns math.MaxFloat64 if the snapshot has no
+// values or variable is not a var of the model of the snapshot.
+func (s *SolutionSnapshot) Value(variable Var) float64 {
+	index := variable.Index()
+	if !s.hasValues || index < 0 || index >= len(s.values) {
+		return math.MaxFloat64
+	}
+	return s.values[index]
+}
+
+// Values returns a copy of the values of all vars, indexed by Var.Index.
+// Returns nil if the snapshot has no values.
+func (s *SolutionSnapshot) Values() []float64 {
+	if !s.hasValues {
+		return nil
+	}
+	values := make([]float64, len(s.values))
+	copy(values, s.values)
+	return values
+}
@@ -0,0 +1,43 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"sync"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestSolutionSnapshot(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0.0, 10.0)
+	y := model.NewBool()
+
+	solution := newTestSolution(3.0, map[mip.Var]float64{x: 2.0, y: 1.0})
+	snapshot := mip.NewSolutionSnapshot(model, solution)
+
+	// Changing the original solution does not change the snapshot.
+	solution.values[x.Index()] = 5.0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if snapshot.Value(x) != 2.0 || snapshot.Value(y) != 1.0 {
+				t.Errorf("unexpected values %v", snapshot.Values())
+			}
+			values := snapshot.Values()
+			values[0] = -1.0
+		}()
+	}
+	wg.Wait()
+
+	if !snapshot.IsOptimal() || snapshot.ObjectiveValue() != 3.0 {
+		t.Errorf("unexpected status or objective value")
+	}
+	if snapshot.Values()[0] != 2.0 {
+		t.Errorf("Values must return a copy")
+	}
+}
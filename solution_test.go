@@ -0,0 +1,80 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"math"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// testSolution is a Solution with fixed values, used to test functionality
+// operating on solutions without invoking a back-end solver.
+type testSolution struct {
+	values         map[int]float64
+	objectiveValue float64
+	optimal        bool
+}
+
+func newTestSolution(
+	objectiveValue float64,
+	values map[mip.Var]float64,
+) *testSolution {
+	solution := &testSolution{
+		values:         make(map[int]float64, len(values)),
+		objectiveValue: objectiveValue,
+		optimal:        true,
+	}
+	for v, value := range values {
+		solution.values[v.Index()] = value
+	}
+	return solution
+}
+
+func (s *testSolution) HasValues() bool {
+	return s.values != nil
+}
+
+func (s *testSolution) IsInfeasible() bool {
+	return false
+}
+
+func (s *testSolution) IsNumericalFailure() bool {
+	return false
+}
+
+func (s *testSolution) IsOptimal() bool {
+	return s.optimal
+}
+
+func (s *testSolution) IsSubOptimal() bool {
+	return !s.optimal
+}
+
+func (s *testSolution) IsTimeOut() bool {
+	return false
+}
+
+func (s *testSolution) IsUnbounded() bool {
+	return false
+}
+
+func (s *testSolution) ObjectiveValue() float64 {
+	return s.objectiveValue
+}
+
+func (s *testSolution) Provider() mip.SolverProvider {
+	return "test"
+}
+
+func (s *testSolution) RunTime() time.Duration {
+	return time.Second
+}
+
+func (s *testSolution) Value(variable mip.Var) float64 {
+	if s.values == nil {
+		return math.MaxFloat64
+	}
+	return s.values[variable.Index()]
+}
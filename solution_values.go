@@ -0,0 +1,49 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "math"
+
+// VarValue is the value of a var in a solution.
+type VarValue struct {
+	// Var is the var.
+	Var Var
+	// Value of the var.
+	Value float64
+}
+
+// ValueByName returns the value in solution of the var of model named name,
+// see Var.SetName. If several vars have the name, the one with the lowest
+// index is used. Returns false if no var has the name or solution has no
+// values. Each call scans the vars of model, use a VarMap or keep the vars to
+// look up many values.
+func ValueByName(model Model, solution Solution, name string) (float64, bool) {
+	if !solution.HasValues() {
+		return 0, false
+	}
+	for _, v := range model.Vars() {
+		if v.Name() == name {
+			return solution.Value(v), true
+		}
+	}
+	return 0, false
+}
+
+// NonZeroValues returns the vars of model whose value in solution exceeds the
+// default feasibility tolerance in magnitude, in the order of their index.
+// For sparse solutions, e.g. of assignment models where few bool vars are 1,
+// iterating them is much cheaper than iterating all vars. Returns nil if
+// solution has no values.
+func NonZeroValues(model Model, solution Solution) []VarValue {
+	if !solution.HasValues() {
+		return nil
+	}
+	tolerance := DefaultTolerances().Feasibility
+	var values []VarValue
+	for _, v := range model.Vars() {
+		if value := solution.Value(v); math.Abs(value) > tolerance {
+			values = append(values, VarValue{Var: v, Value: value})
+		}
+	}
+	return values
+}
@@ -0,0 +1,107 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// SolveLogger emits the lifecycle events of a solve to the logger of the
+// solve options. Back-ends create one per Solver.Solve invocation and report
+// every stage of the solve, so solves are observable with the logging stack
+// of the application. All methods are no-ops if the options have no logger.
+// The methods are safe for concurrent use, e.g. from callbacks invoked on
+// threads of the solver.
+type SolveLogger struct {
+	logger     *slog.Logger
+	start      time.Time
+	incumbents atomic.Int64
+}
+
+// NewSolveLogger creates a SolveLogger for solving model with provider. All
+// events carry the provider and the size of the model.
+func NewSolveLogger(
+	options SolveOptions,
+	provider SolverProvider,
+	model Model,
+) *SolveLogger {
+	if options.Logger == nil {
+		return &SolveLogger{}
+	}
+	return &SolveLogger{
+		logger: options.Logger.With(
+			slog.String("provider", string(provider)),
+			slog.Int("vars", len(model.Vars())),
+			slog.Int("constraints", len(model.Constraints())),
+		),
+		start: time.Now(),
+	}
+}
+
+// ModelHandedToBackend logs that the model has been translated and handed
+// to the back-end solver.
+func (l *SolveLogger) ModelHandedToBackend() {
+	l.log(slog.LevelInfo, "model handed to backend")
+}
+
+// PresolveDone logs that presolve finished with the given size of the
+// presolved model.
+func (l *SolveLogger) PresolveDone(vars int, constraints int) {
+	l.log(
+		slog.LevelDebug,
+		"presolve done",
+		slog.Int("presolved_vars", vars),
+		slog.Int("presolved_constraints", constraints),
+	)
+}
+
+// Incumbent logs that a solution with objectiveValue has been found. The
+// first one is logged as "first incumbent", all later ones as "improved
+// incumbent".
+func (l *SolveLogger) Incumbent(objectiveValue float64) {
+	if l.logger == nil {
+		return
+	}
+	incumbents := l.incumbents.Add(1)
+	msg := "improved incumbent"
+	if incumbents == 1 {
+		msg = "first incumbent"
+	}
+	l.log(
+		slog.LevelDebug,
+		msg,
+		slog.Float64("objective_value", objectiveValue),
+		slog.Int64("incumbents", incumbents),
+	)
+}
+
+// Finished logs that the solve finished with solution. A nil solution logs
+// err as the reason the solve failed.
+func (l *SolveLogger) Finished(solution Solution, err error) {
+	if err != nil || solution == nil {
+		l.log(slog.LevelError, "finished", slog.Any("error", err))
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("status", solutionStatus(solution)),
+		slog.Duration("run_time", solution.RunTime()),
+	}
+	if solution.HasValues() {
+		attrs = append(
+			attrs,
+			slog.Float64("objective_value", solution.ObjectiveValue()),
+		)
+	}
+	l.log(slog.LevelInfo, "finished", attrs...)
+}
+
+func (l *SolveLogger) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if l.logger == nil {
+		return
+	}
+	attrs = append(attrs, slog.Duration("elapsed", time.Since(l.start)))
+	l.logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
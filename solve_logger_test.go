@@ -0,0 +1,44 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestSolveLoggerConcurrentIncumbents(t *testing.T) {
+	var buffer bytes.Buffer
+	options := mip.SolveOptions{}
+	options.SetLogger(slog.New(slog.NewTextHandler(&buffer, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+	logger := mip.NewSolveLogger(options, "test", mip.NewModel())
+
+	// Back-ends report incumbents from the threads of the solver.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			logger.Incumbent(float64(i))
+		}(i)
+	}
+	wg.Wait()
+
+	log := buffer.String()
+	if got := strings.Count(log, `msg="first incumbent"`); got != 1 {
+		t.Errorf("got %d first incumbents, want 1", got)
+	}
+	if got := strings.Count(log, `msg="improved incumbent"`); got != 7 {
+		t.Errorf("got %d improved incumbents, want 7", got)
+	}
+	if !strings.Contains(log, "incumbents=8") {
+		t.Errorf("got log %q, want 8 incumbents", log)
+	}
+}
@@ -2,7 +2,18 @@
 
 package mip
 
+import (
+	"math"
+	"time"
+)
+
 // Solver for a MIP problem.
+//
+// A solver may keep the state of the back-end, e.g. the translated model,
+// basis, cuts and incumbent, across invocations of Solve for the same model.
+// Solvers which hold resources implement io.Closer, use a Session to reuse
+// a solver and release it. Check for optional interfaces, e.g.
+// IncumbentNotifier, on UnwrapSolver of a solver created by NewSolver.
 type Solver interface {
 	// Solve is the entrypoint to solve the model associated with
 	// the invoking solver. Returns a solution when the invoking solver
@@ -12,3 +23,75 @@ type Solver interface {
 
 // SolverProvider identifier for a back-end solver.
 type SolverProvider string
+
+// SolverFactory creates a solver for a model.
+type SolverFactory func(model Model) (Solver, error)
+
+// Incumbent is an improving solution found while solving.
+type Incumbent struct {
+	// ObjectiveValue of the incumbent.
+	ObjectiveValue float64
+	// Values of the vars, indexed by Var.Index.
+	Values []float64
+}
+
+// IncumbentNotifier is implemented by solvers which report incumbents while
+// solving.
+type IncumbentNotifier interface {
+	// OnIncumbent registers f to be invoked, possibly from another goroutine,
+	// every time the invoking solver finds an improving solution.
+	OnIncumbent(f func(incumbent Incumbent))
+}
+
+// IncumbentAcceptor is implemented by solvers which accept incumbents found
+// elsewhere while solving, using them as warm start and cutoff.
+type IncumbentAcceptor interface {
+	// AcceptIncumbent hands incumbent to the invoking solver. It is safe to
+	// invoke AcceptIncumbent concurrently with Solve.
+	AcceptIncumbent(incumbent Incumbent)
+}
+
+// Interrupter is implemented by solvers whose solve can be stopped early.
+type Interrupter interface {
+	// Interrupt asks the running solve of the invoking solver to stop as
+	// soon as possible and return the best solution found so far. It is
+	// safe to invoke Interrupt concurrently with Solve and after Solve has
+	// returned.
+	Interrupt()
+}
+
+// Progress is the state of a running solve.
+type Progress struct {
+	// BestBound is the best bound on the objective value proven so far.
+	BestBound float64
+	// Elapsed is the time since the solve started.
+	Elapsed time.Duration
+	// HasIncumbent is true if a solution has been found.
+	HasIncumbent bool
+	// Incumbent is the objective value of the best solution found so far,
+	// only meaningful if HasIncumbent is true.
+	Incumbent float64
+	// Nodes is the number of branch-and-bound nodes processed so far.
+	Nodes int64
+}
+
+// Gap returns the relative gap between the incumbent and the best bound,
+// |incumbent - bound| / |incumbent|. Returns +Inf if there is no incumbent.
+func (p Progress) Gap() float64 {
+	if !p.HasIncumbent || math.IsInf(p.BestBound, 0) {
+		return math.Inf(1)
+	}
+	difference := math.Abs(p.Incumbent - p.BestBound)
+	if difference == 0 {
+		return 0
+	}
+	return difference / math.Abs(p.Incumbent)
+}
+
+// ProgressNotifier is implemented by solvers which report their progress
+// while solving.
+type ProgressNotifier interface {
+	// OnProgress registers f to be invoked, possibly from another goroutine,
+	// every time the invoking solver reports progress.
+	OnProgress(f func(progress Progress))
+}
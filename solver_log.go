@@ -0,0 +1,291 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogRecordKind identifies the kind of a LogRecord.
+type LogRecordKind string
+
+const (
+	// LogMessage is a line of the log without structure known to the parser.
+	LogMessage LogRecordKind = "message"
+	// LogProgress is a progress line of the branch-and-bound search.
+	LogProgress LogRecordKind = "progress"
+	// LogIncumbent is a progress line reporting an improved solution, e.g.
+	// found by a heuristic. LogRecord.Source identifies how it was found.
+	LogIncumbent LogRecordKind = "incumbent"
+	// LogSummary is a line of the report printed when the solver finishes.
+	LogSummary LogRecordKind = "summary"
+)
+
+// LogRecord is a structured line of the log of a solver.
+type LogRecord struct {
+	// Kind of the record.
+	Kind LogRecordKind `json:"kind"`
+	// Line is the raw line of the log, without the trailing newline.
+	Line string `json:"line"`
+	// Progress of the search for LogProgress and LogIncumbent records, nil
+	// otherwise.
+	Progress *LogProgressRecord `json:"progress,omitempty"`
+	// Source of the solution of a LogIncumbent record as reported by the
+	// solver, e.g. "H" for a heuristic in HiGHS.
+	Source string `json:"source,omitempty"`
+	// Name of the value of a LogSummary record, e.g. "primal bound".
+	Name string `json:"name,omitempty"`
+	// Value of a LogSummary record.
+	Value float64 `json:"value,omitempty"`
+}
+
+// LogProgressRecord is the state of the branch-and-bound search reported on
+// a progress line of the log. Percentages are in [0, 100], a gap which is
+// not known is +Inf. Values which are not finite are written as null in
+// JSON.
+type LogProgressRecord struct {
+	BestBound       float64       `json:"best_bound"`
+	BestSolution    float64       `json:"best_solution"`
+	Conflicts       int64         `json:"conflicts"`
+	Cuts            int64         `json:"cuts"`
+	CutsInLP        int64         `json:"cuts_in_lp"`
+	ExploredPercent float64       `json:"explored_percent"`
+	GapPercent      float64       `json:"gap_percent"`
+	LPIterations    int64         `json:"lp_iterations"`
+	Leaves          int64         `json:"leaves"`
+	Nodes           int64         `json:"nodes"`
+	NodesInQueue    int64         `json:"nodes_in_queue"`
+	Time            time.Duration `json:"time"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface. Values which are
+// not finite, e.g. the best solution before the first incumbent, are
+// written as null.
+func (r LogProgressRecord) MarshalJSON() ([]byte, error) {
+	type record LogProgressRecord
+	return json.Marshal(struct {
+		record
+		BestBound       *float64 `json:"best_bound"`
+		BestSolution    *float64 `json:"best_solution"`
+		ExploredPercent *float64 `json:"explored_percent"`
+		GapPercent      *float64 `json:"gap_percent"`
+	}{
+		record:          record(r),
+		BestBound:       finite(r.BestBound),
+		BestSolution:    finite(r.BestSolution),
+		ExploredPercent: finite(r.ExploredPercent),
+		GapPercent:      finite(r.GapPercent),
+	})
+}
+
+// MarshalJSON implements the [json.Marshaler] interface. A value which is
+// not finite is omitted.
+func (r LogRecord) MarshalJSON() ([]byte, error) {
+	type record LogRecord
+	value := finite(r.Value)
+	if r.Value == 0 {
+		value = nil
+	}
+	return json.Marshal(struct {
+		record
+		Value *float64 `json:"value,omitempty"`
+	}{
+		record: record(r),
+		Value:  value,
+	})
+}
+
+// finite returns a pointer to value, nil if value is NaN or infinite.
+func finite(value float64) *float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	return &value
+}
+
+// Progress converts the record into a Progress. Solvers which report their
+// progress only through their log use it to implement ProgressNotifier.
+func (r LogProgressRecord) Progress() Progress {
+	return Progress{
+		BestBound:    r.BestBound,
+		Elapsed:      r.Time,
+		HasIncumbent: !math.IsInf(r.BestSolution, 0),
+		Incumbent:    r.BestSolution,
+		Nodes:        r.Nodes,
+	}
+}
+
+// LogParser turns a line of the log of a solver into a record. Lines which
+// can not be parsed are returned as LogMessage records.
+type LogParser func(line string) LogRecord
+
+// NewLogRecordWriter returns a writer which splits everything written to it
+// into lines, parses them with parser and hands the records to f. Use it as
+// SolveOptions.LogWriter to capture the log of a solver in structured
+// logging. Close flushes an incomplete last line. It is safe to write to the
+// writer from multiple goroutines, f is never invoked concurrently.
+func NewLogRecordWriter(parser LogParser, f func(LogRecord)) io.WriteCloser {
+	return &logRecordWriter{parser: parser, f: f}
+}
+
+type logRecordWriter struct {
+	parser LogParser
+	f      func(LogRecord)
+	buffer []byte
+	mutex  sync.Mutex
+}
+
+func (w *logRecordWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.buffer = append(w.buffer, p...)
+	for {
+		i := bytes.IndexByte(w.buffer, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(string(w.buffer[:i]))
+		w.buffer = w.buffer[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *logRecordWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(w.buffer) > 0 {
+		w.emit(string(w.buffer))
+		w.buffer = nil
+	}
+	return nil
+}
+
+func (w *logRecordWriter) emit(line string) {
+	w.f(w.parser(strings.TrimRight(line, "\r")))
+}
+
+// highsProgressColumns is the number of columns of a progress line of the
+// HiGHS MIP log, without the source of a solution.
+const highsProgressColumns = 12
+
+// highsSummaryNames maps the labels of the HiGHS solving report to the names
+// of LogSummary records.
+var highsSummaryNames = map[string]string{
+	"Primal bound":  "primal bound",
+	"Dual bound":    "dual bound",
+	"Gap":           "gap",
+	"Nodes":         "nodes",
+	"LP iterations": "lp iterations",
+	"Timing":        "time",
+}
+
+// ParseHiGHSLogLine is a LogParser for the log of HiGHS. It recognizes the
+// progress lines of the MIP search, including the cut statistics, lines
+// reporting improved solutions and the solving report.
+func ParseHiGHSLogLine(line string) LogRecord {
+	fields := strings.Fields(line)
+	source := ""
+	if len(fields) == highsProgressColumns+1 && !isDigit(fields[0][0]) {
+		source, fields = fields[0], fields[1:]
+	}
+	if progress, ok := parseHiGHSProgress(fields); ok {
+		kind := LogProgress
+		if source != "" {
+			kind = LogIncumbent
+		}
+		return LogRecord{Kind: kind, Line: line, Progress: progress, Source: source}
+	}
+
+	trimmed := strings.TrimSpace(line)
+	for label, name := range highsSummaryNames {
+		rest, ok := strings.CutPrefix(trimmed, label+" ")
+		if !ok {
+			continue
+		}
+		value := strings.Fields(rest)
+		if len(value) == 0 {
+			break
+		}
+		number, err := parseHiGHSNumber(strings.TrimSuffix(value[0], "%"))
+		if err != nil {
+			break
+		}
+		return LogRecord{Kind: LogSummary, Line: line, Name: name, Value: number}
+	}
+
+	return LogRecord{Kind: LogMessage, Line: line}
+}
+
+func parseHiGHSProgress(fields []string) (*LogProgressRecord, bool) {
+	if len(fields) != highsProgressColumns ||
+		!strings.HasSuffix(fields[3], "%") ||
+		!strings.HasSuffix(fields[11], "s") {
+		return nil, false
+	}
+
+	var integers [7]int64
+	for i, j := range []int{0, 1, 2, 7, 8, 9, 10} {
+		value, err := strconv.ParseInt(fields[j], 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		integers[i] = value
+	}
+
+	var floats [4]float64
+	for i, field := range []string{
+		strings.TrimSuffix(fields[3], "%"),
+		fields[4],
+		fields[5],
+		strings.TrimSuffix(fields[6], "%"),
+	} {
+		value, err := parseHiGHSNumber(field)
+		if err != nil {
+			return nil, false
+		}
+		floats[i] = value
+	}
+
+	seconds, err := strconv.ParseFloat(strings.TrimSuffix(fields[11], "s"), 64)
+	if err != nil {
+		return nil, false
+	}
+
+	return &LogProgressRecord{
+		Nodes:           integers[0],
+		NodesInQueue:    integers[1],
+		Leaves:          integers[2],
+		ExploredPercent: floats[0],
+		BestBound:       floats[1],
+		BestSolution:    floats[2],
+		GapPercent:      floats[3],
+		Cuts:            integers[3],
+		CutsInLP:        integers[4],
+		Conflicts:       integers[5],
+		LPIterations:    integers[6],
+		Time:            time.Duration(seconds * float64(time.Second)),
+	}, true
+}
+
+// parseHiGHSNumber parses a number of the HiGHS log, which uses "inf" and
+// "Large" for unknown and very large values.
+func parseHiGHSNumber(field string) (float64, error) {
+	switch field {
+	case "Large":
+		return math.Inf(1), nil
+	case "-Large":
+		return math.Inf(-1), nil
+	}
+	return strconv.ParseFloat(field, 64)
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
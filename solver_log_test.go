@@ -0,0 +1,116 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+const testHiGHSLog = `Running HiGHS 1.7.0: Copyright (c) 2024 HiGHS under MIT licence terms
+   Nodes   |   B&B Tree   |   Objective Bounds   |  Dynamic Constraints |   Work
+   Proc. InQueue |  Leaves   Expl. | BestBound   BestSol   Gap |   Cuts   InLp Confl. | LpIters   Time
+
+   0   0   0   0.00%   -inf   inf   inf   0   0   0   0   0.0s
+ H   0   0   0   0.00%   -inf   36   Large   0   0   0   0   0.0s
+   0   0   0   0.00%   15.5   36   56.94%   12   5   0   20   0.1s
+
+Solving report
+  Status            Optimal
+  Primal bound      30
+  Dual bound        30
+  Gap               0% (tolerance: 0.01%)
+  Nodes             1
+  LP iterations     20 (total)
+`
+
+func TestParseHiGHSLogLine(t *testing.T) {
+	var records []mip.LogRecord
+	w := mip.NewLogRecordWriter(mip.ParseHiGHSLogLine, func(r mip.LogRecord) {
+		records = append(records, r)
+	})
+
+	options := mip.SolveOptions{}
+	options.SetLogWriter(w)
+	// Write in chunks which do not align with lines.
+	for i := 0; i < len(testHiGHSLog); i += 7 {
+		_, err := io.WriteString(options.LogWriter, testHiGHSLog[i:min(i+7, len(testHiGHSLog))])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	counts := make(map[mip.LogRecordKind]int)
+	for _, record := range records {
+		counts[record.Kind]++
+	}
+	want := map[mip.LogRecordKind]int{
+		mip.LogMessage:   7,
+		mip.LogProgress:  2,
+		mip.LogIncumbent: 1,
+		mip.LogSummary:   5,
+	}
+	if fmt.Sprint(counts) != fmt.Sprint(want) {
+		t.Errorf("got record counts %v, want %v", counts, want)
+	}
+
+	incumbent := records[5]
+	if incumbent.Kind != mip.LogIncumbent || incumbent.Source != "H" ||
+		incumbent.Progress.BestSolution != 36 ||
+		!math.IsInf(incumbent.Progress.GapPercent, 1) {
+		t.Errorf("unexpected incumbent record %+v", incumbent)
+	}
+
+	progress := records[6].Progress
+	wantProgress := mip.LogProgressRecord{
+		BestBound:    15.5,
+		BestSolution: 36,
+		Cuts:         12,
+		CutsInLP:     5,
+		GapPercent:   56.94,
+		LPIterations: 20,
+		Time:         100 * time.Millisecond,
+	}
+	if progress == nil || *progress != wantProgress {
+		t.Errorf("got progress %+v, want %+v", progress, wantProgress)
+	}
+
+	gap := records[12]
+	if gap.Kind != mip.LogSummary || gap.Name != "gap" || gap.Value != 0 {
+		t.Errorf("unexpected summary record %+v", gap)
+	}
+}
+
+func TestLogProgressRecordJSON(t *testing.T) {
+	// The first progress line is logged before the first incumbent.
+	record := mip.ParseHiGHSLogLine("   0   0   0   0.00%   -inf   inf   inf   0   0   0   0   0.0s")
+	if record.Kind != mip.LogProgress || !math.IsInf(record.Progress.BestSolution, 1) {
+		t.Fatalf("got record %+v, want progress without incumbent", record)
+	}
+	b, err := json.Marshal(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	progress := decoded["progress"].(map[string]any)
+	for _, key := range []string{"best_bound", "best_solution", "gap_percent"} {
+		if value, ok := progress[key]; !ok || value != nil {
+			t.Errorf("got %s %v, want null", key, value)
+		}
+	}
+	if progress["explored_percent"] != 0.0 || progress["nodes"] != 0.0 {
+		t.Errorf("got progress %v, want explored percent and nodes 0", progress)
+	}
+}
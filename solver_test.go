@@ -0,0 +1,49 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"sync"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// testSolver is a Solver returning a fixed solution, used to test
+// functionality orchestrating solvers without a back-end solver. It reports
+// incumbents and records the incumbents it accepts.
+type testSolver struct {
+	solution    *testSolution
+	err         error
+	incumbents  []mip.Incumbent
+	onIncumbent func(mip.Incumbent)
+	accepted    []mip.Incumbent
+	mutex       sync.Mutex
+}
+
+func (s *testSolver) Solve(_ mip.SolveOptions) (mip.Solution, error) {
+	if s.onIncumbent != nil {
+		for _, incumbent := range s.incumbents {
+			s.onIncumbent(incumbent)
+		}
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.solution, nil
+}
+
+func (s *testSolver) OnIncumbent(f func(mip.Incumbent)) {
+	s.onIncumbent = f
+}
+
+func (s *testSolver) AcceptIncumbent(incumbent mip.Incumbent) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.accepted = append(s.accepted, incumbent)
+}
+
+func (s *testSolver) factory() mip.SolverFactory {
+	return func(mip.Model) (mip.Solver, error) {
+		return s, nil
+	}
+}
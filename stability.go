@@ -0,0 +1,59 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "sort"
+
+// PenalizeDeviation adds objective terms to model penalizing the deviation
+// of vars from their value in reference by weight per unit, e.g. to keep a
+// re-plan of a rolling horizon close to the previous plan. The deviation of
+// a var is its absolute difference to the reference value, modeled with a
+// new var. Returns the deviation vars by the var they measure. The deviation
+// of a bool var from 0 or 1 is linear in the var, it is penalized with a
+// term of the bool var itself, which shifts the objective value by weight
+// per bool var which is 1 in reference. The penalty is added when
+// minimizing and subtracted when maximizing, so the objective sense must be
+// set before.
+//
+//	reference := make(map[mip.Var]float64)
+//	for _, v := range vars {
+//		reference[v] = previous.Value(v)
+//	}
+//	mip.PenalizeDeviation(model, reference, 10)
+func PenalizeDeviation(
+	model Model,
+	reference map[Var]float64,
+	weight float64,
+) map[Var]Var {
+	objective := model.Objective()
+	if objective.IsMaximize() {
+		weight = -weight
+	}
+
+	vars := make(Vars, 0, len(reference))
+	for v := range reference {
+		vars = append(vars, v)
+	}
+	sort.Slice(vars, func(i, j int) bool {
+		return vars[i].Index() < vars[j].Index()
+	})
+
+	deviations := make(map[Var]Var, len(vars))
+	for _, v := range vars {
+		value := reference[v]
+		if v.Type() == Binary && (value == 0 || value == 1) {
+			objective.NewTerm(weight*(1-2*value), v)
+			continue
+		}
+		deviation := model.NewFloat(0, Infinity())
+		above := model.NewConstraint(LessThanOrEqual, value)
+		above.NewTerm(1, v)
+		above.NewTerm(-1, deviation)
+		below := model.NewConstraint(GreaterThanOrEqual, value)
+		below.NewTerm(1, v)
+		below.NewTerm(1, deviation)
+		objective.NewTerm(weight, deviation)
+		deviations[v] = deviation
+	}
+	return deviations
+}
@@ -0,0 +1,52 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestPenalizeDeviation(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	on := model.NewBool()
+	off := model.NewBool()
+
+	deviations := mip.PenalizeDeviation(model, map[mip.Var]float64{
+		x:   4,
+		on:  1,
+		off: 0,
+	}, 2)
+	if len(deviations) != 1 || deviations[x] == nil {
+		t.Fatalf("got deviations %v, want one for %v", deviations, x)
+	}
+	if got := len(model.Constraints()); got != 2 {
+		t.Errorf("got %d constraints, want 2", got)
+	}
+
+	objective := model.Objective()
+	for v, want := range map[mip.Var]float64{deviations[x]: 2, on: -2, off: 2} {
+		if term, _ := objective.Term(v); term.Coefficient() != want {
+			t.Errorf("got coefficient %v for %v, want %v", term.Coefficient(), v, want)
+		}
+	}
+
+	// The deviation is at least |x - 4|.
+	for deviation, want := range map[float64]int{3: 0, 2: 1} {
+		solution := newTestSolution(0, map[mip.Var]float64{x: 7, deviations[x]: deviation})
+		report := mip.Verify(model, solution, mip.DefaultTolerances())
+		if got := len(report.ConstraintViolations); got != want {
+			t.Errorf("deviation %v: got %d violations, want %d", deviation, got, want)
+		}
+	}
+
+	maximize := mip.NewModel()
+	maximize.Objective().SetMaximize()
+	y := maximize.NewFloat(0, 10)
+	deviations = mip.PenalizeDeviation(maximize, map[mip.Var]float64{y: 1}, 3)
+	if term, _ := maximize.Objective().Term(deviations[y]); term.Coefficient() != -3 {
+		t.Errorf("got coefficient %v when maximizing, want -3", term.Coefficient())
+	}
+}
@@ -0,0 +1,24 @@
+// © 2019-present nextmv.io inc
+
+// Package stochastic builds two-stage stochastic programs. First-stage
+// decisions are taken before the uncertainty is revealed, recourse decisions
+// are taken per scenario after it is revealed. The program is solved as its
+// deterministic equivalent, a single model with the first-stage vars and a
+// copy of the recourse vars and constraints per scenario, which minimizes or
+// maximizes the first-stage objective plus the expected recourse objective.
+//
+//	program := stochastic.NewProgram()
+//	capacity := program.Model().NewFloat(0, 100)
+//	program.Model().Objective().NewTerm(10, capacity)
+//
+//	for _, d := range demands {
+//		scenario := program.NewScenario(d.name, d.probability)
+//		shortage := scenario.NewFloat(0, mip.Infinity())
+//		scenario.NewObjectiveTerm(50, shortage)
+//		c := scenario.NewConstraint(mip.GreaterThanOrEqual, d.demand)
+//		c.NewTerm(1, capacity)
+//		c.NewTerm(1, shortage)
+//	}
+//
+//	model, err := program.DeterministicEquivalent()
+package stochastic
@@ -0,0 +1,181 @@
+// © 2019-present nextmv.io inc
+
+package stochastic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// probabilityTolerance is the tolerance of the sum of the probabilities of
+// the scenarios of a program.
+const probabilityTolerance = 1e-9
+
+// Program is a two-stage stochastic program, see NewProgram.
+type Program struct {
+	model     mip.Model
+	first     int
+	scenarios []*Scenario
+}
+
+// NewProgram creates an empty two-stage stochastic program.
+func NewProgram() *Program {
+	return &Program{
+		model: mip.NewModel(),
+	}
+}
+
+// Model returns the model of the invoking program. Vars, constraints and
+// objective terms added to it directly, before the first scenario is
+// created, form the first stage.
+func (p *Program) Model() mip.Model {
+	return p.model
+}
+
+// FirstStageVars returns the vars of the first stage of the invoking
+// program.
+func (p *Program) FirstStageVars() mip.Vars {
+	vars := p.model.Vars()
+	if len(p.scenarios) == 0 {
+		return vars
+	}
+	return vars[:p.first]
+}
+
+// NewScenario adds a scenario which occurs with probability to the invoking
+// program. The recourse vars and constraints of the scenario are added with
+// the methods of the returned scenario. Creating the first scenario ends the
+// first stage.
+func (p *Program) NewScenario(name string, probability float64) *Scenario {
+	if len(p.scenarios) == 0 {
+		p.first = len(p.model.Vars())
+	}
+	scenario := &Scenario{
+		program:     p,
+		name:        name,
+		probability: probability,
+	}
+	p.scenarios = append(p.scenarios, scenario)
+	return scenario
+}
+
+// Scenarios returns the scenarios of the invoking program in the order they
+// were created.
+func (p *Program) Scenarios() []*Scenario {
+	scenarios := make([]*Scenario, len(p.scenarios))
+	copy(scenarios, p.scenarios)
+	return scenarios
+}
+
+// DeterministicEquivalent returns the deterministic equivalent of the
+// invoking program which can be solved by any solver. Returns an error if
+// the program has no scenarios, a probability is not in [0, 1] or the
+// probabilities do not sum to 1.
+func (p *Program) DeterministicEquivalent() (mip.Model, error) {
+	if len(p.scenarios) == 0 {
+		return nil, errors.New("stochastic: no scenarios")
+	}
+	sum := 0.0
+	for _, s := range p.scenarios {
+		if math.IsNaN(s.probability) || s.probability < 0 || s.probability > 1 {
+			return nil, fmt.Errorf(
+				"stochastic: scenario %q has probability %v, want [0, 1]",
+				s.name,
+				s.probability,
+			)
+		}
+		sum += s.probability
+	}
+	if math.Abs(sum-1) > probabilityTolerance {
+		return nil, fmt.Errorf("stochastic: probabilities sum to %v, want 1", sum)
+	}
+	return p.model, nil
+}
+
+// Scenario is a realization of the uncertainty of a program with its
+// recourse vars and constraints, see Program.NewScenario.
+type Scenario struct {
+	program     *Program
+	name        string
+	probability float64
+	vars        mip.Vars
+	terms       []mip.TermSpec
+}
+
+// Name returns the name of the invoking scenario.
+func (s *Scenario) Name() string {
+	return s.name
+}
+
+// Probability returns the probability of the invoking scenario.
+func (s *Scenario) Probability() float64 {
+	return s.probability
+}
+
+// Vars returns the recourse vars of the invoking scenario.
+func (s *Scenario) Vars() mip.Vars {
+	vars := make(mip.Vars, len(s.vars))
+	copy(vars, s.vars)
+	return vars
+}
+
+// NewFloat adds a recourse float var to the invoking scenario, see
+// mip.Model.NewFloat. The var belongs to the group named after the scenario.
+func (s *Scenario) NewFloat(lowerBound, upperBound float64) mip.Float {
+	v := s.program.model.NewFloat(lowerBound, upperBound)
+	s.add(v)
+	return v
+}
+
+// NewInt adds a recourse int var to the invoking scenario, see
+// mip.Model.NewInt. The var belongs to the group named after the scenario.
+func (s *Scenario) NewInt(lowerBound, upperBound int64) mip.Int {
+	v := s.program.model.NewInt(lowerBound, upperBound)
+	s.add(v)
+	return v
+}
+
+// NewBool adds a recourse bool var to the invoking scenario, see
+// mip.Model.NewBool. The var belongs to the group named after the scenario.
+func (s *Scenario) NewBool() mip.Bool {
+	v := s.program.model.NewBool()
+	s.add(v)
+	return v
+}
+
+func (s *Scenario) add(v mip.Var) {
+	v.SetGroup(s.name)
+	s.vars = append(s.vars, v)
+}
+
+// NewConstraint adds a constraint of the invoking scenario, see
+// mip.Model.NewConstraint. Its terms may use first-stage vars and the
+// recourse vars of the scenario.
+func (s *Scenario) NewConstraint(sense mip.Sense, rightHandSide float64) mip.Constraint {
+	return s.program.model.NewConstraint(sense, rightHandSide)
+}
+
+// NewObjectiveTerm adds the term coefficient times v to the recourse
+// objective of the invoking scenario. The term is weighted with the
+// probability of the scenario in the objective of the program.
+func (s *Scenario) NewObjectiveTerm(coefficient float64, v mip.Var) mip.Term {
+	s.terms = append(s.terms, mip.TermSpec{Coefficient: coefficient, Var: v})
+	return s.program.model.Objective().NewTerm(s.probability*coefficient, v)
+}
+
+// ObjectiveValue returns the recourse objective value of the invoking
+// scenario in solution, not weighted with its probability. Returns 0 if
+// solution has no values.
+func (s *Scenario) ObjectiveValue(solution mip.Solution) float64 {
+	if !solution.HasValues() {
+		return 0
+	}
+	value := 0.0
+	for _, t := range s.terms {
+		value += t.Coefficient * solution.Value(t.Var)
+	}
+	return value
+}
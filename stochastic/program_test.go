@@ -0,0 +1,84 @@
+// © 2019-present nextmv.io inc
+
+package stochastic_test
+
+import (
+	"testing"
+	"time"
+
+	mip "github.com/nextmv-io/go-mip"
+	"github.com/nextmv-io/go-mip/stochastic"
+)
+
+type solution map[mip.Var]float64
+
+func (s solution) HasValues() bool                { return true }
+func (s solution) IsInfeasible() bool             { return false }
+func (s solution) IsNumericalFailure() bool       { return false }
+func (s solution) IsOptimal() bool                { return true }
+func (s solution) IsSubOptimal() bool             { return false }
+func (s solution) IsTimeOut() bool                { return false }
+func (s solution) IsUnbounded() bool              { return false }
+func (s solution) ObjectiveValue() float64        { return 0 }
+func (s solution) Provider() mip.SolverProvider   { return "test" }
+func (s solution) RunTime() time.Duration         { return 0 }
+func (s solution) Value(variable mip.Var) float64 { return s[variable] }
+
+func TestDeterministicEquivalent(t *testing.T) {
+	program := stochastic.NewProgram()
+	capacity := program.Model().NewFloat(0, 100)
+	program.Model().Objective().NewTerm(10, capacity)
+
+	shortages := map[string]mip.Var{}
+	for _, d := range []struct {
+		name        string
+		probability float64
+		demand      float64
+	}{{"low", 0.25, 20}, {"high", 0.75, 60}} {
+		scenario := program.NewScenario(d.name, d.probability)
+		shortage := scenario.NewFloat(0, mip.Infinity())
+		scenario.NewObjectiveTerm(40, shortage)
+		c := scenario.NewConstraint(mip.GreaterThanOrEqual, d.demand)
+		c.NewTerm(1, capacity)
+		c.NewTerm(1, shortage)
+		shortages[d.name] = shortage
+	}
+
+	model, err := program.DeterministicEquivalent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first := program.FirstStageVars(); len(first) != 1 || first[0] != capacity {
+		t.Errorf("got first-stage vars %v, want capacity", first)
+	}
+	if shortages["high"].Group() != "high" {
+		t.Errorf("got group %q, want high", shortages["high"].Group())
+	}
+
+	values := solution{capacity: 20, shortages["low"]: 0, shortages["high"]: 40}
+	evaluation := mip.Evaluate(model, values)
+	if violated := evaluation.Violated(1e-9); len(violated) > 0 {
+		t.Errorf("violated constraints: %v", violated)
+	}
+	if want := 10*20 + 0.75*40*40; evaluation.ObjectiveValue != want {
+		t.Errorf("got objective value %v, want %v", evaluation.ObjectiveValue, want)
+	}
+	if value := program.Scenarios()[1].ObjectiveValue(values); value != 1600 {
+		t.Errorf("got recourse objective value %v, want 1600", value)
+	}
+}
+
+func TestDeterministicEquivalentErrors(t *testing.T) {
+	program := stochastic.NewProgram()
+	if _, err := program.DeterministicEquivalent(); err == nil {
+		t.Error("expected error without scenarios")
+	}
+	program.NewScenario("a", 0.5)
+	if _, err := program.DeterministicEquivalent(); err == nil {
+		t.Error("expected error for probabilities not summing to 1")
+	}
+	program.NewScenario("b", 1.5)
+	if _, err := program.DeterministicEquivalent(); err == nil {
+		t.Error("expected error for probability out of range")
+	}
+}
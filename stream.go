@@ -0,0 +1,178 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrForeignVar is returned when a var of another model is used in a
+// ModelStream.
+var ErrForeignVar = errors.New("var does not belong to model stream")
+
+// ErrStreamedVar is the panic when the type, bounds or name of a var is
+// changed after the var has been passed to the sink of a ModelStream.
+var ErrStreamedVar = errors.New("var has been passed to the stream sink")
+
+// StreamSink receives the model built with a ModelStream, typically the
+// native model of a back-end. Back-ends which support streaming implement
+// it, see NewModelStream.
+type StreamSink interface {
+	// AddVars adds vars to the native model. The vars are passed in the
+	// order of their indices and each var is passed once. Their types,
+	// bounds and names do not change afterwards.
+	AddVars(vars Vars) error
+	// AddConstraint adds a constraint with name as specified by spec to
+	// the native model. The vars of spec have been passed to AddVars.
+	AddConstraint(name string, spec ConstraintSpec) error
+	// Solve sets the objective of the native model to objective and solves
+	// it. The vars of objective have been passed to AddVars. The values of
+	// the returned solution are accessed with the vars passed to AddVars.
+	Solve(objective Objective, options SolveOptions) (Solution, error)
+}
+
+// ModelStream builds a model directly in a back-end, for models too large to
+// hold twice in memory, once as a Model and once as the native model of the
+// back-end. Each constraint is passed to the sink when it is added and not
+// kept, only the vars and the objective are kept. Vars are passed to the
+// sink when they are first needed, which is before the next constraint is
+// added or the model is solved, so set the name of a var right after
+// creating it. Changing the type, fixed value or name of a var which has
+// been passed to the sink panics with an error wrapping ErrStreamedVar. The
+// objective is passed to the sink when the model is solved.
+//
+//	stream := mip.NewModelStream(sink)
+//	x := stream.NewFloat(0, 10)
+//	x.SetName("x")
+//	err := stream.AddConstraint("capacity", mip.ConstraintSpec{
+//		Sense:         mip.LessThanOrEqual,
+//		RightHandSide: 5,
+//		Terms:         []mip.TermSpec{{Coefficient: 1, Var: x}},
+//	})
+//	stream.Objective().NewTerm(1, x)
+//	solution, err := stream.Solve(options)
+//
+// The methods of a model stream are not safe for concurrent use.
+type ModelStream struct {
+	// model holds the vars and the objective, it has no constraints.
+	model       *model
+	sink        StreamSink
+	added       int
+	constraints int
+}
+
+// NewModelStream returns a model stream passing the model to sink.
+func NewModelStream(sink StreamSink) *ModelStream {
+	return &ModelStream{
+		model: NewModel().(*model),
+		sink:  sink,
+	}
+}
+
+// NewBool adds a bool var, see Model.NewBool.
+func (s *ModelStream) NewBool() Bool {
+	return s.model.NewBool()
+}
+
+// NewFloat adds a float var, see Model.NewFloat.
+func (s *ModelStream) NewFloat(lowerBound float64, upperBound float64) Float {
+	return s.model.NewFloat(lowerBound, upperBound)
+}
+
+// NewInt adds an int var, see Model.NewInt.
+func (s *ModelStream) NewInt(lowerBound int64, upperBound int64) Int {
+	return s.model.NewInt(lowerBound, upperBound)
+}
+
+// Vars returns a copy slice of the vars of the invoking model stream.
+func (s *ModelStream) Vars() Vars {
+	return s.model.Vars()
+}
+
+// Objective returns the objective of the invoking model stream.
+func (s *ModelStream) Objective() Objective {
+	return s.model.Objective()
+}
+
+// Constraints returns the number of constraints passed to the sink.
+func (s *ModelStream) Constraints() int {
+	return s.constraints
+}
+
+// AddConstraint passes a constraint with name as specified by spec to the
+// sink, unless spec is skipped. Returns an error wrapping ErrForeignVar if
+// a var of spec was not created by the invoking model stream, an error
+// wrapping ErrNaN if a number of spec is NaN and the errors of the sink.
+func (s *ModelStream) AddConstraint(name string, spec ConstraintSpec) error {
+	if spec.Skip {
+		return nil
+	}
+	if err := checkNaN("constraint right-hand side", spec.RightHandSide); err != nil {
+		return err
+	}
+	for _, t := range spec.Terms {
+		if err := checkNaN("constraint term coefficient", t.Coefficient); err != nil {
+			return err
+		}
+		if err := s.checkVar(t.Var); err != nil {
+			return err
+		}
+	}
+	if err := s.addVars(); err != nil {
+		return err
+	}
+	if err := s.sink.AddConstraint(name, spec); err != nil {
+		return err
+	}
+	s.constraints++
+	return nil
+}
+
+// Solve passes the vars not yet passed and the objective to the sink and
+// solves the model. Returns an error wrapping ErrForeignVar if a var of the
+// objective was not created by the invoking model stream and the errors of
+// the sink.
+func (s *ModelStream) Solve(options SolveOptions) (Solution, error) {
+	objective := s.model.Objective()
+	for _, t := range objective.Terms() {
+		if err := s.checkVar(t.Var()); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range objective.QuadraticTerms() {
+		if err := s.checkVar(t.Var1()); err != nil {
+			return nil, err
+		}
+		if err := s.checkVar(t.Var2()); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.addVars(); err != nil {
+		return nil, err
+	}
+	return s.sink.Solve(objective, options)
+}
+
+// addVars passes the vars created since the last call to the sink.
+func (s *ModelStream) addVars() error {
+	if s.added == len(s.model.vars) {
+		return nil
+	}
+	if err := s.sink.AddVars(s.model.vars[s.added:len(s.model.vars):len(s.model.vars)]); err != nil {
+		return err
+	}
+	s.added = len(s.model.vars)
+	s.model.streamed = s.added
+	return nil
+}
+
+// checkVar returns an error wrapping ErrForeignVar if v was not created by
+// the invoking model stream.
+func (s *ModelStream) checkVar(v Var) error {
+	index := v.Index()
+	if index < 0 || index >= len(s.model.vars) || s.model.vars[index] != v {
+		return fmt.Errorf("%w: %v", ErrForeignVar, v)
+	}
+	return nil
+}
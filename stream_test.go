@@ -0,0 +1,133 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// recordingSink builds the streamed model as a model and records the calls.
+type recordingSink struct {
+	model mip.Model
+	calls []string
+}
+
+func (s *recordingSink) AddVars(vars mip.Vars) error {
+	for _, v := range vars {
+		s.model.NewFloat(v.LowerBound(), v.UpperBound()).SetName(v.Name())
+	}
+	s.calls = append(s.calls, fmt.Sprintf("vars %v", vars))
+	return nil
+}
+
+func (s *recordingSink) AddConstraint(name string, spec mip.ConstraintSpec) error {
+	c := s.model.NewConstraint(spec.Sense, spec.RightHandSide)
+	c.SetName(name)
+	for _, t := range spec.Terms {
+		c.NewTerm(t.Coefficient, s.model.Vars()[t.Var.Index()])
+	}
+	s.calls = append(s.calls, "constraint "+name)
+	return nil
+}
+
+func (s *recordingSink) Solve(objective mip.Objective, _ mip.SolveOptions) (mip.Solution, error) {
+	s.calls = append(s.calls, fmt.Sprintf("solve %v", objective.Terms()))
+	return newTestSolution(0, nil), nil
+}
+
+func TestModelStream(t *testing.T) {
+	sink := &recordingSink{model: mip.NewModel()}
+	stream := mip.NewModelStream(sink)
+	x := stream.NewFloat(0, 10)
+	x.SetName("x")
+	y := stream.NewInt(0, 5)
+	y.SetName("y")
+
+	err := stream.AddConstraint("capacity", mip.ConstraintSpec{
+		Sense:         mip.LessThanOrEqual,
+		RightHandSide: 8,
+		Terms:         []mip.TermSpec{{Coefficient: 1, Var: x}, {Coefficient: 2, Var: y}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := stream.NewBool()
+	err = stream.AddConstraint("skipped", mip.ConstraintSpec{Skip: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.AddConstraint("link", mip.ConstraintSpec{
+		Sense:         mip.GreaterThanOrEqual,
+		Terms:         []mip.TermSpec{{Coefficient: 1, Var: y}, {Coefficient: -5, Var: z}},
+		RightHandSide: 0,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	foreign := mip.NewModel().NewFloat(0, 1)
+	err = stream.AddConstraint("foreign", mip.ConstraintSpec{
+		Terms: []mip.TermSpec{{Coefficient: 1, Var: foreign}},
+	})
+	if !errors.Is(err, mip.ErrForeignVar) {
+		t.Errorf("got error %v, want ErrForeignVar", err)
+	}
+	err = stream.AddConstraint("nan", mip.ConstraintSpec{RightHandSide: math.NaN()})
+	if !errors.Is(err, mip.ErrNaN) {
+		t.Errorf("got error %v, want ErrNaN", err)
+	}
+
+	stream.Objective().NewTerm(3, x)
+	if _, err := stream.Solve(mip.SolveOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[vars [x y] constraint capacity vars [B2] constraint link solve [3 x]]"
+	if fmt.Sprint(sink.calls) != want {
+		t.Errorf("got calls %v, want %v", sink.calls, want)
+	}
+	if stream.Constraints() != 2 {
+		t.Errorf("got %d constraints, want 2", stream.Constraints())
+	}
+	if got := fmt.Sprint(sink.model.Constraints()); got != "[1 x + 2 y <= 8 1 y + -5 F2 >= 0]" {
+		t.Errorf("got streamed constraints %v", got)
+	}
+}
+
+func TestModelStreamStreamedVar(t *testing.T) {
+	stream := mip.NewModelStream(&recordingSink{model: mip.NewModel()})
+	x := stream.NewFloat(0, 10)
+	err := stream.AddConstraint("capacity", mip.ConstraintSpec{
+		Sense:         mip.LessThanOrEqual,
+		RightHandSide: 8,
+		Terms:         []mip.TermSpec{{Coefficient: 1, Var: x}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Vars which have not been passed to the sink yet can be changed.
+	y := stream.NewFloat(0, 5)
+	y.SetName("y")
+	y.Fix(1)
+
+	changes := map[string]func(){
+		"name":  func() { x.SetName("x") },
+		"fix":   func() { x.Fix(1) },
+		"unfix": func() { x.Unfix() },
+	}
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if err, ok := recover().(error); !ok || !errors.Is(err, mip.ErrStreamedVar) {
+					t.Errorf("got panic %v, want %v", err, mip.ErrStreamedVar)
+				}
+			}()
+			change()
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StringLimit is the number of vars, constraints and non-zeros of a model
+// above which Model.String returns Model.Summary instead of the full model.
+const StringLimit = 100_000
+
+const (
+	// summaryLines is the number of constraints and vars Summary shows.
+	summaryLines = 10
+	// summaryTerms is the number of terms above which Summary only shows
+	// the number of terms of a constraint.
+	summaryTerms = 10
+)
+
+func (m *model) Summary() string {
+	types := map[VarType]int{}
+	for _, v := range m.vars {
+		types[v.Type()]++
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(
+		&sb,
+		"%d vars (%d continuous, %d integer, %d binary), %d constraints, %d non-zeros\n",
+		len(m.vars),
+		types[Continuous],
+		types[Integer],
+		types[Binary],
+		len(m.constraints),
+		m.nonZeros,
+	)
+
+	direction := "minimize"
+	if m.objective.IsMaximize() {
+		direction = "maximize"
+	}
+	fmt.Fprintf(
+		&sb,
+		"%s %d terms, %d quadratic terms\n",
+		direction,
+		len(m.objective.Terms()),
+		len(m.objective.QuadraticTerms()),
+	)
+
+	for i, c := range m.constraints[:min(len(m.constraints), summaryLines)] {
+		if terms := len(c.Terms()); terms > summaryTerms {
+			fmt.Fprintf(&sb, "%7d: %d terms %s %v\n", i, terms, sense(c.Sense()), c.RightHandSide())
+			continue
+		}
+		fmt.Fprintf(&sb, "%7d: %v\n", i, c)
+	}
+	if more := len(m.constraints) - summaryLines; more > 0 {
+		fmt.Fprintf(&sb, "    ...: %d more constraints\n", more)
+	}
+
+	for i, v := range m.vars[:min(len(m.vars), summaryLines)] {
+		fmt.Fprintf(&sb, "%7d: %v [%v, %v]\n", i, v, v.LowerBound(), v.UpperBound())
+	}
+	if more := len(m.vars) - summaryLines; more > 0 {
+		fmt.Fprintf(&sb, "    ...: %d more vars\n", more)
+	}
+	return sb.String()
+}
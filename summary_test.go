@@ -0,0 +1,25 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"fmt"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestStringLimit(t *testing.T) {
+	model := mip.NewModel()
+	for i := 0; i < mip.StringLimit; i++ {
+		model.NewBool()
+	}
+	if got := fmt.Sprint(model); got == model.Summary() {
+		t.Errorf("got summary for a model within the limit")
+	}
+
+	model.NewBool()
+	if got, want := fmt.Sprint(model), model.Summary(); got != want {
+		t.Errorf("got %d bytes, want the summary", len(got))
+	}
+}
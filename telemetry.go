@@ -0,0 +1,125 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"math"
+	"time"
+)
+
+// SolveAttributes describe a solve before it starts, e.g. to set the
+// attributes of a tracing span.
+type SolveAttributes struct {
+	// Provider of the solver.
+	Provider SolverProvider `json:"provider"`
+	// Cols is the number of vars of the model.
+	Cols int `json:"cols"`
+	// Rows is the number of constraints of the model.
+	Rows int `json:"rows"`
+	// NonZeros is the number of constraint terms of the model.
+	NonZeros int `json:"non_zeros"`
+}
+
+// SolveResult describes a finished solve, e.g. to end a tracing span and
+// record metrics.
+type SolveResult struct {
+	// Duration is the wall-clock duration of Solver.Solve.
+	Duration time.Duration `json:"duration"`
+	// Err is the error returned by Solver.Solve.
+	Err error `json:"-"`
+	// Gap is the relative gap of the solution, NaN if it is not known, see
+	// RelativeGap.
+	Gap float64 `json:"-"`
+	// HasValues is true if the solution has values.
+	HasValues bool `json:"has_values"`
+	// ObjectiveValue of the solution, only meaningful if HasValues is true.
+	ObjectiveValue float64 `json:"objective_value"`
+	// Status of the solution, one of "optimal", "unbounded", "suboptimal",
+	// "infeasible", "unknown" or "error".
+	Status string `json:"status"`
+}
+
+// SolveObserver observes solves, e.g. to create a span per solve and record
+// metrics for solve duration and objective value with OpenTelemetry. This
+// package does not depend on a telemetry library, an application adapts its
+// tracer and meters to this interface.
+type SolveObserver interface {
+	// StartSolve is invoked before a solve starts. The returned function is
+	// invoked with the result once the solve finished.
+	StartSolve(attributes SolveAttributes) func(result SolveResult)
+}
+
+// ObserveSolverFactory returns a SolverFactory which creates solvers using
+// factory and reports each of their solves to observer. The returned solvers
+// only implement Solver and io.Closer, optional interfaces such as
+// IncumbentNotifier of the solvers created by factory are not available.
+// Closing a returned solver closes the solver created by factory, see
+// CloseSolver.
+func ObserveSolverFactory(
+	provider SolverProvider,
+	factory SolverFactory,
+	observer SolveObserver,
+) SolverFactory {
+	return func(model Model) (Solver, error) {
+		solver, err := factory(model)
+		if err != nil {
+			return nil, err
+		}
+		return &observedSolver{
+			solver:   solver,
+			model:    model,
+			provider: provider,
+			observer: observer,
+		}, nil
+	}
+}
+
+type observedSolver struct {
+	solver   Solver
+	model    Model
+	provider SolverProvider
+	observer SolveObserver
+}
+
+func (s *observedSolver) Unwrap() Solver {
+	return s.solver
+}
+
+func (s *observedSolver) Close() error {
+	return CloseSolver(s.solver)
+}
+
+func (s *observedSolver) Solve(options SolveOptions) (Solution, error) {
+	attributes := SolveAttributes{
+		Provider: s.provider,
+		Cols:     len(s.model.Vars()),
+		Rows:     len(s.model.Constraints()),
+	}
+	for _, c := range s.model.Constraints() {
+		attributes.NonZeros += len(c.Terms())
+	}
+
+	end := s.observer.StartSolve(attributes)
+	start := time.Now()
+	solution, err := s.solver.Solve(options)
+
+	result := SolveResult{
+		Duration: time.Since(start),
+		Err:      err,
+		Gap:      math.NaN(),
+		Status:   "error",
+	}
+	if err == nil && solution != nil {
+		result.Status = solutionStatus(solution)
+		result.HasValues = solution.HasValues()
+		if result.HasValues {
+			result.ObjectiveValue = solution.ObjectiveValue()
+		}
+		if gap, ok := RelativeGap(solution); ok {
+			result.Gap = gap
+		}
+	}
+	end(result)
+
+	return solution, err
+}
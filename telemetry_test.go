@@ -0,0 +1,108 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// boundSolution is a testSolution with a best bound.
+type boundSolution struct {
+	*testSolution
+	bound float64
+}
+
+func (s *boundSolution) BestBound() float64 {
+	return s.bound
+}
+
+type testObserver struct {
+	attributes []mip.SolveAttributes
+	results    []mip.SolveResult
+}
+
+func (o *testObserver) StartSolve(attributes mip.SolveAttributes) func(mip.SolveResult) {
+	o.attributes = append(o.attributes, attributes)
+	return func(result mip.SolveResult) {
+		o.results = append(o.results, result)
+	}
+}
+
+func TestObserveSolverFactory(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewInt(0, 10)
+	c := model.NewConstraint(mip.LessThanOrEqual, 5)
+	c.NewTerm(1, x)
+	c.NewTerm(1, y)
+
+	solution := newTestSolution(4.0, map[mip.Var]float64{x: 2, y: 2})
+	solver := &testSolver{}
+	observer := &testObserver{}
+	factory := mip.ObserveSolverFactory("test", solver.factory(), observer)
+
+	solver.solution = solution
+	observed, err := factory(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := observed.Solve(mip.SolveOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	solver.solution, solver.err = nil, errors.New("failed")
+	if _, err := observed.Solve(mip.SolveOptions{}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	want := mip.SolveAttributes{Provider: "test", Cols: 2, Rows: 1, NonZeros: 2}
+	if len(observer.attributes) != 2 || observer.attributes[0] != want {
+		t.Errorf("got attributes %v, want %v", observer.attributes, want)
+	}
+	first, second := observer.results[0], observer.results[1]
+	if first.Status != "optimal" || !first.HasValues ||
+		first.ObjectiveValue != 4 || !math.IsNaN(first.Gap) {
+		t.Errorf("unexpected result %+v", first)
+	}
+	if second.Status != "error" || second.Err == nil {
+		t.Errorf("unexpected result %+v", second)
+	}
+}
+
+func TestObserveSolverFactoryUnwrap(t *testing.T) {
+	model := mip.NewModel()
+	solver := &interruptibleSolver{interrupted: make(chan struct{})}
+	factory := mip.ObserveSolverFactory(
+		"test",
+		func(mip.Model) (mip.Solver, error) { return solver, nil },
+		&testObserver{},
+	)
+	observed, err := factory(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	interrupter, ok := mip.UnwrapSolver(observed).(mip.Interrupter)
+	if !ok {
+		t.Fatal("observed solver does not unwrap to an interrupter")
+	}
+	interrupter.Interrupt()
+	select {
+	case <-solver.interrupted:
+	default:
+		t.Error("solver not interrupted")
+	}
+}
+
+func TestRelativeGap(t *testing.T) {
+	solution := newTestSolution(4.0, nil)
+	if _, ok := mip.RelativeGap(solution); ok {
+		t.Errorf("expected no gap for a solution without bound")
+	}
+	gap, ok := mip.RelativeGap(&boundSolution{testSolution: solution, bound: 3.0})
+	if !ok || gap != 0.25 {
+		t.Errorf("got gap %v, %v, want 0.25, true", gap, ok)
+	}
+}
@@ -0,0 +1,62 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+// toleranceParameters maps the tolerances to the names of the parameters of
+// the back-ends, in the order integrality, primal feasibility, dual
+// feasibility and optimality.
+var toleranceParameters = map[SolverProvider][4]string{
+	"highs": {
+		"mip_feasibility_tolerance",
+		"primal_feasibility_tolerance",
+		"dual_feasibility_tolerance",
+		"ipm_optimality_tolerance",
+	},
+	"xpress": {
+		"MIPTOL",
+		"FEASTOL",
+		"OPTIMALITYTOL",
+		"BARGAPSTOP",
+	},
+}
+
+// ToleranceControlOptions returns the tolerances which are set, i.e. not 0,
+// as control options of provider. Back-ends apply them the same way as the
+// float control options. Returns false if the parameters of provider are not
+// known.
+func ToleranceControlOptions(
+	provider SolverProvider,
+	tolerances ToleranceOptions,
+) ([]TypedControlOption[float64], bool) {
+	names, ok := toleranceParameters[provider]
+	if !ok {
+		return nil, false
+	}
+	values := [4]float64{
+		tolerances.Integrality,
+		tolerances.PrimalFeasibility,
+		tolerances.DualFeasibility,
+		tolerances.Optimality,
+	}
+	options := make([]TypedControlOption[float64], 0, len(values))
+	for i, value := range values {
+		if value == 0 {
+			continue
+		}
+		options = append(options, TypedControlOption[float64]{
+			Name:  names[i],
+			Value: value,
+		})
+	}
+	return options, true
+}
+
+// ToleranceSolution is implemented by solutions which report the tolerances
+// the solver actually used, including the defaults of the solver for
+// tolerances which have not been set. Use a type assertion to check whether
+// a solution provides them.
+type ToleranceSolution interface {
+	Solution
+	// Tolerances returns the tolerances used by the solver.
+	Tolerances() ToleranceOptions
+}
@@ -0,0 +1,176 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "fmt"
+
+// ErrTranslateNotSupported is returned by Translate for solvers which do
+// not implement Translator. It is an ErrUnsupportedFeature.
+var ErrTranslateNotSupported = NewKindError(
+	ErrUnsupportedFeature,
+	"translation without solving not supported",
+)
+
+// BackendModel describes the model and the parameters a solver hands to its
+// back-end, see Translate.
+type BackendModel struct {
+	// Provider is the back-end the model is translated for.
+	Provider SolverProvider `json:"provider"`
+	// Rows is the number of constraints.
+	Rows int `json:"rows"`
+	// Columns is the number of vars.
+	Columns int `json:"columns"`
+	// NonZeros is the number of non-zero coefficients of the constraints.
+	NonZeros int `json:"non_zeros"`
+	// Continuous, Integer and Binary are the numbers of vars per type.
+	Continuous int `json:"continuous"`
+	Integer    int `json:"integer"`
+	Binary     int `json:"binary"`
+	// ObjectiveNonZeros is the number of non-zero linear coefficients of the
+	// objective, 0 if the objective is dropped, see
+	// SolveOptions.FeasibilityOnly.
+	ObjectiveNonZeros int `json:"objective_non_zeros"`
+	// QuadraticNonZeros is the number of non-zero quadratic coefficients of
+	// the objective.
+	QuadraticNonZeros int `json:"quadratic_non_zeros"`
+	// Maximize is true if the objective is maximized.
+	Maximize bool `json:"maximize"`
+	// Parameters are the parameters set on the back-end, in the order they
+	// are applied.
+	Parameters TypedControlOptions `json:"parameters"`
+}
+
+// Translator is implemented by solvers which translate their model without
+// solving it, e.g. to assert in tests that options and model features are
+// mapped as expected.
+type Translator interface {
+	// Translate returns the model and parameters the invoking solver would
+	// hand to its back-end when solving with options, without solving.
+	Translate(options SolveOptions) (BackendModel, error)
+}
+
+// Translate returns the model and parameters solver would hand to its
+// back-end when solving with options, see Translator. Returns an error
+// wrapping ErrTranslateNotSupported if solver does not implement
+// Translator.
+//
+//	translated, err := mip.Translate(solver, options)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(translated.Rows, translated.Parameters.Int)
+func Translate(solver Solver, options SolveOptions) (BackendModel, error) {
+	translator, ok := UnwrapSolver(solver).(Translator)
+	if !ok {
+		return BackendModel{}, fmt.Errorf("%w: solver %T", ErrTranslateNotSupported, solver)
+	}
+	return translator.Translate(withProviderDefaults(solver, options))
+}
+
+// NewBackendModel returns the counts of model and the parameters derived
+// from options for provider. The parameters are the limits, tolerances and
+// feasibility-only options, see LimitControlOptions, ToleranceControlOptions
+// and FeasibilityOnlyControlOptions, followed by the control options, which
+// take precedence. Back-ends implementing Translator start from it and add
+// their own parameters, e.g. the time limit. NonZeros counts the vars of each
+// constraint with a non-zero sum of coefficients, as loaded by BuildMatrix.
+// Returns an error wrapping ErrUnsupportedFeature if options set limits,
+// tolerances or feasibility only and their parameters are not known for
+// provider.
+func NewBackendModel(
+	provider SolverProvider,
+	model Model,
+	options SolveOptions,
+) (BackendModel, error) {
+	controls, err := options.Control.ToTyped()
+	if err != nil {
+		return BackendModel{}, err
+	}
+
+	backend := BackendModel{
+		Provider: provider,
+		Rows:     len(model.Constraints()),
+		Columns:  len(model.Vars()),
+		Maximize: model.Objective().IsMaximize(),
+		Parameters: TypedControlOptions{
+			Bool:   []TypedControlOption[bool]{},
+			Float:  []TypedControlOption[float64]{},
+			Int:    []TypedControlOption[int]{},
+			String: []TypedControlOption[string]{},
+		},
+	}
+	for _, v := range model.Vars() {
+		switch v.Type() {
+		case Continuous:
+			backend.Continuous++
+		case Integer:
+			backend.Integer++
+		case Binary:
+			backend.Binary++
+		}
+	}
+	coefficients := make(map[int]float64)
+	for _, c := range model.Constraints() {
+		backend.NonZeros += rowNonZeros(c, coefficients)
+	}
+	if !options.FeasibilityOnly {
+		backend.ObjectiveNonZeros = countNonZeros(model.Objective().Terms())
+		for _, t := range model.Objective().QuadraticTerms() {
+			if t.Coefficient() != 0 {
+				backend.QuadraticNonZeros++
+			}
+		}
+	}
+
+	parameters := &backend.Parameters
+	limits, ok := LimitControlOptions(provider, options.Limits)
+	if !ok && options.Limits != (LimitOptions{}) {
+		return BackendModel{}, fmt.Errorf("%w: limits for provider %q", ErrUnsupportedFeature, provider)
+	}
+	parameters.Int = append(parameters.Int, limits...)
+	if options.FeasibilityOnly {
+		feasibility, ok := FeasibilityOnlyControlOptions(provider)
+		if !ok {
+			return BackendModel{}, fmt.Errorf("%w: feasibility only for provider %q", ErrUnsupportedFeature, provider)
+		}
+		parameters.Int = append(parameters.Int, feasibility...)
+	}
+	tolerances, ok := ToleranceControlOptions(provider, options.Tolerances)
+	if !ok && options.Tolerances != (ToleranceOptions{}) {
+		return BackendModel{}, fmt.Errorf("%w: tolerances for provider %q", ErrUnsupportedFeature, provider)
+	}
+	parameters.Float = append(parameters.Float, tolerances...)
+	parameters.Bool = append(parameters.Bool, controls.Bool...)
+	parameters.Float = append(parameters.Float, controls.Float...)
+	parameters.Int = append(parameters.Int, controls.Int...)
+	parameters.String = append(parameters.String, controls.String...)
+	return backend, nil
+}
+
+// rowNonZeros returns the number of vars of c with a non-zero sum of
+// coefficients, like the row of c in BuildMatrix. coefficients is scratch
+// space, it is cleared first.
+func rowNonZeros(c Constraint, coefficients map[int]float64) int {
+	clear(coefficients)
+	eachTerm(c, func(index int, coefficient float64) {
+		coefficients[index] += coefficient
+	})
+	count := 0
+	for _, coefficient := range coefficients {
+		if coefficient != 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// countNonZeros returns the number of terms with a non-zero coefficient.
+func countNonZeros(terms Terms) int {
+	count := 0
+	for _, t := range terms {
+		if t.Coefficient() != 0 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,141 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+// translatingSolver is a Solver which translates its model for HiGHS
+// without solving it.
+type translatingSolver struct {
+	testSolver
+	model mip.Model
+}
+
+func (s *translatingSolver) Translate(options mip.SolveOptions) (mip.BackendModel, error) {
+	return mip.NewBackendModel("highs", s.model, options)
+}
+
+func TestTranslate(t *testing.T) {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	x := model.NewFloat(0, 10)
+	y := model.NewInt(0, 5)
+	z := model.NewBool()
+	c := model.NewConstraint(mip.LessThanOrEqual, 8)
+	c.NewTerm(1, x)
+	c.NewTerm(2, y)
+	c.NewTerm(0, z)
+	model.NewConstraint(mip.GreaterThanOrEqual, 1).NewTerm(1, z)
+	model.Objective().NewTerm(1, x)
+	model.Objective().NewTerm(3, z)
+
+	options := mip.SolveOptions{}
+	options.Limits.Nodes = 100
+	options.Tolerances.Integrality = 1e-6
+	options.Control.Int = "threads=4"
+	translated, err := mip.Translate(&translatingSolver{model: model}, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := mip.BackendModel{
+		Provider:          "highs",
+		Rows:              2,
+		Columns:           3,
+		NonZeros:          3,
+		Continuous:        1,
+		Integer:           1,
+		Binary:            1,
+		ObjectiveNonZeros: 2,
+		Maximize:          true,
+		Parameters: mip.TypedControlOptions{
+			Bool: []mip.TypedControlOption[bool]{},
+			Float: []mip.TypedControlOption[float64]{
+				{Name: "mip_feasibility_tolerance", Value: 1e-6},
+			},
+			Int: []mip.TypedControlOption[int]{
+				{Name: "mip_max_nodes", Value: 100},
+				{Name: "threads", Value: 4},
+			},
+			String: []mip.TypedControlOption[string]{},
+		},
+	}
+	if !reflect.DeepEqual(translated, want) {
+		t.Errorf("got %+v, want %+v", translated, want)
+	}
+
+	// The objective is dropped when only feasibility is of interest.
+	options = mip.SolveOptions{}
+	options.SetFeasibilityOnly(true)
+	translated, err = mip.Translate(&translatingSolver{model: model}, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translated.ObjectiveNonZeros != 0 {
+		t.Errorf("got %d objective non-zeros, want 0", translated.ObjectiveNonZeros)
+	}
+	wantInt := []mip.TypedControlOption[int]{{Name: "mip_max_improving_sols", Value: 1}}
+	if !reflect.DeepEqual(translated.Parameters.Int, wantInt) {
+		t.Errorf("got int parameters %v, want %v", translated.Parameters.Int, wantInt)
+	}
+
+	options = mip.SolveOptions{}
+	options.Control.Int = "threads=four"
+	if _, err := mip.Translate(&translatingSolver{model: model}, options); err == nil {
+		t.Error("want an error for a malformed control option")
+	}
+}
+
+func TestTranslateNotSupported(t *testing.T) {
+	_, err := mip.Translate(&testSolver{}, mip.SolveOptions{})
+	if !errors.Is(err, mip.ErrTranslateNotSupported) {
+		t.Errorf("got error %v, want %v", err, mip.ErrTranslateNotSupported)
+	}
+}
+
+func TestNewBackendModelMergedTerms(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewFloat(0, 10)
+	c := model.NewConstraint(mip.LessThanOrEqual, 8)
+	c.NewTerm(1, x)
+	c.NewTerm(2, x)
+	c.NewTerm(1, y)
+	c.NewTerm(-1, y)
+
+	translated, err := mip.NewBackendModel("highs", model, mip.SolveOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matrix, err := mip.BuildMatrix(model, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translated.NonZeros != 1 || len(matrix.Values) != 1 {
+		t.Errorf("got %d non-zeros and %d matrix values, want 1", translated.NonZeros, len(matrix.Values))
+	}
+}
+
+func TestNewBackendModelUnknownProvider(t *testing.T) {
+	model := mip.NewModel()
+	if _, err := mip.NewBackendModel("unknown", model, mip.SolveOptions{}); err != nil {
+		t.Errorf("got error %v without limits and tolerances", err)
+	}
+	limits := mip.SolveOptions{}
+	limits.Limits.Nodes = 100
+	tolerances := mip.SolveOptions{}
+	tolerances.Tolerances.Integrality = 1e-6
+	feasibility := mip.SolveOptions{}
+	feasibility.SetFeasibilityOnly(true)
+	for _, options := range []mip.SolveOptions{limits, tolerances, feasibility} {
+		_, err := mip.NewBackendModel("unknown", model, options)
+		if !errors.Is(err, mip.ErrUnsupportedFeature) {
+			t.Errorf("got error %v for options %+v, want %v", err, options, mip.ErrUnsupportedFeature)
+		}
+	}
+}
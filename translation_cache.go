@@ -0,0 +1,128 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"container/list"
+	"sync"
+)
+
+// TranslationCache caches back-end translations of models, such as the
+// Matrix of BuildMatrix or a native model, keyed by ModelHash. Services
+// which solve identical models repeatedly, e.g. with different time
+// limits, skip the translation of models they have seen before. Back-ends
+// use it in their solver factories:
+//
+//	var cache = mip.NewTranslationCache[*mip.Matrix](64)
+//
+//	func NewSolver(model mip.Model) (mip.Solver, error) {
+//		matrix, err := cache.Get(model, func(model mip.Model) (*mip.Matrix, error) {
+//			return mip.BuildMatrix(model, 0)
+//		})
+//		...
+//	}
+//
+// Cached translations are shared by all solvers of identical models, they
+// must not be changed by a solve. Computing the hash reads the whole model,
+// which is cheaper than most translations but not free. The methods of a
+// cache are safe for concurrent use, concurrent misses of the same model may
+// translate it more than once.
+type TranslationCache[T any] struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	// recent holds the entries from the most to the least recently used.
+	recent *list.List
+	hits   int
+	misses int
+}
+
+// TranslationCacheStats are statistics of a TranslationCache.
+type TranslationCacheStats struct {
+	// Hits is the number of translations taken from the cache.
+	Hits int `json:"hits"`
+	// Misses is the number of translations which were not cached.
+	Misses int `json:"misses"`
+	// Entries is the number of cached translations.
+	Entries int `json:"entries"`
+}
+
+// translationEntry is a cached translation.
+type translationEntry[T any] struct {
+	hash        string
+	translation T
+}
+
+// NewTranslationCache returns a cache which keeps the capacity most
+// recently used translations. Panics if capacity is not positive.
+func NewTranslationCache[T any](capacity int) *TranslationCache[T] {
+	if capacity <= 0 {
+		panic("mip: NewTranslationCache with non-positive capacity")
+	}
+	return &TranslationCache[T]{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		recent:   list.New(),
+	}
+}
+
+// Get returns the cached translation of model, or translates model with
+// translate and caches the result. Errors of translate are returned and not
+// cached.
+func (c *TranslationCache[T]) Get(
+	model Model,
+	translate func(model Model) (T, error),
+) (T, error) {
+	hash := ModelHash(model)
+	c.mutex.Lock()
+	if element, ok := c.entries[hash]; ok {
+		c.recent.MoveToFront(element)
+		c.hits++
+		c.mutex.Unlock()
+		return element.Value.(*translationEntry[T]).translation, nil
+	}
+	c.misses++
+	c.mutex.Unlock()
+
+	translation, err := translate(model)
+	if err != nil {
+		return translation, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.entries[hash]; ok {
+		// Translated concurrently, the cached translation is kept.
+		c.recent.MoveToFront(element)
+		return translation, nil
+	}
+	c.entries[hash] = c.recent.PushFront(&translationEntry[T]{
+		hash:        hash,
+		translation: translation,
+	})
+	if c.recent.Len() > c.capacity {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.entries, oldest.Value.(*translationEntry[T]).hash)
+	}
+	return translation, nil
+}
+
+// Stats returns the statistics of the invoking cache.
+func (c *TranslationCache[T]) Stats() TranslationCacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return TranslationCacheStats{
+		Hits:    c.hits,
+		Misses:  c.misses,
+		Entries: c.recent.Len(),
+	}
+}
+
+// Clear removes all cached translations.
+func (c *TranslationCache[T]) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	clear(c.entries)
+	c.recent.Init()
+}
@@ -0,0 +1,86 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"errors"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func newCachedModel(capacity float64) mip.Model {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	model.NewConstraint(mip.LessThanOrEqual, capacity).NewTerm(1, x)
+	model.Objective().NewTerm(1, x)
+	return model
+}
+
+func TestTranslationCache(t *testing.T) {
+	cache := mip.NewTranslationCache[*mip.Matrix](2)
+	translations := 0
+	translate := func(model mip.Model) (*mip.Matrix, error) {
+		translations++
+		return mip.BuildMatrix(model, 1)
+	}
+
+	first, err := cache.Get(newCachedModel(5), translate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An identical model is not translated again.
+	second, err := cache.Get(newCachedModel(5), translate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second != first || translations != 1 {
+		t.Errorf("got %d translations, want the cached one", translations)
+	}
+	if _, err := cache.Get(newCachedModel(6), translate); err != nil {
+		t.Fatal(err)
+	}
+	if translations != 2 {
+		t.Errorf("got %d translations, want 2", translations)
+	}
+
+	// The least recently used translation is evicted.
+	if _, err := cache.Get(newCachedModel(5), translate); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Get(newCachedModel(7), translate); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Get(newCachedModel(6), translate); err != nil {
+		t.Fatal(err)
+	}
+	if translations != 4 {
+		t.Errorf("got %d translations, want 4", translations)
+	}
+
+	want := mip.TranslationCacheStats{Hits: 2, Misses: 4, Entries: 2}
+	if stats := cache.Stats(); stats != want {
+		t.Errorf("got stats %+v, want %+v", stats, want)
+	}
+	cache.Clear()
+	if stats := cache.Stats(); stats.Entries != 0 {
+		t.Errorf("got %d entries after clear, want 0", stats.Entries)
+	}
+}
+
+func TestTranslationCacheError(t *testing.T) {
+	cache := mip.NewTranslationCache[int](1)
+	failure := errors.New("translation failed")
+	_, err := cache.Get(newCachedModel(5), func(mip.Model) (int, error) {
+		return 0, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("got error %v, want %v", err, failure)
+	}
+	value, err := cache.Get(newCachedModel(5), func(mip.Model) (int, error) {
+		return 1, nil
+	})
+	if err != nil || value != 1 {
+		t.Errorf("got %d, %v, want the failed translation to be retried", value, err)
+	}
+}
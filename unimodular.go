@@ -0,0 +1,154 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import "math"
+
+// UnimodularityReport is the result of DetectTotalUnimodularity.
+type UnimodularityReport struct {
+	// TotallyUnimodular is true if the constraint matrix is proven to be
+	// totally unimodular.
+	TotallyUnimodular bool
+	// IntegralData is true if all right-hand sides and finite bounds are
+	// integral.
+	IntegralData bool
+	// ConflictingVars are the vars whose columns violate the sufficient
+	// condition, see DetectTotalUnimodularity. The matrix is near totally
+	// unimodular if there are few, e.g. side constraints of an assignment
+	// model, and fixing or branching on them first can pay off.
+	ConflictingVars Vars
+}
+
+// CanRelax returns true if the LP relaxation of the model has integral
+// optimal vertices, so integrality of int and bool vars can be relaxed, see
+// Model.SetVarType, and the model solved as an LP.
+func (r UnimodularityReport) CanRelax() bool {
+	return r.TotallyUnimodular && r.IntegralData
+}
+
+// IsTU returns true if the constraint matrix of model is proven to be totally
+// unimodular, see DetectTotalUnimodularity.
+func IsTU(model Model) bool {
+	return DetectTotalUnimodularity(model).TotallyUnimodular
+}
+
+// DetectTotalUnimodularity checks a sufficient condition for the constraint
+// matrix of model to be totally unimodular: all coefficients are 1 or -1,
+// every column has at most two of them and the rows can be split into two
+// sets such that the two entries of a column are in different sets if they
+// have the same sign and in the same set otherwise. It holds for network
+// flow, bipartite matching, assignment and transportation models. Matrices
+// for which the condition fails can still be totally unimodular, e.g.
+// interval matrices.
+func DetectTotalUnimodularity(model Model) UnimodularityReport {
+	vars := model.Vars()
+	constraints := model.Constraints()
+	report := UnimodularityReport{IntegralData: true}
+	for _, c := range constraints {
+		if !isIntegral(c.RightHandSide()) {
+			report.IntegralData = false
+		}
+	}
+
+	sides := newParityUnion(len(constraints))
+	for i, column := range unimodularColumns(constraints, len(vars)) {
+		lower, upper := bounds(vars[i])
+		if !isIntegralBound(lower) || !isIntegralBound(upper) {
+			report.IntegralData = false
+		}
+		if !sides.addColumn(column) {
+			report.ConflictingVars = append(report.ConflictingVars, vars[i])
+		}
+	}
+	report.TotallyUnimodular = len(report.ConflictingVars) == 0
+	return report
+}
+
+// unimodularColumns returns the non-zeros of the constraint matrix of
+// constraints by var index.
+func unimodularColumns(constraints Constraints, vars int) [][]unimodularEntry {
+	columns := make([][]unimodularEntry, vars)
+	for i, c := range constraints {
+		for _, t := range c.Terms() {
+			if t.Coefficient() == 0 {
+				continue
+			}
+			index := t.Var().Index()
+			columns[index] = append(columns[index], unimodularEntry{
+				row:         i,
+				coefficient: t.Coefficient(),
+			})
+		}
+	}
+	return columns
+}
+
+// unimodularEntry is a non-zero of a column of the constraint matrix.
+type unimodularEntry struct {
+	row         int
+	coefficient float64
+}
+
+func isIntegralBound(bound float64) bool {
+	return math.IsInf(bound, 0) || isIntegral(bound)
+}
+
+// parityUnion is a union-find of rows which tracks for each row whether it
+// is in the same set as the root of its tree.
+type parityUnion struct {
+	parent []int
+	// parity is true if a row is in the other set than its parent.
+	parity []bool
+}
+
+func newParityUnion(n int) *parityUnion {
+	u := &parityUnion{
+		parent: make([]int, n),
+		parity: make([]bool, n),
+	}
+	for i := range u.parent {
+		u.parent[i] = i
+	}
+	return u
+}
+
+// find returns the root of row and whether row is in the other set than the
+// root.
+func (u *parityUnion) find(row int) (int, bool) {
+	parity := false
+	for u.parent[row] != row {
+		parity = parity != u.parity[row]
+		row = u.parent[row]
+	}
+	return row, parity
+}
+
+// addColumn adds the requirement of column to the split of the rows.
+// Returns false, leaving the split unchanged, if the column has other
+// coefficients than 1 and -1, more than two of them, or contradicts the
+// requirements of earlier columns.
+func (u *parityUnion) addColumn(column []unimodularEntry) bool {
+	for _, entry := range column {
+		if math.Abs(entry.coefficient) != 1 {
+			return false
+		}
+	}
+	switch len(column) {
+	case 0, 1:
+		return true
+	case 2:
+	default:
+		return false
+	}
+
+	// Entries of the same sign must be in different sets.
+	different := column[0].coefficient == column[1].coefficient
+	root0, parity0 := u.find(column[0].row)
+	root1, parity1 := u.find(column[1].row)
+	if root0 == root1 {
+		return (parity0 != parity1) == different
+	}
+	u.parent[root1] = root0
+	u.parity[root1] = parity0 != parity1 != different
+	return true
+}
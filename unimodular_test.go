@@ -0,0 +1,58 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestDetectTotalUnimodularity(t *testing.T) {
+	// Assignment of 3 workers to 3 jobs.
+	model := mip.NewModel()
+	workers := make([]mip.Constraint, 3)
+	jobs := make([]mip.Constraint, 3)
+	for i := range workers {
+		workers[i] = model.NewConstraint(mip.Equal, 1)
+		jobs[i] = model.NewConstraint(mip.Equal, 1)
+	}
+	for i := range workers {
+		for j := range jobs {
+			x := model.NewBool()
+			workers[i].NewTerm(1, x)
+			jobs[j].NewTerm(1, x)
+		}
+	}
+
+	report := mip.DetectTotalUnimodularity(model)
+	if !report.TotallyUnimodular || !report.CanRelax() || !mip.IsTU(model) {
+		t.Errorf("assignment model: got %+v, want totally unimodular", report)
+	}
+
+	// A side constraint with a coefficient of 2 on one var.
+	budget := model.NewConstraint(mip.LessThanOrEqual, 3.5)
+	budget.NewTerm(2, model.Vars()[4])
+	report = mip.DetectTotalUnimodularity(model)
+	if report.TotallyUnimodular || report.IntegralData {
+		t.Errorf("assignment with budget: got %+v, want neither", report)
+	}
+	if len(report.ConflictingVars) != 1 || report.ConflictingVars[0].Index() != 4 {
+		t.Errorf("got conflicting vars %v, want [B4]", report.ConflictingVars)
+	}
+
+	// Edges of a triangle, an odd cycle, in vertex rows.
+	triangle := mip.NewModel()
+	vertices := make([]mip.Constraint, 3)
+	for i := range vertices {
+		vertices[i] = triangle.NewConstraint(mip.LessThanOrEqual, 1)
+	}
+	for i := range vertices {
+		edge := triangle.NewBool()
+		vertices[i].NewTerm(1, edge)
+		vertices[(i+1)%3].NewTerm(1, edge)
+	}
+	if mip.IsTU(triangle) {
+		t.Errorf("triangle is totally unimodular")
+	}
+}
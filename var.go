@@ -4,6 +4,22 @@ package mip
 
 import (
 	"fmt"
+	"math"
+)
+
+// VarType is the type of a var.
+type VarType int64
+
+// Types of a Var.
+const (
+	// Continuous vars take any value between their bounds, see
+	// Model.NewFloat.
+	Continuous VarType = iota
+	// Integer vars take integer values between their bounds, see
+	// Model.NewInt.
+	Integer
+	// Binary vars take the values zero and one, see Model.NewBool.
+	Binary
 )
 
 // Var represents the entities on which the solver has to make a decision
@@ -15,6 +31,21 @@ import (
 // (0, 1, 2, ...)
 // Bool vars can take two values, zero or one.
 type Var interface {
+	// Attr returns the value of the attribute key of the invoking var. The
+	// second return value is false if the attribute has not been set.
+	Attr(key string) (any, bool)
+	// Fix pins the invoking var to value until Unfix is invoked. The fix is
+	// tracked separately from the bounds of the var, LowerBound and
+	// UpperBound are not changed. Back-ends use value as both bounds of a
+	// fixed var. Fixing a var that is already fixed replaces the value.
+	// Panics if value is NaN.
+	Fix(value float64)
+	// FixedValue returns the value the invoking var is fixed to. The second
+	// return value is false if the var is not fixed.
+	FixedValue() (float64, bool)
+	// Group returns the group assigned to the var, empty if the var has not
+	// been assigned to a group.
+	Group() string
 	// Index is a unique number assigned to the var. The index corresponds
 	// to the location in the slice returned by Model.Variables().
 	Index() int
@@ -36,8 +67,28 @@ type Var interface {
 	// Name returns assigned name. If no name has been set it will return
 	// a unique auto-generated name.
 	Name() string
+	// SetAttr sets the attribute key of the invoking var to value.
+	// Attributes carry domain metadata, e.g. a customer ID, with the var into
+	// callbacks and solution reporting. A nil value removes the attribute.
+	SetAttr(key string, value any)
+	// SetGroup assigns the invoking var to group, used to aggregate values of
+	// vars in reports, see GroupSums. A var belongs to at most one group,
+	// assigning an empty group removes the var from its group.
+	SetGroup(group string)
 	// SetName assigns name to invoking var
 	SetName(name string)
+	// Type returns the type of the invoking var. Switching on the type
+	// instead of using IsBool, IsFloat and IsInt lets linters such as
+	// exhaustive check that all types are handled:
+	//
+	//	switch v.Type() {
+	//	case mip.Continuous:
+	//	case mip.Integer:
+	//	case mip.Binary:
+	//	}
+	Type() VarType
+	// Unfix removes the fix of the invoking var, see Fix.
+	Unfix()
 	// UpperBound returns the upperBound of the invoking variable.
 	//
 	// Upper bounds of variables are limited by the upper bounds of the
@@ -66,6 +117,7 @@ type Int interface {
 // one.
 type Bool interface {
 	Int
+	Expr
 	ensureBool() bool
 }
 
@@ -81,20 +133,36 @@ type floatVariable struct {
 	upperBound float64
 }
 
+func (f *floatVariable) Attr(key string) (any, bool) {
+	return f.model.attributes.get(f, key)
+}
+
+func (f *floatVariable) Fix(value float64) {
+	f.model.fixVar(f, value)
+}
+
+func (f *floatVariable) FixedValue() (float64, bool) {
+	return f.model.getFixedValue(f)
+}
+
+func (f *floatVariable) Group() string {
+	return f.model.getVarGroup(f)
+}
+
 func (f *floatVariable) Index() int {
 	return f.index
 }
 
 func (f *floatVariable) IsBool() bool {
-	return false
+	return f.Type() == Binary
 }
 
 func (f *floatVariable) IsFloat() bool {
-	return true
+	return f.Type() == Continuous
 }
 
 func (f *floatVariable) IsInt() bool {
-	return false
+	return f.Type() != Continuous
 }
 
 func (f *floatVariable) LowerBound() float64 {
@@ -105,10 +173,26 @@ func (f *floatVariable) Name() string {
 	return f.model.getVarName(f)
 }
 
+func (f *floatVariable) SetAttr(key string, value any) {
+	f.model.setAttr(f, key, value)
+}
+
+func (f *floatVariable) SetGroup(group string) {
+	f.model.setVarGroup(f, group)
+}
+
 func (f *floatVariable) SetName(name string) {
 	f.model.setVarName(f, name)
 }
 
+func (f *floatVariable) Type() VarType {
+	return f.model.getVarType(f, Continuous)
+}
+
+func (f *floatVariable) Unfix() {
+	f.model.unfixVar(f)
+}
+
 func (f *floatVariable) UpperBound() float64 {
 	return f.upperBound
 }
@@ -128,23 +212,42 @@ type intVariable struct {
 	upperBound int64
 }
 
+func (i *intVariable) Attr(key string) (any, bool) {
+	return i.model.attributes.get(i, key)
+}
+
+func (i *intVariable) Fix(value float64) {
+	i.model.fixVar(i, value)
+}
+
+func (i *intVariable) FixedValue() (float64, bool) {
+	return i.model.getFixedValue(i)
+}
+
+func (i *intVariable) Group() string {
+	return i.model.getVarGroup(i)
+}
+
 func (i *intVariable) Index() int {
 	return i.index
 }
 
 func (i *intVariable) IsBool() bool {
-	return false
+	return i.Type() == Binary
 }
 
 func (i *intVariable) IsFloat() bool {
-	return false
+	return i.Type() == Continuous
 }
 
 func (i *intVariable) IsInt() bool {
-	return true
+	return i.Type() != Continuous
 }
 
 func (i *intVariable) LowerBound() float64 {
+	if i.lowerBound == math.MinInt64 {
+		return math.Inf(-1)
+	}
 	return float64(i.lowerBound)
 }
 
@@ -152,11 +255,30 @@ func (i *intVariable) Name() string {
 	return i.model.getVarName(i)
 }
 
+func (i *intVariable) SetAttr(key string, value any) {
+	i.model.setAttr(i, key, value)
+}
+
+func (i *intVariable) SetGroup(group string) {
+	i.model.setVarGroup(i, group)
+}
+
 func (i *intVariable) SetName(name string) {
 	i.model.setVarName(i, name)
 }
 
+func (i *intVariable) Type() VarType {
+	return i.model.getVarType(i, Integer)
+}
+
+func (i *intVariable) Unfix() {
+	i.model.unfixVar(i)
+}
+
 func (i *intVariable) UpperBound() float64 {
+	if i.upperBound == math.MaxInt64 {
+		return math.Inf(1)
+	}
 	return float64(i.upperBound)
 }
 
@@ -173,20 +295,36 @@ type boolVariable struct {
 	variable
 }
 
+func (b *boolVariable) Attr(key string) (any, bool) {
+	return b.model.attributes.get(b, key)
+}
+
+func (b *boolVariable) Fix(value float64) {
+	b.model.fixVar(b, value)
+}
+
+func (b *boolVariable) FixedValue() (float64, bool) {
+	return b.model.getFixedValue(b)
+}
+
+func (b *boolVariable) Group() string {
+	return b.model.getVarGroup(b)
+}
+
 func (b *boolVariable) Index() int {
 	return b.index
 }
 
 func (b *boolVariable) IsBool() bool {
-	return true
+	return b.Type() == Binary
 }
 
 func (b *boolVariable) IsFloat() bool {
-	return false
+	return b.Type() == Continuous
 }
 
 func (b *boolVariable) IsInt() bool {
-	return true
+	return b.Type() != Continuous
 }
 
 func (b *boolVariable) LowerBound() float64 {
@@ -197,10 +335,26 @@ func (b *boolVariable) Name() string {
 	return b.model.getVarName(b)
 }
 
+func (b *boolVariable) SetAttr(key string, value any) {
+	b.model.setAttr(b, key, value)
+}
+
+func (b *boolVariable) SetGroup(group string) {
+	b.model.setVarGroup(b, group)
+}
+
 func (b *boolVariable) SetName(name string) {
 	b.model.setVarName(b, name)
 }
 
+func (b *boolVariable) Type() VarType {
+	return b.model.getVarType(b, Binary)
+}
+
+func (b *boolVariable) Unfix() {
+	b.model.unfixVar(b)
+}
+
 func (b *boolVariable) UpperBound() float64 {
 	return 1.0
 }
@@ -212,3 +366,19 @@ func (b *boolVariable) String() string {
 	}
 	return name
 }
+
+// bounds returns the bounds of v used to solve the model, which are the
+// fixed value if v is fixed.
+func bounds(v Var) (float64, float64) {
+	if value, ok := v.FixedValue(); ok {
+		return value, value
+	}
+	return v.LowerBound(), v.UpperBound()
+}
+
+// Infinity returns the value of a bound which does not limit a var, use
+// -Infinity() for a lower bound. Back-ends translate it to the infinity of
+// the solver instead of a large number.
+func Infinity() float64 {
+	return math.Inf(1)
+}
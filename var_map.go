@@ -0,0 +1,207 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+// VarMap is a family of vars indexed by domain keys, e.g. a bool var per
+// driver. Keys are kept in the order in which they have been given. A VarMap
+// is not modified after its creation and is safe for concurrent reads.
+type VarMap[K comparable, V Var] struct {
+	keys []K
+	vars map[K]V
+}
+
+// NewVarMap creates a var for each of keys using create, which adds the var
+// to a model and typically names it after the key. Duplicate keys create a
+// single var.
+//
+//	x := mip.NewVarMap(drivers, func(d Driver) mip.Bool {
+//		v := model.NewBool()
+//		v.SetName("x_" + d.ID)
+//		return v
+//	})
+func NewVarMap[K comparable, V Var](keys []K, create func(key K) V) VarMap[K, V] {
+	m := VarMap[K, V]{
+		keys: make([]K, 0, len(keys)),
+		vars: make(map[K]V, len(keys)),
+	}
+	for _, key := range keys {
+		if _, ok := m.vars[key]; ok {
+			continue
+		}
+		m.keys = append(m.keys, key)
+		m.vars[key] = create(key)
+	}
+	return m
+}
+
+// Get returns the var of key. Panics if the map has no var for key, as this
+// is a modeling error.
+func (m VarMap[K, V]) Get(key K) V {
+	v, ok := m.vars[key]
+	if !ok {
+		panic("var map has no var for key")
+	}
+	return v
+}
+
+// Lookup returns the var of key. The second return value is false if the map
+// has no var for key.
+func (m VarMap[K, V]) Lookup(key K) (V, bool) {
+	v, ok := m.vars[key]
+	return v, ok
+}
+
+// Keys returns a copy of the keys in creation order.
+func (m VarMap[K, V]) Keys() []K {
+	keys := make([]K, len(m.keys))
+	copy(keys, m.keys)
+	return keys
+}
+
+// Len returns the number of vars.
+func (m VarMap[K, V]) Len() int {
+	return len(m.keys)
+}
+
+// Each invokes f for each key and var in creation order.
+func (m VarMap[K, V]) Each(f func(key K, v V)) {
+	for _, key := range m.keys {
+		f(key, m.vars[key])
+	}
+}
+
+// Vars returns the vars in creation order.
+func (m VarMap[K, V]) Vars() Vars {
+	vars := make(Vars, len(m.keys))
+	for i, key := range m.keys {
+		vars[i] = m.vars[key]
+	}
+	return vars
+}
+
+// Values returns the values of the vars in solution by key. Returns nil if
+// solution has no values.
+func (m VarMap[K, V]) Values(solution Solution) map[K]float64 {
+	if !solution.HasValues() {
+		return nil
+	}
+	values := make(map[K]float64, len(m.keys))
+	for key, v := range m.vars {
+		values[key] = solution.Value(v)
+	}
+	return values
+}
+
+// VarMatrix is a family of vars indexed by two domain keys, e.g. a bool var
+// per driver and shift. A VarMatrix is not modified after its creation and is
+// safe for concurrent reads.
+type VarMatrix[R comparable, C comparable, V Var] struct {
+	rows []R
+	cols []C
+	vars map[R]map[C]V
+}
+
+// NewVarMatrix creates a var for each combination of rows and cols using
+// create. Duplicate keys create a single var.
+func NewVarMatrix[R comparable, C comparable, V Var](
+	rows []R,
+	cols []C,
+	create func(row R, col C) V,
+) VarMatrix[R, C, V] {
+	m := VarMatrix[R, C, V]{
+		rows: unique(rows),
+		cols: unique(cols),
+	}
+	m.vars = make(map[R]map[C]V, len(m.rows))
+	for _, row := range m.rows {
+		m.vars[row] = make(map[C]V, len(m.cols))
+		for _, col := range m.cols {
+			m.vars[row][col] = create(row, col)
+		}
+	}
+	return m
+}
+
+// Get returns the var of row and col. Panics if the matrix has no var for
+// row and col, as this is a modeling error.
+func (m VarMatrix[R, C, V]) Get(row R, col C) V {
+	v, ok := m.vars[row][col]
+	if !ok {
+		panic("var matrix has no var for row and col")
+	}
+	return v
+}
+
+// Lookup returns the var of row and col. The second return value is false if
+// the matrix has no var for row and col.
+func (m VarMatrix[R, C, V]) Lookup(row R, col C) (V, bool) {
+	v, ok := m.vars[row][col]
+	return v, ok
+}
+
+// Rows returns a copy of the row keys in creation order.
+func (m VarMatrix[R, C, V]) Rows() []R {
+	rows := make([]R, len(m.rows))
+	copy(rows, m.rows)
+	return rows
+}
+
+// Cols returns a copy of the column keys in creation order.
+func (m VarMatrix[R, C, V]) Cols() []C {
+	cols := make([]C, len(m.cols))
+	copy(cols, m.cols)
+	return cols
+}
+
+// Row returns the vars of row in column order, e.g. to sum over them.
+func (m VarMatrix[R, C, V]) Row(row R) Vars {
+	vars := make(Vars, 0, len(m.cols))
+	for _, col := range m.cols {
+		if v, ok := m.vars[row][col]; ok {
+			vars = append(vars, v)
+		}
+	}
+	return vars
+}
+
+// Col returns the vars of col in row order, e.g. to sum over them.
+func (m VarMatrix[R, C, V]) Col(col C) Vars {
+	vars := make(Vars, 0, len(m.rows))
+	for _, row := range m.rows {
+		if v, ok := m.vars[row][col]; ok {
+			vars = append(vars, v)
+		}
+	}
+	return vars
+}
+
+// Each invokes f for each combination of row and col in row-major order.
+func (m VarMatrix[R, C, V]) Each(f func(row R, col C, v V)) {
+	for _, row := range m.rows {
+		for _, col := range m.cols {
+			f(row, col, m.vars[row][col])
+		}
+	}
+}
+
+// Vars returns all vars in row-major order.
+func (m VarMatrix[R, C, V]) Vars() Vars {
+	vars := make(Vars, 0, len(m.rows)*len(m.cols))
+	m.Each(func(_ R, _ C, v V) {
+		vars = append(vars, v)
+	})
+	return vars
+}
+
+// unique returns keys without duplicates, keeping the first occurrence.
+func unique[K comparable](keys []K) []K {
+	seen := make(map[K]bool, len(keys))
+	result := make([]K, 0, len(keys))
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, key)
+		}
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestVarType(t *testing.T) {
+	model := mip.NewModel()
+	tests := []struct {
+		v    mip.Var
+		want mip.VarType
+	}{
+		{model.NewFloat(0, 1), mip.Continuous},
+		{model.NewInt(0, 5), mip.Integer},
+		{model.NewBool(), mip.Binary},
+	}
+	for _, tt := range tests {
+		if got := tt.v.Type(); got != tt.want {
+			t.Errorf("%v: got type %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestSetVarType(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewInt(0, 5)
+	b := model.NewBool()
+	c := model.NewConstraint(mip.LessThanOrEqual, 4)
+	term := c.NewTerm(2, x)
+
+	model.SetVarType(x, mip.Continuous)
+	model.SetVarType(b, mip.Continuous)
+	if x.Type() != mip.Continuous || !x.IsFloat() || x.IsInt() {
+		t.Errorf("x was not relaxed")
+	}
+	if b.IsBool() || b.UpperBound() != 1 {
+		t.Errorf("b was not relaxed to [0, 1]")
+	}
+	if term.Var() != x || c.Terms()[0].Var() != x {
+		t.Errorf("relaxing x changed the var of its terms")
+	}
+	if copied := model.Copy().Vars(); !copied[0].IsFloat() || copied[0].UpperBound() != 5 {
+		t.Errorf("copy did not create a float var for x")
+	}
+
+	model.SetVarType(x, mip.Integer)
+	model.SetVarType(b, mip.Binary)
+	if x.Type() != mip.Integer || !b.IsBool() || !b.IsInt() {
+		t.Errorf("types were not restored")
+	}
+	model.SetVarType(x, mip.Binary)
+	if !x.IsBool() || !x.IsInt() {
+		t.Errorf("x is not binary")
+	}
+}
+
+func TestSetVarTypeUnknown(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 1)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetVarType with an unknown type did not panic")
+		}
+		if x.Type() != mip.Continuous {
+			t.Errorf("got type %v, want %v", x.Type(), mip.Continuous)
+		}
+	}()
+	model.SetVarType(x, mip.VarType(99))
+}
@@ -0,0 +1,193 @@
+// © 2019-present nextmv.io inc
+
+package mip
+
+import (
+	"math"
+)
+
+// Tolerances define how much a value may deviate before it is considered a
+// violation.
+type Tolerances struct {
+	// Feasibility is the absolute amount by which a constraint or a bound may
+	// be violated.
+	Feasibility float64 `json:"feasibility"`
+	// Integrality is the absolute amount by which the value of an int or bool
+	// var may deviate from the nearest integer.
+	Integrality float64 `json:"integrality"`
+	// Objective is the relative amount by which the recomputed objective
+	// value may deviate from the reported objective value. The deviation is
+	// relative to the maximum of 1 and the absolute reported value.
+	Objective float64 `json:"objective"`
+}
+
+// DefaultTolerances returns the tolerances used by most back-end solvers.
+func DefaultTolerances() Tolerances {
+	return Tolerances{
+		Feasibility: 1e-6,
+		Integrality: 1e-5,
+		Objective:   1e-6,
+	}
+}
+
+// ConstraintViolation reports a constraint which is not satisfied.
+type ConstraintViolation struct {
+	// Constraint which is violated.
+	Constraint Constraint
+	// Activity is the value of the left-hand side of the constraint.
+	Activity float64
+	// Violation is the amount by which the constraint is violated.
+	Violation float64
+}
+
+// VarViolation reports a var whose value is not within its bounds or which
+// is not integral while it should be.
+type VarViolation struct {
+	// Var which is violated.
+	Var Var
+	// Value of the var.
+	Value float64
+	// Violation is the amount by which the bound or the integrality
+	// requirement is violated.
+	Violation float64
+}
+
+// VerificationReport is the result of Verify.
+type VerificationReport struct {
+	// HasValues is true if the verified solution has values.
+	HasValues bool
+	// BoundViolations are the vars whose values are outside their bounds.
+	BoundViolations []VarViolation
+	// ConstraintViolations are the constraints which are violated.
+	ConstraintViolations []ConstraintViolation
+	// IntegralityViolations are the int and bool vars whose values are not
+	// integral.
+	IntegralityViolations []VarViolation
+	// ObjectiveValue is the recomputed objective value.
+	ObjectiveValue float64
+	// ReportedObjectiveValue is the objective value reported by the solution.
+	ReportedObjectiveValue float64
+	// ObjectiveMismatch is true if the recomputed objective value deviates
+	// from the reported objective value by more than the tolerance.
+	ObjectiveMismatch bool
+}
+
+// IsValid returns true if the solution has values, satisfies all bounds,
+// constraints and integrality requirements and reports the correct objective
+// value.
+func (r VerificationReport) IsValid() bool {
+	return r.HasValues &&
+		len(r.BoundViolations) == 0 &&
+		len(r.ConstraintViolations) == 0 &&
+		len(r.IntegralityViolations) == 0 &&
+		!r.ObjectiveMismatch
+}
+
+// Verify independently checks solution against model. It checks primal
+// feasibility of every bound and constraint, integrality of int and bool
+// vars, and recomputes the objective value. Use Verify to certify the output
+// of a solver before committing to it. Values, activities and objective
+// values which are NaN or infinite where a finite number is required are
+// violations.
+func Verify(
+	model Model,
+	solution Solution,
+	tolerances Tolerances,
+) VerificationReport {
+	report := VerificationReport{
+		HasValues:             solution != nil && solution.HasValues(),
+		BoundViolations:       []VarViolation{},
+		ConstraintViolations:  []ConstraintViolation{},
+		IntegralityViolations: []VarViolation{},
+	}
+	if !report.HasValues {
+		return report
+	}
+
+	for _, v := range model.Vars() {
+		value := solution.Value(v)
+		lower, upper := bounds(v)
+		violation := math.Max(lower-value, value-upper)
+		if exceeds(violation, tolerances.Feasibility) {
+			report.BoundViolations = append(
+				report.BoundViolations,
+				VarViolation{Var: v, Value: value, Violation: violation},
+			)
+		}
+		if v.IsInt() {
+			violation := math.Abs(value - math.Round(value))
+			if exceeds(violation, tolerances.Integrality) {
+				report.IntegralityViolations = append(
+					report.IntegralityViolations,
+					VarViolation{Var: v, Value: value, Violation: violation},
+				)
+			}
+		}
+	}
+
+	for _, c := range model.Constraints() {
+		activity := constraintActivity(c, solution.Value)
+		violation := constraintViolation(c, activity)
+		if exceeds(violation, tolerances.Feasibility) {
+			report.ConstraintViolations = append(
+				report.ConstraintViolations,
+				ConstraintViolation{
+					Constraint: c,
+					Activity:   activity,
+					Violation:  violation,
+				},
+			)
+		}
+	}
+
+	report.ObjectiveValue = objectiveValue(model.Objective(), solution.Value)
+	report.ReportedObjectiveValue = solution.ObjectiveValue()
+	report.ObjectiveMismatch = exceeds(
+		math.Abs(report.ObjectiveValue-report.ReportedObjectiveValue),
+		tolerances.Objective*math.Max(1, math.Abs(report.ReportedObjectiveValue)),
+	)
+
+	return report
+}
+
+// exceeds returns true if violation is NaN or greater than tolerance. A NaN
+// violation stems from a value or activity which is not finite.
+func exceeds(violation, tolerance float64) bool {
+	return math.IsNaN(violation) || violation > tolerance
+}
+
+// constraintActivity returns the value of the left-hand side of c given the
+// values of the vars.
+func constraintActivity(c Constraint, value func(Var) float64) float64 {
+	activity := 0.0
+	for _, t := range c.Terms() {
+		activity += t.Coefficient() * value(t.Var())
+	}
+	return activity
+}
+
+// constraintViolation returns the amount by which c is violated given the
+// activity of c. Returns 0 if c is satisfied.
+func constraintViolation(c Constraint, activity float64) float64 {
+	switch c.Sense() {
+	case LessThanOrEqual:
+		return math.Max(0, activity-c.RightHandSide())
+	case GreaterThanOrEqual:
+		return math.Max(0, c.RightHandSide()-activity)
+	default:
+		return math.Abs(activity - c.RightHandSide())
+	}
+}
+
+// objectiveValue returns the value of objective given the values of the
+// vars.
+func objectiveValue(objective Objective, value func(Var) float64) float64 {
+	objectiveValue := 0.0
+	for _, t := range objective.Terms() {
+		objectiveValue += t.Coefficient() * value(t.Var())
+	}
+	for _, t := range objective.QuadraticTerms() {
+		objectiveValue += t.Coefficient() * value(t.Var1()) * value(t.Var2())
+	}
+	return objectiveValue
+}
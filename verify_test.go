@@ -0,0 +1,130 @@
+// © 2019-present nextmv.io inc
+
+package mip_test
+
+import (
+	"math"
+	"testing"
+
+	mip "github.com/nextmv-io/go-mip"
+)
+
+func TestVerify(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0.0, 10.0)
+	y := model.NewInt(0, 5)
+	model.Objective().SetMaximize()
+	model.Objective().NewTerm(1.0, x)
+	model.Objective().NewTerm(2.0, y)
+	c := model.NewConstraint(mip.LessThanOrEqual, 8.0)
+	c.NewTerm(1.0, x)
+	c.NewTerm(1.0, y)
+
+	tolerances := mip.DefaultTolerances()
+
+	report := mip.Verify(
+		model,
+		newTestSolution(13.0, map[mip.Var]float64{x: 3.0, y: 5.0}),
+		tolerances,
+	)
+	if !report.IsValid() {
+		t.Errorf("expected valid report, got %+v", report)
+	}
+
+	report = mip.Verify(
+		model,
+		newTestSolution(14.0, map[mip.Var]float64{x: 4.0, y: 5.0}),
+		tolerances,
+	)
+	if len(report.ConstraintViolations) != 1 ||
+		report.ConstraintViolations[0].Violation != 1.0 {
+		t.Errorf("expected one violation of 1, got %+v",
+			report.ConstraintViolations,
+		)
+	}
+
+	report = mip.Verify(
+		model,
+		newTestSolution(9.0, map[mip.Var]float64{x: 2.0, y: 2.5}),
+		tolerances,
+	)
+	if len(report.IntegralityViolations) != 1 {
+		t.Errorf("expected one integrality violation, got %+v",
+			report.IntegralityViolations,
+		)
+	}
+	if !report.ObjectiveMismatch {
+		t.Errorf("expected objective mismatch, recomputed %v",
+			report.ObjectiveValue,
+		)
+	}
+
+	report = mip.Verify(
+		model,
+		newTestSolution(0.0, map[mip.Var]float64{x: -1.0}),
+		tolerances,
+	)
+	if len(report.BoundViolations) != 1 {
+		t.Errorf("expected one bound violation, got %+v",
+			report.BoundViolations,
+		)
+	}
+}
+
+func TestVerifyFixed(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0.0, 10.0)
+	x.Fix(2.0)
+
+	report := mip.Verify(
+		model,
+		newTestSolution(0.0, map[mip.Var]float64{x: 3.0}),
+		mip.DefaultTolerances(),
+	)
+	if len(report.BoundViolations) != 1 ||
+		report.BoundViolations[0].Violation != 1.0 {
+		t.Errorf("expected one bound violation of 1, got %+v",
+			report.BoundViolations,
+		)
+	}
+
+	x.Unfix()
+	report = mip.Verify(
+		model,
+		newTestSolution(0.0, map[mip.Var]float64{x: 3.0}),
+		mip.DefaultTolerances(),
+	)
+	if !report.IsValid() {
+		t.Errorf("expected valid report, got %+v", report)
+	}
+}
+
+func TestVerifyNaN(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewInt(0, 5)
+	model.Objective().NewTerm(1, x)
+	c := model.NewConstraint(mip.LessThanOrEqual, 8)
+	c.NewTerm(1, x)
+	c.NewTerm(1, y)
+
+	nan := math.NaN()
+	report := mip.Verify(
+		model,
+		newTestSolution(nan, map[mip.Var]float64{x: nan, y: nan}),
+		mip.DefaultTolerances(),
+	)
+	if report.IsValid() || len(report.BoundViolations) != 2 || len(report.IntegralityViolations) != 1 ||
+		len(report.ConstraintViolations) != 1 || !report.ObjectiveMismatch {
+		t.Errorf("got report %+v, want all checks violated", report)
+	}
+
+	report = mip.Verify(
+		model,
+		newTestSolution(nan, map[mip.Var]float64{x: 1, y: 1}),
+		mip.DefaultTolerances(),
+	)
+	if report.IsValid() || !report.ObjectiveMismatch {
+		t.Errorf("got report %+v, want an objective mismatch", report)
+	}
+}